@@ -0,0 +1,206 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/aas-hub-org/aashub/internal/logging"
+)
+
+// Page sizes of the lists of the AAS API.
+const (
+	defaultPageSize = 100
+	maxPageSize     = 1000
+)
+
+// Message is an entry of Result.
+type Message struct {
+	Code          string `json:"code"`
+	CorrelationId string `json:"correlationId,omitempty"`
+	MessageType   string `json:"messageType"`
+	Text          string `json:"text"`
+	Timestamp     string `json:"timestamp"`
+}
+
+// Result is the error body of the AAS API (IDTA-01002). AAS clients show its
+// messages rather than the status line.
+type Result struct {
+	Messages []Message `json:"messages"`
+}
+
+// PagingMetadata holds the cursor of the next page of a list; it is omitted
+// on the last page.
+type PagingMetadata struct {
+	Cursor string `json:"cursor,omitempty"`
+}
+
+// writeResult writes an error with one message per text. The request ID
+// serves as correlation ID.
+func writeResult(w http.ResponseWriter, r *http.Request, status int, texts ...string) {
+	result := Result{Messages: []Message{}}
+	timestamp := time.Now().UTC().Format(time.RFC3339)
+	for _, text := range texts {
+		result.Messages = append(result.Messages, Message{
+			Code:          strconv.Itoa(status),
+			CorrelationId: logging.RequestID(r.Context()),
+			MessageType:   "Error",
+			Text:          text,
+			Timestamp:     timestamp,
+		})
+	}
+	writeJSON(w, status, result)
+}
+
+// writeRepositoryError maps the errors of the shell and submodel
+// repositories to their status codes.
+func writeRepositoryError(w http.ResponseWriter, r *http.Request, err error) {
+	switch {
+	case errors.Is(err, repositories.ErrIdentifiableNotFound):
+		writeResult(w, r, http.StatusNotFound, err.Error())
+	case errors.Is(err, repositories.ErrIdentifiableExists):
+		writeResult(w, r, http.StatusConflict, err.Error())
+	case errors.Is(err, repositories.ErrNotOwner):
+		writeResult(w, r, http.StatusForbidden, err.Error())
+	default:
+		writeResult(w, r, http.StatusInternalServerError, "Internal server error")
+	}
+}
+
+// pathIdentifier decodes the identifier in the path parameter name, which the
+// AAS API encodes as base64url.
+func pathIdentifier(r *http.Request, name string) (string, error) {
+	id, err := decodeIdentifier(r.PathValue(name))
+	if err != nil {
+		return "", fmt.Errorf("%s is not a base64url encoded identifier", name)
+	}
+	return id, nil
+}
+
+// decodeIdentifier decodes a base64url encoded identifier. Padding is
+// optional.
+func decodeIdentifier(value string) (string, error) {
+	id, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err == nil && len(id) == 0 {
+		err = errors.New("empty identifier")
+	}
+	return string(id), err
+}
+
+// paging reads the limit and cursor query parameters of a list.
+func paging(r *http.Request) (limit int, cursor string, err error) {
+	limit = defaultPageSize
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return 0, "", fmt.Errorf("limit must be between 1 and %d", maxPageSize)
+		}
+	}
+	return limit, r.URL.Query().Get("cursor"), nil
+}
+
+// indexPaging reads the limit and cursor query parameters of a list that is
+// part of a stored document, e.g. the submodel references of a shell. The
+// cursor is the index of the first item of the page.
+func indexPaging(r *http.Request) (limit int, start int, err error) {
+	limit, cursor, err := paging(r)
+	if err != nil || cursor == "" {
+		return limit, 0, err
+	}
+	if start, err = strconv.Atoi(cursor); err != nil || start < 0 {
+		return 0, 0, errors.New("invalid cursor")
+	}
+	return limit, start, nil
+}
+
+// page returns up to limit items starting at index start, and the paging
+// metadata pointing to the next page.
+func page[T any](items []T, start int, limit int) ([]T, PagingMetadata) {
+	if start >= len(items) {
+		return []T{}, PagingMetadata{}
+	}
+	end := min(start+limit, len(items))
+	if end < len(items) {
+		return items[start:end], PagingMetadata{Cursor: strconv.Itoa(end)}
+	}
+	return items[start:end], PagingMetadata{}
+}
+
+// violationsError rejects a document or an update whose result violates the
+// metamodel constraints.
+type violationsError struct {
+	violations []string
+}
+
+func (e *violationsError) Error() string {
+	return strings.Join(e.violations, "; ")
+}
+
+// notFoundError is returned by updates for a missing part of a document,
+// e.g. a submodel element.
+type notFoundError struct {
+	text string
+}
+
+func (e *notFoundError) Error() string { return e.text }
+
+// conflictError is returned by updates that would duplicate a part of a
+// document, e.g. a reference or an idShort.
+type conflictError struct {
+	text string
+}
+
+func (e *conflictError) Error() string { return e.text }
+
+// validate checks the single identifiable in env against the metamodel
+// constraints. The paths of the violations are relative to prefix, e.g.
+// "$.submodels[0]".
+func validate(env *aas.Environment, prefix string) error {
+	violations := aas.Validate(env)
+	if len(violations) == 0 {
+		return nil
+	}
+	err := &violationsError{}
+	for _, violation := range violations {
+		violation.Path = "$" + strings.TrimPrefix(violation.Path, prefix)
+		err.violations = append(err.violations, violation.Error())
+	}
+	return err
+}
+
+// violationsOf turns violations, e.g. those of a descriptor, into a
+// violationsError, or returns nil if there are none.
+func violationsOf(violations []aas.Violation) error {
+	if len(violations) == 0 {
+		return nil
+	}
+	err := &violationsError{}
+	for _, violation := range violations {
+		err.violations = append(err.violations, violation.Error())
+	}
+	return err
+}
+
+// writeUpdateError writes the errors of the repositories' updates, including
+// those of the modifications of the handlers.
+func writeUpdateError(w http.ResponseWriter, r *http.Request, err error) {
+	var invalid *violationsError
+	var notFound *notFoundError
+	var conflict *conflictError
+	switch {
+	case errors.As(err, &invalid):
+		writeResult(w, r, http.StatusBadRequest, invalid.violations...)
+	case errors.As(err, &notFound):
+		writeResult(w, r, http.StatusNotFound, notFound.text)
+	case errors.As(err, &conflict):
+		writeResult(w, r, http.StatusConflict, conflict.text)
+	default:
+		writeRepositoryError(w, r, err)
+	}
+}
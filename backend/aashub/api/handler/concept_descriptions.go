@@ -0,0 +1,253 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+)
+
+// GetConceptDescriptionsResult is a page of concept descriptions.
+type GetConceptDescriptionsResult struct {
+	PagingMetadata PagingMetadata           `json:"paging_metadata"`
+	Result         []aas.ConceptDescription `json:"result"`
+}
+
+// ConceptDescriptionHandler implements the Concept Description Repository
+// Service (IDTA-01002 Part 2). Concept descriptions are identified by their
+// base64url encoded id.
+type ConceptDescriptionHandler struct {
+	Repo interfaces.ConceptDescriptionRepositoryInterface
+}
+
+// validateConceptDescription checks a concept description against the
+// metamodel constraints. The paths of the violations start at the concept
+// description.
+func validateConceptDescription(cd *aas.ConceptDescription) error {
+	return validate(&aas.Environment{ConceptDescriptions: []aas.ConceptDescription{*cd}}, "$.conceptDescriptions[0]")
+}
+
+// queryReference decodes the query parameter name, a reference serialized as
+// JSON and encoded as base64url, or returns nil if it is not given.
+func queryReference(r *http.Request, name string) (*aas.Reference, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	data, err := decodeIdentifier(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not base64url encoded", name)
+	}
+	var ref aas.Reference
+	if err := json.Unmarshal([]byte(data), &ref); err != nil || len(ref.Keys) == 0 {
+		return nil, fmt.Errorf("%s is not a reference", name)
+	}
+	return &ref, nil
+}
+
+// GetConceptDescriptions lists the stored concept descriptions.
+// @Summary List concept descriptions
+// @Description Returns the concept descriptions ordered by an internal key. isCaseOf and dataSpecificationRef are references serialized as JSON and encoded as base64url; they match if one of the isCaseOf references or data specifications equals them in type and keys.
+// @Tags concept-descriptions
+// @Produce json
+// @Param idShort query string false "Only concept descriptions with this idShort"
+// @Param isCaseOf query string false "Only concept descriptions that are a case of this reference"
+// @Param dataSpecificationRef query string false "Only concept descriptions with this data specification"
+// @Param limit query int false "Maximum number of concept descriptions" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetConceptDescriptionsResult "A page of concept descriptions"
+// @Failure 400 {object} Result "Invalid parameters"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /concept-descriptions [get]
+func (h *ConceptDescriptionHandler) GetConceptDescriptions(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := paging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	isCaseOf, err := queryReference(r, "isCaseOf")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	dataSpecificationRef, err := queryReference(r, "dataSpecificationRef")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	cds, next, err := h.Repo.ListConceptDescriptions(r.Context(), r.URL.Query().Get("idShort"), isCaseOf, dataSpecificationRef, cursor, limit)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetConceptDescriptionsResult{PagingMetadata: PagingMetadata{Cursor: next}, Result: cds})
+}
+
+// PostConceptDescription stores a new concept description owned by the
+// authenticated user.
+// @Summary Create a concept description
+// @Description Stores a concept description given in JSON or XML. It must satisfy the metamodel constraints. Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags concept-descriptions
+// @Accept json,xml
+// @Produce json,xml
+// @Param conceptDescription body aas.ConceptDescription true "The concept description"
+// @Success 201 {object} aas.ConceptDescription "The stored concept description"
+// @Failure 400 {object} Result "Invalid concept description"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Failure 409 {object} Result "A concept description with the same id exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /concept-descriptions [post]
+func (h *ConceptDescriptionHandler) PostConceptDescription(w http.ResponseWriter, r *http.Request) {
+	if !acceptable(w, r) {
+		return
+	}
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	var cd aas.ConceptDescription
+	if _, err := readModel(w, r, "conceptDescription", &cd); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid concept description: "+err.Error())
+		return
+	}
+	if err := validateConceptDescription(&cd); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if err := h.Repo.CreateConceptDescription(r.Context(), ownerID, &cd); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/concept-descriptions/"+base64.RawURLEncoding.EncodeToString([]byte(cd.Id)))
+	writeModel(w, r, http.StatusCreated, "conceptDescription", &cd, mediaTypeJSON)
+}
+
+// GetConceptDescription returns a concept description.
+// @Summary Get a concept description
+// @Tags concept-descriptions
+// @Produce json,xml
+// @Param cdIdentifier path string true "The concept description's id, base64url encoded"
+// @Success 200 {object} aas.ConceptDescription "The concept description"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Concept description not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /concept-descriptions/{cdIdentifier} [get]
+func (h *ConceptDescriptionHandler) GetConceptDescription(w http.ResponseWriter, r *http.Request) {
+	id, err := pathIdentifier(r, "cdIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	cd, err := h.Repo.GetConceptDescription(r.Context(), id)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeModel(w, r, http.StatusOK, "conceptDescription", cd, mediaTypeJSON)
+}
+
+// PutConceptDescription replaces a concept description of the authenticated
+// user.
+// @Summary Replace a concept description
+// @Description Replaces a concept description with the one given in JSON or XML, which must have the same id. Only the owner may replace a concept description.
+// @Tags concept-descriptions
+// @Accept json,xml
+// @Param cdIdentifier path string true "The concept description's id, base64url encoded"
+// @Param conceptDescription body aas.ConceptDescription true "The concept description"
+// @Success 204 "Concept description replaced"
+// @Failure 400 {object} Result "Invalid concept description"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The concept description belongs to another user"
+// @Failure 404 {object} Result "Concept description not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /concept-descriptions/{cdIdentifier} [put]
+func (h *ConceptDescriptionHandler) PutConceptDescription(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "cdIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var cd aas.ConceptDescription
+	if _, err := readModel(w, r, "conceptDescription", &cd); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid concept description: "+err.Error())
+		return
+	}
+	err = h.Repo.UpdateConceptDescription(r.Context(), ownerID, id, func(stored *aas.ConceptDescription) error {
+		if cd.Id != stored.Id {
+			return &violationsError{violations: []string{"the id of the concept description does not match the identifier in the path"}}
+		}
+		*stored = cd
+		return validateConceptDescription(stored)
+	})
+	if err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteConceptDescription removes a concept description of the
+// authenticated user.
+// @Summary Delete a concept description
+// @Description Only the owner may delete a concept description. Semantic ids referring to it are kept.
+// @Tags concept-descriptions
+// @Param cdIdentifier path string true "The concept description's id, base64url encoded"
+// @Success 204 "Concept description deleted"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The concept description belongs to another user"
+// @Failure 404 {object} Result "Concept description not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /concept-descriptions/{cdIdentifier} [delete]
+func (h *ConceptDescriptionHandler) DeleteConceptDescription(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "cdIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.Repo.DeleteConceptDescription(r.Context(), ownerID, id); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// resolveSemanticIds returns the stored concept descriptions that the
+// semantic ids of a submodel or element and the elements below it refer to.
+// A semantic id refers to the concept description whose id is the value of
+// its first key; semantic ids without a stored concept description are
+// skipped.
+func resolveSemanticIds(r *http.Request, repo interfaces.ConceptDescriptionRepositoryInterface, semanticId *aas.Reference, elements aas.SubmodelElements) ([]aas.ConceptDescription, error) {
+	refs := []*aas.Reference{semanticId}
+	aas.Walk(elements, func(element aas.SubmodelElement) {
+		refs = append(refs, element.Base().SemanticId)
+	})
+
+	var ids []string
+	seen := map[string]bool{}
+	for _, ref := range refs {
+		if ref == nil || len(ref.Keys) == 0 || seen[ref.Keys[0].Value] {
+			continue
+		}
+		seen[ref.Keys[0].Value] = true
+		ids = append(ids, ref.Keys[0].Value)
+	}
+	return repo.GetConceptDescriptions(r.Context(), ids)
+}
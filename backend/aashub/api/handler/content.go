@@ -0,0 +1,131 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+// Media types of the JSON and XML serializations of the metamodel.
+const (
+	mediaTypeJSON = "application/json"
+	mediaTypeXML  = "application/xml"
+)
+
+// isXML reports whether a media type denotes XML, e.g. text/xml or
+// application/atom+xml.
+func isXML(mediaType string) bool {
+	return mediaType == mediaTypeXML || mediaType == "text/xml" || strings.HasSuffix(mediaType, "+xml")
+}
+
+// negotiate picks the serialization requested by the Accept header, fallback
+// if both are equally acceptable. ok is false if neither is acceptable.
+func negotiate(r *http.Request, fallback string) (mediaType string, ok bool) {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return fallback, true
+	}
+
+	// The quality of each serialization is taken from the most specific
+	// matching range: 2 for the media type itself, 1 for application/* and 0
+	// for */*.
+	quality := map[string]float64{mediaTypeJSON: 0, mediaTypeXML: 0}
+	specificity := map[string]int{mediaTypeJSON: -1, mediaTypeXML: -1}
+	for _, part := range strings.Split(accept, ",") {
+		mediaRange, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		if value, ok := params["q"]; ok {
+			if q, err = strconv.ParseFloat(value, 64); err != nil {
+				continue
+			}
+		}
+		for candidate := range quality {
+			level := -1
+			switch {
+			case mediaRange == candidate || candidate == mediaTypeXML && isXML(mediaRange):
+				level = 2
+			case mediaRange == "application/*":
+				level = 1
+			case mediaRange == "*/*":
+				level = 0
+			}
+			if level < 0 {
+				continue
+			}
+			if level > specificity[candidate] || level == specificity[candidate] && q > quality[candidate] {
+				specificity[candidate], quality[candidate] = level, q
+			}
+		}
+	}
+
+	qJSON, qXML := quality[mediaTypeJSON], quality[mediaTypeXML]
+	switch {
+	case qJSON == 0 && qXML == 0:
+		return "", false
+	case qJSON == qXML:
+		return fallback, true
+	case qXML > qJSON:
+		return mediaTypeXML, true
+	}
+	return mediaTypeJSON, true
+}
+
+// readModel decodes the body of r into v, as XML with the given root element
+// if the Content-Type is XML and as JSON otherwise. It returns the media type
+// of the body.
+func readModel(w http.ResponseWriter, r *http.Request, name string, v any) (string, error) {
+	body := http.MaxBytesReader(w, r.Body, maxEnvironmentSize)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); isXML(mediaType) {
+		return mediaTypeXML, aas.DecodeXMLElement(body, name, v)
+	}
+	return mediaTypeJSON, json.NewDecoder(body).Decode(v)
+}
+
+// acceptable reports whether the Accept header allows a model to be written,
+// writing 406 if it does not. Handlers that change data check it up front, so
+// a request whose response cannot be written has no effect.
+func acceptable(w http.ResponseWriter, r *http.Request) bool {
+	if _, ok := negotiate(r, mediaTypeJSON); !ok {
+		w.Header().Add("Vary", "Accept")
+		writeNotAcceptable(w)
+		return false
+	}
+	return true
+}
+
+func writeNotAcceptable(w http.ResponseWriter) {
+	http.Error(w, "Supported media types are application/json and application/xml", http.StatusNotAcceptable)
+}
+
+// writeModel writes v in the serialization requested by the Accept header,
+// fallback if there is no preference. name is the root element in XML, e.g.
+// "submodel".
+func writeModel(w http.ResponseWriter, r *http.Request, status int, name string, v any, fallback string) {
+	w.Header().Add("Vary", "Accept")
+	mediaType, ok := negotiate(r, fallback)
+	if !ok {
+		writeNotAcceptable(w)
+		return
+	}
+	if mediaType == mediaTypeJSON {
+		writeJSON(w, status, v)
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := aas.EncodeXMLElement(&buf, name, v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", mediaTypeXML)
+	w.WriteHeader(status)
+	w.Write(buf.Bytes())
+}
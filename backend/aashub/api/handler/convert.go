@@ -0,0 +1,35 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+type ConversionHandler struct{}
+
+// Convert translates an AAS environment between JSON and XML.
+// @Summary Convert an AAS environment between JSON and XML
+// @Description Reads an environment in the serialization given by the Content-Type and writes it in the one requested by the Accept header. Without a preference, JSON is converted to XML and XML to JSON. The environment is not validated; use /validate for that.
+// @Tags aas
+// @Accept json,xml
+// @Produce json,xml
+// @Param environment body aas.Environment true "Environment to convert"
+// @Success 200 {object} aas.Environment "The converted environment"
+// @Failure 400 {string} string "The body is not an AAS environment"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Router /convert [post]
+func (h *ConversionHandler) Convert(w http.ResponseWriter, r *http.Request) {
+	var env aas.Environment
+	mediaType, err := readModel(w, r, "environment", &env)
+	if err != nil {
+		http.Error(w, "Invalid environment: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	target := mediaTypeXML
+	if mediaType == mediaTypeXML {
+		target = mediaTypeJSON
+	}
+	writeModel(w, r, http.StatusOK, "environment", &env, target)
+}
@@ -0,0 +1,46 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/aas-hub-org/aashub/internal/health"
+)
+
+type HealthHandler struct {
+	Checker *health.Checker
+}
+
+// Livez reports whether the process is alive.
+// @Summary Liveness probe
+// @Description Responds with OK as long as the server is able to handle requests. It does not check any dependency.
+// @Tags health
+// @Produce json
+// @Success 200 {object} map[string]string "Server is alive"
+// @Router /livez [get]
+func (h *HealthHandler) Livez(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": health.StatusOK})
+}
+
+// Readyz reports whether the server can serve requests.
+// @Summary Readiness probe
+// @Description Checks the database and the mail transport and reports the result of every check. Responds with 503 if a check fails or the server is shutting down.
+// @Tags health
+// @Produce json
+// @Success 200 {object} health.Report "Server is ready"
+// @Failure 503 {object} health.Report "A dependency is unavailable or the server is shutting down"
+// @Router /readyz [get]
+func (h *HealthHandler) Readyz(w http.ResponseWriter, r *http.Request) {
+	report := h.Checker.Run(r.Context())
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	writeJSON(w, status, report)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,175 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+)
+
+// GetShellIdsResult is a page of shell ids.
+type GetShellIdsResult struct {
+	PagingMetadata PagingMetadata `json:"paging_metadata"`
+	Result         []string       `json:"result"`
+}
+
+// LookupHandler implements the AAS Basic Discovery Service (IDTA-01002 Part
+// 2), which finds the shells of an asset by its asset ids. The asset ids of
+// stored shells and registered shell descriptors are found without being
+// linked explicitly.
+type LookupHandler struct {
+	Repo interfaces.AssetLinkRepositoryInterface
+}
+
+// queryAssetIds decodes the assetIds query parameters, asset ids serialized
+// as JSON and encoded as base64url.
+func queryAssetIds(r *http.Request) ([]aas.SpecificAssetId, error) {
+	var ids []aas.SpecificAssetId
+	for _, value := range r.URL.Query()["assetIds"] {
+		data, err := decodeIdentifier(value)
+		if err != nil {
+			return nil, fmt.Errorf("assetIds is not base64url encoded")
+		}
+		var id aas.SpecificAssetId
+		if err := json.Unmarshal([]byte(data), &id); err != nil || id.Name == "" || id.Value == "" {
+			return nil, fmt.Errorf("assetIds is not an asset id with a name and a value")
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// GetShellIdsByAssetLink looks up the ids of the shells of an asset.
+// @Summary Look up shells by asset ids
+// @Description Returns the ids of the shells that all given asset ids are linked to, ordered by an internal key. Each assetIds is an asset id like {"name": "serialNumber", "value": "4711"} serialized as JSON and encoded as base64url; a globalAssetId is looked up under the name "globalAssetId". Without assetIds the ids of all shells with asset ids are returned.
+// @Tags lookup
+// @Produce json
+// @Param assetIds query []string false "Asset ids, base64url encoded" collectionFormat(multi)
+// @Param limit query int false "Maximum number of shell ids" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetShellIdsResult "A page of shell ids"
+// @Failure 400 {object} Result "Invalid parameters"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /lookup/shells [get]
+func (h *LookupHandler) GetShellIdsByAssetLink(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := paging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	assetIds, err := queryAssetIds(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	ids, next, err := h.Repo.LookupShells(r.Context(), assetIds, cursor, limit)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetShellIdsResult{PagingMetadata: PagingMetadata{Cursor: next}, Result: ids})
+}
+
+// GetAssetLinks returns the asset ids linked to a shell.
+// @Summary Get the asset ids of a shell
+// @Description Returns the asset ids linked to a shell id explicitly together with those of the stored shell and of its registered descriptor.
+// @Tags lookup
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 200 {array} aas.SpecificAssetId "The asset ids"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "No asset ids are linked to the shell"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /lookup/shells/{aasIdentifier} [get]
+func (h *LookupHandler) GetAssetLinks(w http.ResponseWriter, r *http.Request) {
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	assetIds, err := h.Repo.GetAssetLinks(r.Context(), id)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, assetIds)
+}
+
+// PostAssetLinks links asset ids to a shell on behalf of the authenticated
+// user.
+// @Summary Link asset ids to a shell
+// @Description Replaces the asset ids linked explicitly to a shell id, which need not belong to a stored shell. Only the owner of a stored shell or shell descriptor with that id, or otherwise the user who linked the first asset ids to it, may change them. Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags lookup
+// @Accept json
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param assetIds body []aas.SpecificAssetId true "The asset ids"
+// @Success 201 {array} aas.SpecificAssetId "The linked asset ids"
+// @Failure 400 {object} Result "Invalid asset ids"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The links belong to another user"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /lookup/shells/{aasIdentifier} [post]
+func (h *LookupHandler) PostAssetLinks(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var assetIds []aas.SpecificAssetId
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEnvironmentSize)).Decode(&assetIds); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid asset ids: "+err.Error())
+		return
+	}
+	if err := violationsOf(aas.ValidateSpecificAssetIds(assetIds)); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if err := h.Repo.CreateAssetLinks(r.Context(), ownerID, id, assetIds); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	if assetIds == nil {
+		assetIds = []aas.SpecificAssetId{}
+	}
+	writeJSON(w, http.StatusCreated, assetIds)
+}
+
+// DeleteAssetLinks removes the asset ids linked to a shell by the
+// authenticated user.
+// @Summary Unlink the asset ids of a shell
+// @Description Removes the asset ids linked explicitly to a shell id. The asset ids of the stored shell and of its registered descriptor are kept. Only the user who may change the asset ids may remove them.
+// @Tags lookup
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 204 "Asset ids unlinked"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The links belong to another user"
+// @Failure 404 {object} Result "No asset ids are linked to the shell"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /lookup/shells/{aasIdentifier} [delete]
+func (h *LookupHandler) DeleteAssetLinks(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.Repo.DeleteAssetLinks(r.Context(), ownerID, id); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,283 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/aasx"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+	models "github.com/aas-hub-org/aashub/internal/models"
+)
+
+// maxPackageSize limits the size of uploaded AASX packages.
+const maxPackageSize = 32 << 20
+
+// maxAssembledParts limits the number of shells, submodels and attachments
+// of an assembled package.
+const maxAssembledParts = 1000
+
+type APIPackage struct {
+	ID        string    `json:"id"`
+	Filename  string    `json:"filename"`
+	Size      int64     `json:"size"`
+	CreatedAt time.Time `json:"createdAt"`
+	Shells    []string  `json:"shells"`
+	Submodels []string  `json:"submodels"`
+	Files     []string  `json:"files"`
+}
+
+// PackageFileRef selects a supplementary file or thumbnail of an uploaded
+// package.
+type PackageFileRef struct {
+	PackageID string `json:"packageId"`
+	Path      string `json:"path"`
+}
+
+// AssembleRequest selects the stored shells, submodels and files of an
+// assembled package.
+type AssembleRequest struct {
+	Filename    string           `json:"filename,omitempty"`
+	Shells      []string         `json:"shells"`
+	Submodels   []string         `json:"submodels"`
+	Attachments []PackageFileRef `json:"attachments,omitempty"`
+	Thumbnail   *PackageFileRef  `json:"thumbnail,omitempty"`
+}
+
+type PackageHandler struct {
+	Repo interfaces.PackageRepositoryInterface
+}
+
+func newAPIPackage(pkg *models.Package) APIPackage {
+	nonNil := func(values []string) []string {
+		if values == nil {
+			return []string{}
+		}
+		return values
+	}
+	return APIPackage{
+		ID:        pkg.ID,
+		Filename:  pkg.Filename,
+		Size:      pkg.Size,
+		CreatedAt: pkg.CreatedAt,
+		Shells:    nonNil(pkg.Shells),
+		Submodels: nonNil(pkg.Submodels),
+		Files:     nonNil(pkg.Files),
+	}
+}
+
+// UploadPackage stores an AASX package of the authenticated user.
+// @Summary Upload an AASX package
+// @Description Parses the OPC relationships of the package to find its AAS spec parts (JSON or XML), supplementary files and thumbnail. The package is validated like with /validate and stored together with its shells and submodels, which are owned by the uploader. Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags packages
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "AASX package"
+// @Success 201 {object} APIPackage "The stored package"
+// @Failure 400 {string} string "Not an AASX package"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 409 {string} string "A shell or submodel with the same id already exists"
+// @Failure 413 {string} string "Package too large"
+// @Failure 422 {object} ValidationResult "The package violates metamodel constraints"
+// @Failure 500 {string} string "Internal server error"
+// @Router /packages [post]
+func (h *PackageHandler) UploadPackage(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		http.Error(w, "Authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxPackageSize+1<<20)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, "Package too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "Missing package file", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	content, err := io.ReadAll(io.LimitReader(file, maxPackageSize+1))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if len(content) > maxPackageSize {
+		http.Error(w, "Package too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	pkg, err := aasx.Read(bytes.NewReader(content), int64(len(content)))
+	if errors.Is(err, aasx.ErrTooLarge) {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if violations := aas.Validate(&pkg.Environment); len(violations) > 0 {
+		writeJSON(w, http.StatusUnprocessableEntity, ValidationResult{Valid: false, Violations: violations})
+		return
+	}
+
+	filename := path.Base(header.Filename)
+	if len(filename) > 255 {
+		filename = filename[len(filename)-255:]
+	}
+	stored, err := h.Repo.CreatePackage(r.Context(), ownerID, filename, content, pkg)
+	if errors.Is(err, repositories.ErrIdentifiableExists) {
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Could not store the package", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Location", "/api/v1/packages/"+stored.ID)
+	writeJSON(w, http.StatusCreated, newAPIPackage(stored))
+}
+
+// GetPackage describes an uploaded package.
+// @Summary Get an AASX package
+// @Description Returns the metadata of an uploaded package and the ids of the shells and submodels extracted from it.
+// @Tags packages
+// @Produce json
+// @Param id path string true "Package ID"
+// @Success 200 {object} APIPackage "The package"
+// @Failure 404 {string} string "Package not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /packages/{id} [get]
+func (h *PackageHandler) GetPackage(w http.ResponseWriter, r *http.Request) {
+	pkg, err := h.Repo.GetPackage(r.Context(), r.PathValue("id"))
+	if errors.Is(err, repositories.ErrPackageNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Could not load the package", http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, http.StatusOK, newAPIPackage(pkg))
+}
+
+// DownloadPackage returns an uploaded package as it was uploaded.
+// @Summary Download an AASX package
+// @Description Returns the AASX file of an uploaded package.
+// @Tags packages
+// @Produce application/asset-administration-shell-package
+// @Param id path string true "Package ID"
+// @Success 200 {file} file "The AASX package"
+// @Failure 404 {string} string "Package not found"
+// @Failure 500 {string} string "Internal server error"
+// @Router /packages/{id}/download [get]
+func (h *PackageHandler) DownloadPackage(w http.ResponseWriter, r *http.Request) {
+	pkg, content, err := h.Repo.GetPackageContent(r.Context(), r.PathValue("id"))
+	if errors.Is(err, repositories.ErrPackageNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Could not load the package", http.StatusInternalServerError)
+		return
+	}
+	writePackage(w, pkg.Filename, content)
+}
+
+// AssemblePackage builds an AASX package from stored shells, submodels and
+// files.
+// @Summary Assemble an AASX package
+// @Description Writes the selected shells and submodels into the JSON spec part of a new package. Attachments and the thumbnail are taken from uploaded packages; File elements should refer to the attachments by their path.
+// @Tags packages
+// @Accept json
+// @Produce application/asset-administration-shell-package
+// @Param request body AssembleRequest true "Content of the package"
+// @Success 200 {file} file "The AASX package"
+// @Failure 400 {string} string "Invalid request"
+// @Failure 404 {string} string "A shell, submodel or file does not exist"
+// @Failure 500 {string} string "Internal server error"
+// @Router /packages/assemble [post]
+func (h *PackageHandler) AssemblePackage(w http.ResponseWriter, r *http.Request) {
+	var req AssembleRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<20)).Decode(&req); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+	if len(req.Shells)+len(req.Submodels) == 0 {
+		http.Error(w, "Select at least one shell or submodel", http.StatusBadRequest)
+		return
+	}
+	if len(req.Shells)+len(req.Submodels)+len(req.Attachments) > maxAssembledParts {
+		http.Error(w, fmt.Sprintf("A package may contain at most %d shells, submodels and attachments", maxAssembledParts), http.StatusBadRequest)
+		return
+	}
+
+	env, err := h.Repo.GetEnvironment(r.Context(), req.Shells, req.Submodels)
+	if err != nil {
+		h.assembleError(w, err)
+		return
+	}
+	pkg := &aasx.Package{Environment: *env}
+	for _, ref := range req.Attachments {
+		file, err := h.Repo.GetPackageFile(r.Context(), ref.PackageID, ref.Path)
+		if err != nil {
+			h.assembleError(w, err)
+			return
+		}
+		pkg.Supplementary = append(pkg.Supplementary, *file)
+	}
+	if req.Thumbnail != nil {
+		if pkg.Thumbnail, err = h.Repo.GetPackageFile(r.Context(), req.Thumbnail.PackageID, req.Thumbnail.Path); err != nil {
+			h.assembleError(w, err)
+			return
+		}
+	}
+
+	var content bytes.Buffer
+	if err := aasx.Write(&content, pkg); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	filename := path.Base("/" + req.Filename)
+	if filename == "/" {
+		filename = "package.aasx"
+	} else if !strings.HasSuffix(strings.ToLower(filename), ".aasx") {
+		filename += ".aasx"
+	}
+	writePackage(w, filename, content.Bytes())
+}
+
+func (h *PackageHandler) assembleError(w http.ResponseWriter, err error) {
+	if errors.Is(err, repositories.ErrIdentifiableNotFound) || errors.Is(err, repositories.ErrPackageFileNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+	http.Error(w, "Could not assemble the package", http.StatusInternalServerError)
+}
+
+// writePackage sends an AASX file as a download.
+func writePackage(w http.ResponseWriter, filename string, content []byte) {
+	w.Header().Set("Content-Type", aasx.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	w.Header().Set("Content-Length", strconv.Itoa(len(content)))
+	w.WriteHeader(http.StatusOK)
+	w.Write(content)
+}
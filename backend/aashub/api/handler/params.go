@@ -0,0 +1,18 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WithPathValues wraps a handler like gin.WrapF and also makes the route's
+// parameters available through r.PathValue.
+func WithPathValues(h http.HandlerFunc) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		for _, param := range c.Params {
+			c.Request.SetPathValue(param.Key, param.Value)
+		}
+		h(c.Writer, c.Request)
+	}
+}
@@ -0,0 +1,599 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+)
+
+// GetShellDescriptorsResult is a page of shell descriptors.
+type GetShellDescriptorsResult struct {
+	PagingMetadata PagingMetadata                           `json:"paging_metadata"`
+	Result         []aas.AssetAdministrationShellDescriptor `json:"result"`
+}
+
+// GetSubmodelDescriptorsResult is a page of submodel descriptors.
+type GetSubmodelDescriptorsResult struct {
+	PagingMetadata PagingMetadata           `json:"paging_metadata"`
+	Result         []aas.SubmodelDescriptor `json:"result"`
+}
+
+// ShellDescriptorHandler implements the AAS Registry Service (IDTA-01002
+// Part 2), where servers register the endpoints of the shells and submodels
+// they host. Descriptors are identified by the base64url encoded id of the
+// shell or submodel they describe.
+type ShellDescriptorHandler struct {
+	Repo interfaces.ShellDescriptorRepositoryInterface
+}
+
+// SubmodelDescriptorHandler implements the Submodel Registry Service
+// (IDTA-01002 Part 2) for submodels registered without a shell.
+type SubmodelDescriptorHandler struct {
+	Repo interfaces.SubmodelDescriptorRepositoryInterface
+}
+
+// readDescriptor decodes the JSON body of r into v.
+func readDescriptor(w http.ResponseWriter, r *http.Request, v any) error {
+	return json.NewDecoder(http.MaxBytesReader(w, r.Body, maxEnvironmentSize)).Decode(v)
+}
+
+// GetShellDescriptors lists the registered shell descriptors.
+// @Summary List shell descriptors
+// @Description Returns the shell descriptors ordered by an internal key.
+// @Tags registry
+// @Produce json
+// @Param assetKind query string false "Only descriptors of shells with this asset kind" Enums(Type, Instance, NotApplicable)
+// @Param assetType query string false "Only descriptors of shells with this asset type, base64url encoded"
+// @Param limit query int false "Maximum number of descriptors" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetShellDescriptorsResult "A page of shell descriptors"
+// @Failure 400 {object} Result "Invalid parameters"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors [get]
+func (h *ShellDescriptorHandler) GetShellDescriptors(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := paging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	assetKind := aas.AssetKind(r.URL.Query().Get("assetKind"))
+	switch assetKind {
+	case "", aas.AssetKindType, aas.AssetKindInstance, aas.AssetKindNotApplicable:
+	default:
+		writeResult(w, r, http.StatusBadRequest, fmt.Sprintf("invalid asset kind %q", assetKind))
+		return
+	}
+	var assetType string
+	if value := r.URL.Query().Get("assetType"); value != "" {
+		if assetType, err = decodeIdentifier(value); err != nil {
+			writeResult(w, r, http.StatusBadRequest, "assetType is not base64url encoded")
+			return
+		}
+	}
+
+	descriptors, next, err := h.Repo.ListShellDescriptors(r.Context(), assetKind, assetType, cursor, limit)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetShellDescriptorsResult{PagingMetadata: PagingMetadata{Cursor: next}, Result: descriptors})
+}
+
+// PostShellDescriptor registers a new shell descriptor owned by the
+// authenticated user.
+// @Summary Register a shell descriptor
+// @Description Registers a shell descriptor with its submodel descriptors. Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param descriptor body aas.AssetAdministrationShellDescriptor true "The shell descriptor"
+// @Success 201 {object} aas.AssetAdministrationShellDescriptor "The registered descriptor"
+// @Failure 400 {object} Result "Invalid descriptor"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 409 {object} Result "A descriptor with the same id exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors [post]
+func (h *ShellDescriptorHandler) PostShellDescriptor(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	var descriptor aas.AssetAdministrationShellDescriptor
+	if err := readDescriptor(w, r, &descriptor); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid shell descriptor: "+err.Error())
+		return
+	}
+	if err := violationsOf(aas.ValidateShellDescriptor(&descriptor)); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if err := h.Repo.CreateShellDescriptor(r.Context(), ownerID, &descriptor); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/shell-descriptors/"+base64.RawURLEncoding.EncodeToString([]byte(descriptor.Id)))
+	writeJSON(w, http.StatusCreated, &descriptor)
+}
+
+// GetShellDescriptor returns a shell descriptor.
+// @Summary Get a shell descriptor
+// @Tags registry
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 200 {object} aas.AssetAdministrationShellDescriptor "The shell descriptor"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Shell descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier} [get]
+func (h *ShellDescriptorHandler) GetShellDescriptor(w http.ResponseWriter, r *http.Request) {
+	descriptor, ok := h.descriptor(w, r)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, descriptor)
+}
+
+// PutShellDescriptor replaces a shell descriptor of the authenticated user.
+// @Summary Replace a shell descriptor
+// @Description Replaces a shell descriptor, including its submodel descriptors, with one that has the same id. Only the owner may replace a descriptor.
+// @Tags registry
+// @Accept json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param descriptor body aas.AssetAdministrationShellDescriptor true "The shell descriptor"
+// @Success 204 "Shell descriptor replaced"
+// @Failure 400 {object} Result "Invalid descriptor"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The descriptor belongs to another user"
+// @Failure 404 {object} Result "Shell descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier} [put]
+func (h *ShellDescriptorHandler) PutShellDescriptor(w http.ResponseWriter, r *http.Request) {
+	var descriptor aas.AssetAdministrationShellDescriptor
+	if err := readDescriptor(w, r, &descriptor); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid shell descriptor: "+err.Error())
+		return
+	}
+	ok := h.update(w, r, func(stored *aas.AssetAdministrationShellDescriptor) error {
+		if descriptor.Id != stored.Id {
+			return &violationsError{violations: []string{"the id of the descriptor does not match the identifier in the path"}}
+		}
+		*stored = descriptor
+		return nil
+	})
+	if ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteShellDescriptor removes a shell descriptor of the authenticated user.
+// @Summary Delete a shell descriptor
+// @Description Only the owner may delete a descriptor. Its submodel descriptors are deleted with it.
+// @Tags registry
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 204 "Shell descriptor deleted"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The descriptor belongs to another user"
+// @Failure 404 {object} Result "Shell descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier} [delete]
+func (h *ShellDescriptorHandler) DeleteShellDescriptor(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.Repo.DeleteShellDescriptor(r.Context(), ownerID, id); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSubmodelDescriptors lists the submodel descriptors of a shell
+// descriptor.
+// @Summary List the submodel descriptors of a shell descriptor
+// @Tags registry
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param limit query int false "Maximum number of descriptors" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetSubmodelDescriptorsResult "A page of submodel descriptors"
+// @Failure 400 {object} Result "Invalid parameters"
+// @Failure 404 {object} Result "Shell descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier}/submodel-descriptors [get]
+func (h *ShellDescriptorHandler) GetSubmodelDescriptors(w http.ResponseWriter, r *http.Request) {
+	limit, start, err := indexPaging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	descriptor, ok := h.descriptor(w, r)
+	if !ok {
+		return
+	}
+	submodels, next := page(descriptor.SubmodelDescriptors, start, limit)
+	if submodels == nil {
+		submodels = []aas.SubmodelDescriptor{}
+	}
+	writeJSON(w, http.StatusOK, GetSubmodelDescriptorsResult{PagingMetadata: next, Result: submodels})
+}
+
+// PostSubmodelDescriptor adds a submodel descriptor to a shell descriptor.
+// @Summary Add a submodel descriptor to a shell descriptor
+// @Description Only the owner of the shell descriptor may add submodel descriptors.
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param descriptor body aas.SubmodelDescriptor true "The submodel descriptor"
+// @Success 201 {object} aas.SubmodelDescriptor "The added descriptor"
+// @Failure 400 {object} Result "Invalid descriptor"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell descriptor belongs to another user"
+// @Failure 404 {object} Result "Shell descriptor not found"
+// @Failure 409 {object} Result "A submodel descriptor with the same id exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier}/submodel-descriptors [post]
+func (h *ShellDescriptorHandler) PostSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	var submodel aas.SubmodelDescriptor
+	if err := readDescriptor(w, r, &submodel); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel descriptor: "+err.Error())
+		return
+	}
+	aasID, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	ok := h.update(w, r, func(stored *aas.AssetAdministrationShellDescriptor) error {
+		if err := violationsOf(aas.ValidateSubmodelDescriptor(&submodel)); err != nil {
+			return err
+		}
+		if submodelDescriptorIndex(stored, submodel.Id) >= 0 {
+			return &conflictError{text: "a submodel descriptor with the same id exists"}
+		}
+		stored.SubmodelDescriptors = append(stored.SubmodelDescriptors, submodel)
+		return nil
+	})
+	if !ok {
+		return
+	}
+	w.Header().Set("Location", "/api/v1/shell-descriptors/"+base64.RawURLEncoding.EncodeToString([]byte(aasID))+
+		"/submodel-descriptors/"+base64.RawURLEncoding.EncodeToString([]byte(submodel.Id)))
+	writeJSON(w, http.StatusCreated, &submodel)
+}
+
+// GetSubmodelDescriptor returns a submodel descriptor of a shell descriptor.
+// @Summary Get a submodel descriptor of a shell descriptor
+// @Tags registry
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 200 {object} aas.SubmodelDescriptor "The submodel descriptor"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Shell or submodel descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier}/submodel-descriptors/{submodelIdentifier} [get]
+func (h *ShellDescriptorHandler) GetSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	submodelID, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	descriptor, ok := h.descriptor(w, r)
+	if !ok {
+		return
+	}
+	i := submodelDescriptorIndex(descriptor, submodelID)
+	if i < 0 {
+		writeResult(w, r, http.StatusNotFound, errSubmodelDescriptorNotFound.text)
+		return
+	}
+	writeJSON(w, http.StatusOK, &descriptor.SubmodelDescriptors[i])
+}
+
+// PutSubmodelDescriptor replaces a submodel descriptor of a shell descriptor.
+// @Summary Replace a submodel descriptor of a shell descriptor
+// @Description Replaces a submodel descriptor with one that has the same id. Only the owner of the shell descriptor may replace it.
+// @Tags registry
+// @Accept json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param descriptor body aas.SubmodelDescriptor true "The submodel descriptor"
+// @Success 204 "Submodel descriptor replaced"
+// @Failure 400 {object} Result "Invalid descriptor"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell descriptor belongs to another user"
+// @Failure 404 {object} Result "Shell or submodel descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier}/submodel-descriptors/{submodelIdentifier} [put]
+func (h *ShellDescriptorHandler) PutSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	submodelID, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var submodel aas.SubmodelDescriptor
+	if err := readDescriptor(w, r, &submodel); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel descriptor: "+err.Error())
+		return
+	}
+	ok := h.update(w, r, func(stored *aas.AssetAdministrationShellDescriptor) error {
+		i := submodelDescriptorIndex(stored, submodelID)
+		if i < 0 {
+			return errSubmodelDescriptorNotFound
+		}
+		if submodel.Id != submodelID {
+			return &violationsError{violations: []string{"the id of the descriptor does not match the identifier in the path"}}
+		}
+		stored.SubmodelDescriptors[i] = submodel
+		return nil
+	})
+	if ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteSubmodelDescriptor removes a submodel descriptor from a shell
+// descriptor.
+// @Summary Delete a submodel descriptor of a shell descriptor
+// @Description Only the owner of the shell descriptor may delete its submodel descriptors.
+// @Tags registry
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 204 "Submodel descriptor deleted"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell descriptor belongs to another user"
+// @Failure 404 {object} Result "Shell or submodel descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shell-descriptors/{aasIdentifier}/submodel-descriptors/{submodelIdentifier} [delete]
+func (h *ShellDescriptorHandler) DeleteSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	submodelID, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	ok := h.update(w, r, func(stored *aas.AssetAdministrationShellDescriptor) error {
+		i := submodelDescriptorIndex(stored, submodelID)
+		if i < 0 {
+			return errSubmodelDescriptorNotFound
+		}
+		stored.SubmodelDescriptors = append(stored.SubmodelDescriptors[:i], stored.SubmodelDescriptors[i+1:]...)
+		return nil
+	})
+	if ok {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+var errSubmodelDescriptorNotFound = &notFoundError{text: "submodel descriptor not found"}
+
+// submodelDescriptorIndex returns the index of the submodel descriptor with
+// the given id in descriptor, or -1.
+func submodelDescriptorIndex(descriptor *aas.AssetAdministrationShellDescriptor, id string) int {
+	for i := range descriptor.SubmodelDescriptors {
+		if descriptor.SubmodelDescriptors[i].Id == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// descriptor loads the shell descriptor named by the aasIdentifier path
+// value, writing the error response if that fails.
+func (h *ShellDescriptorHandler) descriptor(w http.ResponseWriter, r *http.Request) (*aas.AssetAdministrationShellDescriptor, bool) {
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	descriptor, err := h.Repo.GetShellDescriptor(r.Context(), id)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return nil, false
+	}
+	return descriptor, true
+}
+
+// update applies modify to the shell descriptor named by the aasIdentifier
+// path value on behalf of the authenticated user and validates the result,
+// writing the error response if that fails.
+func (h *ShellDescriptorHandler) update(w http.ResponseWriter, r *http.Request, modify func(*aas.AssetAdministrationShellDescriptor) error) bool {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return false
+	}
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return false
+	}
+	err = h.Repo.UpdateShellDescriptor(r.Context(), ownerID, id, func(descriptor *aas.AssetAdministrationShellDescriptor) error {
+		if err := modify(descriptor); err != nil {
+			return err
+		}
+		return violationsOf(aas.ValidateShellDescriptor(descriptor))
+	})
+	if err != nil {
+		writeUpdateError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// GetSubmodelDescriptors lists the registered submodel descriptors.
+// @Summary List submodel descriptors
+// @Description Returns the submodel descriptors registered without a shell, ordered by an internal key.
+// @Tags registry
+// @Produce json
+// @Param limit query int false "Maximum number of descriptors" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetSubmodelDescriptorsResult "A page of submodel descriptors"
+// @Failure 400 {object} Result "Invalid parameters"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodel-descriptors [get]
+func (h *SubmodelDescriptorHandler) GetSubmodelDescriptors(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := paging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	descriptors, next, err := h.Repo.ListSubmodelDescriptors(r.Context(), cursor, limit)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetSubmodelDescriptorsResult{PagingMetadata: PagingMetadata{Cursor: next}, Result: descriptors})
+}
+
+// PostSubmodelDescriptor registers a new submodel descriptor owned by the
+// authenticated user.
+// @Summary Register a submodel descriptor
+// @Description Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags registry
+// @Accept json
+// @Produce json
+// @Param descriptor body aas.SubmodelDescriptor true "The submodel descriptor"
+// @Success 201 {object} aas.SubmodelDescriptor "The registered descriptor"
+// @Failure 400 {object} Result "Invalid descriptor"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 409 {object} Result "A descriptor with the same id exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodel-descriptors [post]
+func (h *SubmodelDescriptorHandler) PostSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	var descriptor aas.SubmodelDescriptor
+	if err := readDescriptor(w, r, &descriptor); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel descriptor: "+err.Error())
+		return
+	}
+	if err := violationsOf(aas.ValidateSubmodelDescriptor(&descriptor)); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if err := h.Repo.CreateSubmodelDescriptor(r.Context(), ownerID, &descriptor); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/submodel-descriptors/"+base64.RawURLEncoding.EncodeToString([]byte(descriptor.Id)))
+	writeJSON(w, http.StatusCreated, &descriptor)
+}
+
+// GetSubmodelDescriptor returns a submodel descriptor.
+// @Summary Get a submodel descriptor
+// @Tags registry
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 200 {object} aas.SubmodelDescriptor "The submodel descriptor"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Submodel descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodel-descriptors/{submodelIdentifier} [get]
+func (h *SubmodelDescriptorHandler) GetSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	id, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	descriptor, err := h.Repo.GetSubmodelDescriptor(r.Context(), id)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, descriptor)
+}
+
+// PutSubmodelDescriptor replaces a submodel descriptor of the authenticated
+// user.
+// @Summary Replace a submodel descriptor
+// @Description Replaces a submodel descriptor with one that has the same id. Only the owner may replace a descriptor.
+// @Tags registry
+// @Accept json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param descriptor body aas.SubmodelDescriptor true "The submodel descriptor"
+// @Success 204 "Submodel descriptor replaced"
+// @Failure 400 {object} Result "Invalid descriptor"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The descriptor belongs to another user"
+// @Failure 404 {object} Result "Submodel descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodel-descriptors/{submodelIdentifier} [put]
+func (h *SubmodelDescriptorHandler) PutSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	var descriptor aas.SubmodelDescriptor
+	if err := readDescriptor(w, r, &descriptor); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel descriptor: "+err.Error())
+		return
+	}
+	err = h.Repo.UpdateSubmodelDescriptor(r.Context(), ownerID, id, func(stored *aas.SubmodelDescriptor) error {
+		if descriptor.Id != stored.Id {
+			return &violationsError{violations: []string{"the id of the descriptor does not match the identifier in the path"}}
+		}
+		*stored = descriptor
+		return violationsOf(aas.ValidateSubmodelDescriptor(stored))
+	})
+	if err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteSubmodelDescriptor removes a submodel descriptor of the
+// authenticated user.
+// @Summary Delete a submodel descriptor
+// @Description Only the owner may delete a descriptor.
+// @Tags registry
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 204 "Submodel descriptor deleted"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The descriptor belongs to another user"
+// @Failure 404 {object} Result "Submodel descriptor not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodel-descriptors/{submodelIdentifier} [delete]
+func (h *SubmodelDescriptorHandler) DeleteSubmodelDescriptor(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.Repo.DeleteSubmodelDescriptor(r.Context(), ownerID, id); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
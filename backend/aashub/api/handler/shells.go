@@ -0,0 +1,363 @@
+package api
+
+import (
+	"encoding/base64"
+	"net/http"
+	"reflect"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+)
+
+var errReferenceExists = &conflictError{text: "the shell already refers to this submodel"}
+
+// GetShellsResult is a page of shells.
+type GetShellsResult struct {
+	PagingMetadata PagingMetadata                 `json:"paging_metadata"`
+	Result         []aas.AssetAdministrationShell `json:"result"`
+}
+
+// GetReferencesResult is a page of references.
+type GetReferencesResult struct {
+	PagingMetadata PagingMetadata  `json:"paging_metadata"`
+	Result         []aas.Reference `json:"result"`
+}
+
+// ShellHandler implements the shell endpoints of the AAS Repository Service
+// (IDTA-01002 Part 2). Shells are identified by their base64url encoded id.
+type ShellHandler struct {
+	Repo interfaces.ShellRepositoryInterface
+}
+
+// validateShell checks a shell against the metamodel constraints. The paths
+// of the violations start at the shell.
+func validateShell(shell *aas.AssetAdministrationShell) error {
+	return validate(&aas.Environment{AssetAdministrationShells: []aas.AssetAdministrationShell{*shell}}, "$.assetAdministrationShells[0]")
+}
+
+// GetShells lists the stored shells.
+// @Summary List shells
+// @Description Returns the shells ordered by an internal key. If there are more than limit shells, paging_metadata.cursor is to be passed as cursor to get the next page.
+// @Tags shells
+// @Produce json
+// @Param idShort query string false "Only shells with this idShort"
+// @Param limit query int false "Maximum number of shells" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetShellsResult "A page of shells"
+// @Failure 400 {object} Result "Invalid paging parameters"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells [get]
+func (h *ShellHandler) GetShells(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := paging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	shells, next, err := h.Repo.ListShells(r.Context(), r.URL.Query().Get("idShort"), cursor, limit)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, GetShellsResult{PagingMetadata: PagingMetadata{Cursor: next}, Result: shells})
+}
+
+// PostShell stores a new shell owned by the authenticated user.
+// @Summary Create a shell
+// @Description Stores a shell given in JSON or XML. The shell must satisfy the metamodel constraints. Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags shells
+// @Accept json,xml
+// @Produce json,xml
+// @Param shell body aas.AssetAdministrationShell true "The shell"
+// @Success 201 {object} aas.AssetAdministrationShell "The stored shell"
+// @Failure 400 {object} Result "Invalid shell"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Failure 409 {object} Result "A shell with the same id exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells [post]
+func (h *ShellHandler) PostShell(w http.ResponseWriter, r *http.Request) {
+	if !acceptable(w, r) {
+		return
+	}
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	var shell aas.AssetAdministrationShell
+	if _, err := readModel(w, r, "assetAdministrationShell", &shell); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid shell: "+err.Error())
+		return
+	}
+	if err := validateShell(&shell); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if err := h.Repo.CreateShell(r.Context(), ownerID, &shell); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/shells/"+base64.RawURLEncoding.EncodeToString([]byte(shell.Id)))
+	writeModel(w, r, http.StatusCreated, "assetAdministrationShell", &shell, mediaTypeJSON)
+}
+
+// GetShell returns a shell.
+// @Summary Get a shell
+// @Tags shells
+// @Produce json,xml
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 200 {object} aas.AssetAdministrationShell "The shell"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier} [get]
+func (h *ShellHandler) GetShell(w http.ResponseWriter, r *http.Request) {
+	shell, ok := h.shell(w, r)
+	if ok {
+		writeModel(w, r, http.StatusOK, "assetAdministrationShell", shell, mediaTypeJSON)
+	}
+}
+
+// PutShell replaces a shell of the authenticated user.
+// @Summary Replace a shell
+// @Description Replaces a shell with the one given in JSON or XML, which must have the same id. Only the owner may replace a shell.
+// @Tags shells
+// @Accept json,xml
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param shell body aas.AssetAdministrationShell true "The shell"
+// @Success 204 "Shell replaced"
+// @Failure 400 {object} Result "Invalid shell"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell belongs to another user"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier} [put]
+func (h *ShellHandler) PutShell(w http.ResponseWriter, r *http.Request) {
+	var shell aas.AssetAdministrationShell
+	h.update(w, r, "assetAdministrationShell", &shell, http.StatusNoContent, func(stored *aas.AssetAdministrationShell) error {
+		if shell.Id != stored.Id {
+			return &violationsError{violations: []string{"the id of the shell does not match the identifier in the path"}}
+		}
+		*stored = shell
+		return validateShell(stored)
+	})
+}
+
+// DeleteShell removes a shell of the authenticated user.
+// @Summary Delete a shell
+// @Description Only the owner may delete a shell. The submodels it refers to are kept.
+// @Tags shells
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 204 "Shell deleted"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell belongs to another user"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier} [delete]
+func (h *ShellHandler) DeleteShell(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.Repo.DeleteShell(r.Context(), ownerID, id); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetAssetInformation returns the asset information of a shell.
+// @Summary Get the asset information of a shell
+// @Tags shells
+// @Produce json,xml
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Success 200 {object} aas.AssetInformation "The asset information"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier}/asset-information [get]
+func (h *ShellHandler) GetAssetInformation(w http.ResponseWriter, r *http.Request) {
+	shell, ok := h.shell(w, r)
+	if ok {
+		writeModel(w, r, http.StatusOK, "assetInformation", &shell.AssetInformation, mediaTypeJSON)
+	}
+}
+
+// PutAssetInformation replaces the asset information of a shell.
+// @Summary Replace the asset information of a shell
+// @Description Only the owner of the shell may replace its asset information.
+// @Tags shells
+// @Accept json,xml
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param assetInformation body aas.AssetInformation true "The asset information"
+// @Success 204 "Asset information replaced"
+// @Failure 400 {object} Result "Invalid asset information"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell belongs to another user"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier}/asset-information [put]
+func (h *ShellHandler) PutAssetInformation(w http.ResponseWriter, r *http.Request) {
+	var info aas.AssetInformation
+	h.update(w, r, "assetInformation", &info, http.StatusNoContent, func(shell *aas.AssetAdministrationShell) error {
+		shell.AssetInformation = info
+		return validateShell(shell)
+	})
+}
+
+// GetSubmodelRefs lists the references of a shell to its submodels.
+// @Summary List the submodel references of a shell
+// @Tags shells
+// @Produce json
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param limit query int false "Maximum number of references" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetReferencesResult "A page of references"
+// @Failure 400 {object} Result "Invalid identifier or paging parameters"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier}/submodel-refs [get]
+func (h *ShellHandler) GetSubmodelRefs(w http.ResponseWriter, r *http.Request) {
+	limit, start, err := indexPaging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	shell, ok := h.shell(w, r)
+	if !ok {
+		return
+	}
+
+	refs, next := page(shell.Submodels, start, limit)
+	writeJSON(w, http.StatusOK, GetReferencesResult{PagingMetadata: next, Result: refs})
+}
+
+// PostSubmodelRef adds a reference to a submodel to a shell.
+// @Summary Add a submodel reference to a shell
+// @Description Only the owner of the shell may add references. The reference must be a model reference to a submodel.
+// @Tags shells
+// @Accept json,xml
+// @Produce json,xml
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param reference body aas.Reference true "The reference"
+// @Success 201 {object} aas.Reference "The added reference"
+// @Failure 400 {object} Result "Invalid reference"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell belongs to another user"
+// @Failure 404 {object} Result "Shell not found"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Failure 409 {object} Result "The shell already refers to the submodel"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier}/submodel-refs [post]
+func (h *ShellHandler) PostSubmodelRef(w http.ResponseWriter, r *http.Request) {
+	var ref aas.Reference
+	h.update(w, r, "reference", &ref, http.StatusCreated, func(shell *aas.AssetAdministrationShell) error {
+		for _, existing := range shell.Submodels {
+			if reflect.DeepEqual(existing.Keys, ref.Keys) {
+				return errReferenceExists
+			}
+		}
+		shell.Submodels = append(shell.Submodels, ref)
+		return validateShell(shell)
+	})
+}
+
+// DeleteSubmodelRef removes the references of a shell to a submodel.
+// @Summary Remove a submodel reference from a shell
+// @Description Only the owner of the shell may remove references. The submodel itself is kept.
+// @Tags shells
+// @Param aasIdentifier path string true "The shell's id, base64url encoded"
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 204 "Reference removed"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The shell belongs to another user"
+// @Failure 404 {object} Result "Shell or reference not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /shells/{aasIdentifier}/submodel-refs/{submodelIdentifier} [delete]
+func (h *ShellHandler) DeleteSubmodelRef(w http.ResponseWriter, r *http.Request) {
+	submodelID, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	h.update(w, r, "", nil, http.StatusNoContent, func(shell *aas.AssetAdministrationShell) error {
+		var kept []aas.Reference
+		for _, ref := range shell.Submodels {
+			if !refersToSubmodel(ref, submodelID) {
+				kept = append(kept, ref)
+			}
+		}
+		if len(kept) == len(shell.Submodels) {
+			return &notFoundError{text: "the shell does not refer to submodel " + submodelID}
+		}
+		shell.Submodels = kept
+		return nil
+	})
+}
+
+// refersToSubmodel reports whether ref is a model reference to the submodel
+// with the given id.
+func refersToSubmodel(ref aas.Reference, id string) bool {
+	return ref.Type == aas.ReferenceTypesModelReference && len(ref.Keys) > 0 &&
+		ref.Keys[0].Type == aas.KeyTypesSubmodel && ref.Keys[0].Value == id
+}
+
+// shell loads the shell identified in the path, writing the error if it
+// cannot be loaded.
+func (h *ShellHandler) shell(w http.ResponseWriter, r *http.Request) (*aas.AssetAdministrationShell, bool) {
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return nil, false
+	}
+	shell, err := h.Repo.GetShell(r.Context(), id)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return nil, false
+	}
+	return shell, true
+}
+
+// update reads the body into v unless it is nil and applies modify to
+// the shell identified in the path. On success, v is written with status,
+// or nothing if status is 204.
+func (h *ShellHandler) update(w http.ResponseWriter, r *http.Request, name string, v any, status int, modify func(*aas.AssetAdministrationShell) error) {
+	if status != http.StatusNoContent && !acceptable(w, r) {
+		return
+	}
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "aasIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if v != nil {
+		if _, err := readModel(w, r, name, v); err != nil {
+			writeResult(w, r, http.StatusBadRequest, "Invalid "+name+": "+err.Error())
+			return
+		}
+	}
+
+	if err := h.Repo.UpdateShell(r.Context(), ownerID, id, modify); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if status == http.StatusNoContent {
+		w.WriteHeader(status)
+		return
+	}
+	writeModel(w, r, status, name, v, mediaTypeJSON)
+}
@@ -0,0 +1,770 @@
+package api
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+)
+
+// GetSubmodelsResult is a page of submodels.
+type GetSubmodelsResult struct {
+	PagingMetadata PagingMetadata `json:"paging_metadata"`
+	Result         []aas.Submodel `json:"result"`
+}
+
+// GetSubmodelElementsResult is a page of submodel elements.
+type GetSubmodelElementsResult struct {
+	PagingMetadata PagingMetadata       `json:"paging_metadata"`
+	Result         aas.SubmodelElements `json:"result"`
+}
+
+// ResolvedResult is a submodel or submodel element together with the stored
+// concept descriptions its semantic ids refer to.
+type ResolvedResult struct {
+	Result              any                      `json:"result"`
+	ConceptDescriptions []aas.ConceptDescription `json:"conceptDescriptions"`
+}
+
+// SubmodelHandler implements the submodel endpoints of the AAS Repository
+// Service (IDTA-01002 Part 2). Submodels are identified by their base64url
+// encoded id, their elements by idShortPaths like "Documents[0].File".
+type SubmodelHandler struct {
+	Repo interfaces.SubmodelRepositoryInterface
+	// ConceptDescriptions resolves semantic ids on request.
+	ConceptDescriptions interfaces.ConceptDescriptionRepositoryInterface
+}
+
+// modifiers are the level and extent query parameters, which select how much
+// of a submodel or element is returned.
+type modifiers struct {
+	// core returns only the direct children rather than all elements below.
+	core bool
+	// withBlobValue includes the content of blobs.
+	withBlobValue bool
+}
+
+func parseModifiers(r *http.Request) (modifiers, error) {
+	var m modifiers
+	switch level := r.URL.Query().Get("level"); level {
+	case "core":
+		m.core = true
+	case "", "deep":
+	default:
+		return m, fmt.Errorf("level must be core or deep, not %q", level)
+	}
+	switch extent := r.URL.Query().Get("extent"); extent {
+	case "withBlobValue":
+		m.withBlobValue = true
+	case "", "withoutBlobValue":
+	default:
+		return m, fmt.Errorf("extent must be withBlobValue or withoutBlobValue, not %q", extent)
+	}
+	return m, nil
+}
+
+// apply leaves out what the modifiers exclude from elements, which are the
+// children of the returned submodel or element.
+func (m modifiers) apply(elements aas.SubmodelElements) {
+	if m.core {
+		aas.CoreLevel(elements)
+	}
+	if !m.withBlobValue {
+		aas.WithoutBlobValues(elements)
+	}
+}
+
+// validateSubmodel checks a submodel against the metamodel constraints. The
+// paths of the violations start at the submodel.
+func validateSubmodel(submodel *aas.Submodel) error {
+	return validate(&aas.Environment{Submodels: []aas.Submodel{*submodel}}, "$.submodels[0]")
+}
+
+// GetSubmodels lists the stored submodels.
+// @Summary List submodels
+// @Description Returns the submodels ordered by an internal key. If there are more than limit submodels, paging_metadata.cursor is to be passed as cursor to get the next page.
+// @Tags submodels
+// @Produce json
+// @Param idShort query string false "Only submodels with this idShort"
+// @Param semanticId query string false "Only submodels whose semantic id has this first key, base64url encoded"
+// @Param level query string false "Elements to return" Enums(core, deep) default(deep)
+// @Param extent query string false "Whether to return the content of blobs" Enums(withBlobValue, withoutBlobValue) default(withoutBlobValue)
+// @Param limit query int false "Maximum number of submodels" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetSubmodelsResult "A page of submodels"
+// @Failure 400 {object} Result "Invalid parameters"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels [get]
+func (h *SubmodelHandler) GetSubmodels(w http.ResponseWriter, r *http.Request) {
+	limit, cursor, err := paging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	m, err := parseModifiers(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	semanticId := ""
+	if value := r.URL.Query().Get("semanticId"); value != "" {
+		if semanticId, err = decodeIdentifier(value); err != nil {
+			writeResult(w, r, http.StatusBadRequest, "semanticId is not a base64url encoded identifier")
+			return
+		}
+	}
+
+	submodels, next, err := h.Repo.ListSubmodels(r.Context(), r.URL.Query().Get("idShort"), semanticId, cursor, limit)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	for i := range submodels {
+		m.apply(submodels[i].SubmodelElements)
+	}
+	writeJSON(w, http.StatusOK, GetSubmodelsResult{PagingMetadata: PagingMetadata{Cursor: next}, Result: submodels})
+}
+
+// PostSubmodel stores a new submodel owned by the authenticated user.
+// @Summary Create a submodel
+// @Description Stores a submodel given in JSON or XML. The submodel must satisfy the metamodel constraints. Requires the session cookie or an "Authorization: Bearer" token.
+// @Tags submodels
+// @Accept json,xml
+// @Produce json,xml
+// @Param submodel body aas.Submodel true "The submodel"
+// @Success 201 {object} aas.Submodel "The stored submodel"
+// @Failure 400 {object} Result "Invalid submodel"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Failure 409 {object} Result "A submodel with the same id exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels [post]
+func (h *SubmodelHandler) PostSubmodel(w http.ResponseWriter, r *http.Request) {
+	if !acceptable(w, r) {
+		return
+	}
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	var submodel aas.Submodel
+	if _, err := readModel(w, r, "submodel", &submodel); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel: "+err.Error())
+		return
+	}
+	if err := validateSubmodel(&submodel); err != nil {
+		writeUpdateError(w, r, err)
+		return
+	}
+	if err := h.Repo.CreateSubmodel(r.Context(), ownerID, &submodel); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.Header().Set("Location", "/api/v1/submodels/"+base64.RawURLEncoding.EncodeToString([]byte(submodel.Id)))
+	writeModel(w, r, http.StatusCreated, "submodel", &submodel, mediaTypeJSON)
+}
+
+// GetSubmodel returns a submodel.
+// @Summary Get a submodel
+// @Tags submodels
+// @Produce json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param level query string false "Elements to return" Enums(core, deep) default(deep)
+// @Param extent query string false "Whether to return the content of blobs" Enums(withBlobValue, withoutBlobValue) default(withoutBlobValue)
+// @Param resolveSemanticIds query bool false "Return a ResolvedResult in JSON with the concept descriptions of the semantic ids"
+// @Success 200 {object} aas.Submodel "The submodel"
+// @Failure 400 {object} Result "Invalid identifier or parameters"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier} [get]
+func (h *SubmodelHandler) GetSubmodel(w http.ResponseWriter, r *http.Request) {
+	submodel, m, ok := h.submodel(w, r)
+	if ok {
+		m.apply(submodel.SubmodelElements)
+		h.write(w, r, "submodel", submodel, submodel.SemanticId, submodel.SubmodelElements)
+	}
+}
+
+// PutSubmodel replaces a submodel of the authenticated user.
+// @Summary Replace a submodel
+// @Description Replaces a submodel with the one given in JSON or XML, which must have the same id. Only the owner may replace a submodel.
+// @Tags submodels
+// @Accept json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param submodel body aas.Submodel true "The submodel"
+// @Success 204 "Submodel replaced"
+// @Failure 400 {object} Result "Invalid submodel"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The submodel belongs to another user"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier} [put]
+func (h *SubmodelHandler) PutSubmodel(w http.ResponseWriter, r *http.Request) {
+	var submodel aas.Submodel
+	if _, err := readModel(w, r, "submodel", &submodel); err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel: "+err.Error())
+		return
+	}
+	if h.update(w, r, func(stored *aas.Submodel) error {
+		if submodel.Id != stored.Id {
+			return &violationsError{violations: []string{"the id of the submodel does not match the identifier in the path"}}
+		}
+		*stored = submodel
+		return nil
+	}) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// DeleteSubmodel removes a submodel of the authenticated user.
+// @Summary Delete a submodel
+// @Description Only the owner may delete a submodel. References of shells to it are kept.
+// @Tags submodels
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 204 "Submodel deleted"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The submodel belongs to another user"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier} [delete]
+func (h *SubmodelHandler) DeleteSubmodel(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return
+	}
+	id, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err := h.Repo.DeleteSubmodel(r.Context(), ownerID, id); err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSubmodelMetadata returns a submodel without its elements.
+// @Summary Get the metadata of a submodel
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 200 {object} map[string]any "The submodel without submodelElements"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/$metadata [get]
+func (h *SubmodelHandler) GetSubmodelMetadata(w http.ResponseWriter, r *http.Request) {
+	if submodel, _, ok := h.submodel(w, r); ok {
+		writeMetadata(w, r, submodel)
+	}
+}
+
+// GetSubmodelValue returns the values of the elements of a submodel.
+// @Summary Get the value of a submodel
+// @Description Returns the value-only serialization: an object of the values of the submodel elements by their idShort.
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param level query string false "Elements to return" Enums(core, deep) default(deep)
+// @Param extent query string false "Whether to return the content of blobs" Enums(withBlobValue, withoutBlobValue) default(withoutBlobValue)
+// @Success 200 {object} map[string]any "The values by idShort"
+// @Failure 400 {object} Result "Invalid identifier or parameters"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/$value [get]
+func (h *SubmodelHandler) GetSubmodelValue(w http.ResponseWriter, r *http.Request) {
+	if submodel, m, ok := h.submodel(w, r); ok {
+		m.apply(submodel.SubmodelElements)
+		writeJSON(w, http.StatusOK, aas.ElementsValueOnly(submodel.SubmodelElements))
+	}
+}
+
+// GetSubmodelReference returns the model reference to a submodel.
+// @Summary Get a reference to a submodel
+// @Tags submodels
+// @Produce json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Success 200 {object} aas.Reference "The model reference"
+// @Failure 400 {object} Result "Invalid identifier"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/$reference [get]
+func (h *SubmodelHandler) GetSubmodelReference(w http.ResponseWriter, r *http.Request) {
+	if submodel, _, ok := h.submodel(w, r); ok {
+		h.writeReference(w, r, submodel, "")
+	}
+}
+
+// GetSubmodelPaths returns the idShortPaths of the elements of a submodel.
+// @Summary Get the idShortPaths of a submodel
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param level query string false "core for the direct children only" Enums(core, deep) default(deep)
+// @Success 200 {array} string "The idShortPaths"
+// @Failure 400 {object} Result "Invalid identifier or parameters"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/$path [get]
+func (h *SubmodelHandler) GetSubmodelPaths(w http.ResponseWriter, r *http.Request) {
+	if submodel, m, ok := h.submodel(w, r); ok {
+		writeJSON(w, http.StatusOK, aas.IdShortPaths("", submodel.SubmodelElements, false, !m.core))
+	}
+}
+
+// GetSubmodelElements lists the top-level elements of a submodel.
+// @Summary List the elements of a submodel
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param level query string false "Elements to return" Enums(core, deep) default(deep)
+// @Param extent query string false "Whether to return the content of blobs" Enums(withBlobValue, withoutBlobValue) default(withoutBlobValue)
+// @Param limit query int false "Maximum number of elements" minimum(1) maximum(1000) default(100)
+// @Param cursor query string false "Cursor of the page"
+// @Success 200 {object} GetSubmodelElementsResult "A page of elements"
+// @Failure 400 {object} Result "Invalid identifier or parameters"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements [get]
+func (h *SubmodelHandler) GetSubmodelElements(w http.ResponseWriter, r *http.Request) {
+	limit, start, err := indexPaging(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	submodel, m, ok := h.submodel(w, r)
+	if !ok {
+		return
+	}
+	elements, next := page(submodel.SubmodelElements, start, limit)
+	m.apply(elements)
+	writeJSON(w, http.StatusOK, GetSubmodelElementsResult{PagingMetadata: next, Result: elements})
+}
+
+// PostSubmodelElement adds an element to a submodel.
+// @Summary Add an element to a submodel
+// @Description Only the owner of the submodel may add elements. The idShort of the element must be unique in the submodel.
+// @Tags submodels
+// @Accept json,xml
+// @Produce json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param element body object true "The submodel element"
+// @Success 201 {object} object "The added element"
+// @Failure 400 {object} Result "Invalid element"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The submodel belongs to another user"
+// @Failure 404 {object} Result "Submodel not found"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Failure 409 {object} Result "An element with the same idShort exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements [post]
+func (h *SubmodelHandler) PostSubmodelElement(w http.ResponseWriter, r *http.Request) {
+	if !acceptable(w, r) {
+		return
+	}
+	element, err := readElement(w, r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel element: "+err.Error())
+		return
+	}
+	if h.update(w, r, func(submodel *aas.Submodel) error {
+		return addElement(&submodel.SubmodelElements, false, element)
+	}) {
+		writeModel(w, r, http.StatusCreated, aas.XMLElementName(element), element, mediaTypeJSON)
+	}
+}
+
+// GetSubmodelElement returns the element at an idShortPath.
+// @Summary Get a submodel element
+// @Tags submodels
+// @Produce json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Param level query string false "Elements to return" Enums(core, deep) default(deep)
+// @Param extent query string false "Whether to return the content of blobs" Enums(withBlobValue, withoutBlobValue) default(withoutBlobValue)
+// @Param resolveSemanticIds query bool false "Return a ResolvedResult in JSON with the concept descriptions of the semantic ids"
+// @Success 200 {object} object "The submodel element"
+// @Failure 400 {object} Result "Invalid identifier, path or parameters"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath} [get]
+func (h *SubmodelHandler) GetSubmodelElement(w http.ResponseWriter, r *http.Request) {
+	if _, element, ok := h.element(w, r); ok {
+		h.write(w, r, aas.XMLElementName(element), element, nil, aas.SubmodelElements{element})
+	}
+}
+
+// PutSubmodelElement replaces the element at an idShortPath.
+// @Summary Replace a submodel element
+// @Description Only the owner of the submodel may replace elements. The element must keep its idShort.
+// @Tags submodels
+// @Accept json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Param element body object true "The submodel element"
+// @Success 204 "Element replaced"
+// @Failure 400 {object} Result "Invalid element or path"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The submodel belongs to another user"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath} [put]
+func (h *SubmodelHandler) PutSubmodelElement(w http.ResponseWriter, r *http.Request) {
+	element, err := readElement(w, r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel element: "+err.Error())
+		return
+	}
+	path := r.PathValue("idShortPath")
+	if h.update(w, r, func(submodel *aas.Submodel) error {
+		container, index, err := submodel.Locate(path)
+		if err != nil {
+			return elementError(err)
+		}
+		if !equalIdShort((*container)[index].Base().IdShort, element.Base().IdShort) {
+			return &violationsError{violations: []string{"the idShort of the element does not match the idShortPath"}}
+		}
+		(*container)[index] = element
+		return nil
+	}) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// PostSubmodelElementAtPath adds an element to the collection, list, entity
+// or annotated relationship at an idShortPath.
+// @Summary Add an element below a submodel element
+// @Description Only the owner of the submodel may add elements. The element at the idShortPath must be a collection, list, entity or annotated relationship; in all but lists, the idShort of the new element must be unique.
+// @Tags submodels
+// @Accept json,xml
+// @Produce json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the parent, e.g. Documents"
+// @Param element body object true "The submodel element"
+// @Success 201 {object} object "The added element"
+// @Failure 400 {object} Result "Invalid element or path"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The submodel belongs to another user"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 406 {string} string "Neither JSON nor XML is acceptable"
+// @Failure 409 {object} Result "An element with the same idShort exists"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath} [post]
+func (h *SubmodelHandler) PostSubmodelElementAtPath(w http.ResponseWriter, r *http.Request) {
+	if !acceptable(w, r) {
+		return
+	}
+	element, err := readElement(w, r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, "Invalid submodel element: "+err.Error())
+		return
+	}
+	path := r.PathValue("idShortPath")
+	if h.update(w, r, func(submodel *aas.Submodel) error {
+		parent, err := submodel.Element(path)
+		if err != nil {
+			return elementError(err)
+		}
+		children := aas.Children(parent)
+		if children == nil {
+			return &violationsError{violations: []string{fmt.Sprintf("a %s cannot contain submodel elements", parent.ModelType())}}
+		}
+		_, inList := parent.(*aas.SubmodelElementList)
+		return addElement(children, inList, element)
+	}) {
+		writeModel(w, r, http.StatusCreated, aas.XMLElementName(element), element, mediaTypeJSON)
+	}
+}
+
+// DeleteSubmodelElement removes the element at an idShortPath.
+// @Summary Delete a submodel element
+// @Description Only the owner of the submodel may delete elements. Deleting an element of a list shifts the indices of the following elements.
+// @Tags submodels
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Success 204 "Element deleted"
+// @Failure 400 {object} Result "Invalid identifier or path"
+// @Failure 401 {object} map[string]string "Not logged in"
+// @Failure 403 {object} Result "The submodel belongs to another user"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath} [delete]
+func (h *SubmodelHandler) DeleteSubmodelElement(w http.ResponseWriter, r *http.Request) {
+	path := r.PathValue("idShortPath")
+	if h.update(w, r, func(submodel *aas.Submodel) error {
+		container, index, err := submodel.Locate(path)
+		if err != nil {
+			return elementError(err)
+		}
+		*container = slices.Delete(*container, index, index+1)
+		return nil
+	}) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// GetSubmodelElementMetadata returns the element at an idShortPath without
+// its value.
+// @Summary Get the metadata of a submodel element
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Success 200 {object} map[string]any "The element without its value"
+// @Failure 400 {object} Result "Invalid identifier or path"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$metadata [get]
+func (h *SubmodelHandler) GetSubmodelElementMetadata(w http.ResponseWriter, r *http.Request) {
+	if _, element, ok := h.element(w, r); ok {
+		writeMetadata(w, r, element)
+	}
+}
+
+// GetSubmodelElementValue returns the value of the element at an
+// idShortPath.
+// @Summary Get the value of a submodel element
+// @Description Returns the value-only serialization of the element. Operations and capabilities have no value.
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Param level query string false "Elements to return" Enums(core, deep) default(deep)
+// @Param extent query string false "Whether to return the content of blobs" Enums(withBlobValue, withoutBlobValue) default(withoutBlobValue)
+// @Success 200 {object} any "The value"
+// @Failure 400 {object} Result "Invalid identifier, path or parameters, or the element has no value"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$value [get]
+func (h *SubmodelHandler) GetSubmodelElementValue(w http.ResponseWriter, r *http.Request) {
+	_, element, ok := h.element(w, r)
+	if !ok {
+		return
+	}
+	value, ok := aas.ValueOnly(element)
+	if !ok {
+		writeResult(w, r, http.StatusBadRequest, fmt.Sprintf("a %s has no value", element.ModelType()))
+		return
+	}
+	writeJSON(w, http.StatusOK, value)
+}
+
+// GetSubmodelElementReference returns the model reference to the element at
+// an idShortPath.
+// @Summary Get a reference to a submodel element
+// @Tags submodels
+// @Produce json,xml
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Success 200 {object} aas.Reference "The model reference"
+// @Failure 400 {object} Result "Invalid identifier or path"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$reference [get]
+func (h *SubmodelHandler) GetSubmodelElementReference(w http.ResponseWriter, r *http.Request) {
+	if submodel, _, ok := h.element(w, r); ok {
+		h.writeReference(w, r, submodel, r.PathValue("idShortPath"))
+	}
+}
+
+// GetSubmodelElementPaths returns the idShortPath of the element at an
+// idShortPath and those of the elements below it.
+// @Summary Get the idShortPaths of a submodel element
+// @Tags submodels
+// @Produce json
+// @Param submodelIdentifier path string true "The submodel's id, base64url encoded"
+// @Param idShortPath path string true "The idShortPath of the element, e.g. Documents[0].File"
+// @Param level query string false "core for the direct children only" Enums(core, deep) default(deep)
+// @Success 200 {array} string "The idShortPaths"
+// @Failure 400 {object} Result "Invalid identifier, path or parameters"
+// @Failure 404 {object} Result "Submodel or element not found"
+// @Failure 500 {object} Result "Internal server error"
+// @Router /submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$path [get]
+func (h *SubmodelHandler) GetSubmodelElementPaths(w http.ResponseWriter, r *http.Request) {
+	m, err := parseModifiers(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return
+	}
+	_, element, ok := h.element(w, r)
+	if !ok {
+		return
+	}
+	path := r.PathValue("idShortPath")
+	paths := []string{path}
+	if children := aas.Children(element); children != nil {
+		_, inList := element.(*aas.SubmodelElementList)
+		paths = append(paths, aas.IdShortPaths(path, *children, inList, !m.core)...)
+	}
+	writeJSON(w, http.StatusOK, paths)
+}
+
+// submodel loads the submodel identified in the path and parses the
+// modifiers, writing the error if either fails.
+func (h *SubmodelHandler) submodel(w http.ResponseWriter, r *http.Request) (*aas.Submodel, modifiers, bool) {
+	m, err := parseModifiers(r)
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return nil, m, false
+	}
+	id, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return nil, m, false
+	}
+	submodel, err := h.Repo.GetSubmodel(r.Context(), id)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return nil, m, false
+	}
+	return submodel, m, true
+}
+
+// element loads the element at the idShortPath in the path, with the
+// modifiers applied, and the submodel containing it.
+func (h *SubmodelHandler) element(w http.ResponseWriter, r *http.Request) (*aas.Submodel, aas.SubmodelElement, bool) {
+	submodel, m, ok := h.submodel(w, r)
+	if !ok {
+		return nil, nil, false
+	}
+	element, err := submodel.Element(r.PathValue("idShortPath"))
+	if err != nil {
+		writeUpdateError(w, r, elementError(err))
+		return nil, nil, false
+	}
+	if children := aas.Children(element); children != nil {
+		m.apply(*children)
+	}
+	if !m.withBlobValue {
+		aas.WithoutBlobValues(aas.SubmodelElements{element})
+	}
+	return submodel, element, true
+}
+
+// update applies modify to the submodel identified in the path and validates
+// the result. It writes the error and returns false if the update fails.
+func (h *SubmodelHandler) update(w http.ResponseWriter, r *http.Request, modify func(*aas.Submodel) error) bool {
+	ownerID, ok := auth.UserID(r.Context())
+	if !ok {
+		writeResult(w, r, http.StatusUnauthorized, "Authentication required")
+		return false
+	}
+	id, err := pathIdentifier(r, "submodelIdentifier")
+	if err != nil {
+		writeResult(w, r, http.StatusBadRequest, err.Error())
+		return false
+	}
+	err = h.Repo.UpdateSubmodel(r.Context(), ownerID, id, func(submodel *aas.Submodel) error {
+		if err := modify(submodel); err != nil {
+			return err
+		}
+		return validateSubmodel(submodel)
+	})
+	if err != nil {
+		writeUpdateError(w, r, err)
+		return false
+	}
+	return true
+}
+
+// write writes a submodel or element v, or a ResolvedResult if the request
+// asks to resolve the semantic ids, i.e. semanticId and those of elements and
+// the elements below them.
+func (h *SubmodelHandler) write(w http.ResponseWriter, r *http.Request, name string, v any, semanticId *aas.Reference, elements aas.SubmodelElements) {
+	resolve := false
+	if value := r.URL.Query().Get("resolveSemanticIds"); value != "" {
+		var err error
+		if resolve, err = strconv.ParseBool(value); err != nil {
+			writeResult(w, r, http.StatusBadRequest, "resolveSemanticIds must be true or false")
+			return
+		}
+	}
+	if !resolve || h.ConceptDescriptions == nil {
+		writeModel(w, r, http.StatusOK, name, v, mediaTypeJSON)
+		return
+	}
+
+	cds, err := resolveSemanticIds(r, h.ConceptDescriptions, semanticId, elements)
+	if err != nil {
+		writeRepositoryError(w, r, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, ResolvedResult{Result: v, ConceptDescriptions: cds})
+}
+
+// writeReference writes the model reference to the element at path in
+// submodel, or to the submodel if path is empty.
+func (h *SubmodelHandler) writeReference(w http.ResponseWriter, r *http.Request, submodel *aas.Submodel, path string) {
+	ref, err := submodel.Reference(path)
+	if err != nil {
+		writeUpdateError(w, r, elementError(err))
+		return
+	}
+	writeModel(w, r, http.StatusOK, "reference", ref, mediaTypeJSON)
+}
+
+// writeMetadata writes a submodel or element without its values.
+func writeMetadata(w http.ResponseWriter, r *http.Request, v any) {
+	metadata, err := aas.Metadata(v)
+	if err != nil {
+		writeResult(w, r, http.StatusInternalServerError, "Internal server error")
+		return
+	}
+	writeJSON(w, http.StatusOK, metadata)
+}
+
+// elementError maps the errors of idShortPath navigation to those written by
+// writeUpdateError.
+func elementError(err error) error {
+	switch {
+	case errors.Is(err, aas.ErrElementNotFound):
+		return &notFoundError{text: err.Error()}
+	case errors.Is(err, aas.ErrInvalidIdShortPath):
+		return &violationsError{violations: []string{err.Error()}}
+	}
+	return err
+}
+
+// readElement decodes a single submodel element from the body of r, as XML
+// if the Content-Type is XML and as JSON otherwise.
+func readElement(w http.ResponseWriter, r *http.Request) (aas.SubmodelElement, error) {
+	body := http.MaxBytesReader(w, r.Body, maxEnvironmentSize)
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); isXML(mediaType) {
+		return aas.DecodeXMLSubmodelElement(body)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	return aas.UnmarshalSubmodelElement(data)
+}
+
+// addElement appends element to elements, which must not contain an element
+// with the same idShort unless they are the value of a list.
+func addElement(elements *aas.SubmodelElements, inList bool, element aas.SubmodelElement) error {
+	if idShort := element.Base().IdShort; !inList && idShort != nil {
+		for _, existing := range *elements {
+			if equalIdShort(existing.Base().IdShort, idShort) {
+				return &conflictError{text: fmt.Sprintf("an element with idShort %q exists", *idShort)}
+			}
+		}
+	}
+	*elements = append(*elements, element)
+	return nil
+}
+
+func equalIdShort(a, b *string) bool {
+	return a == nil && b == nil || a != nil && b != nil && *a == *b
+}
@@ -6,33 +6,43 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/aas-hub-org/aashub/internal/auth"
 	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
 	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
+	mail "github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/aas-hub-org/aashub/internal/metrics"
 )
 
 type APIUser struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
 	Email    string `json:"email"`
+	Locale   string `json:"locale,omitempty"`
 }
 
 type UserHandler struct {
-	Repo interfaces.UserRepositoryInterface
+	Repo    interfaces.UserRepositoryInterface
+	Metrics *metrics.Metrics
+	// SecureCookie restricts the session cookie to HTTPS.
+	SecureCookie bool
 }
 
 type VerificationHandler struct {
 	VerificationRepository interfaces.VerificationRepositoryInterface
+	Metrics                *metrics.Metrics
 }
 
 // RegisterUser registers a new user in the system.
 // @Summary Register a new user
-// @Description Registers a new user with the provided username, email, and password.
+// @Description Registers a new user with the provided username, email, and password. The verification e-mail is sent in the given locale, falling back to the Accept-Language header.
 // @Tags users
 // @Accept json
 // @Produce json
 // @Param user body APIUser true "User to register"
+// @Param Accept-Language header string false "Preferred locale of the verification e-mail"
 // @Success 201 {string} string "Successfully registered the user"
 // @Failure 400 {string} string "Invalid request parameters"
+// @Failure 409 {string} string "Username or email already registered"
 // @Failure 500 {string} string "Internal server error"
 // @Router /users/register [post]
 func (h *UserHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
@@ -45,16 +55,37 @@ func (h *UserHandler) RegisterUser(w http.ResponseWriter, r *http.Request) {
 
 	// Check if any of the required fields are empty
 	if user.Username == "" || user.Email == "" || user.Password == "" {
+		h.Metrics.Registration(metrics.ResultInvalid)
 		http.Error(w, "Missing required field(s)", http.StatusBadRequest)
 		return
 	}
 
-	err = h.Repo.RegisterUser(user.Username, user.Email, user.Password)
+	// Reject addresses that could not be used as a mail recipient
+	if err := mail.ValidateAddress(user.Email); err != nil {
+		h.Metrics.Registration(metrics.ResultInvalid)
+		http.Error(w, "Invalid email address", http.StatusBadRequest)
+		return
+	}
+
+	// Prefer the explicit locale over the browser's language
+	locale := user.Locale
+	if locale == "" {
+		locale = r.Header.Get("Accept-Language")
+	}
+
+	err = h.Repo.RegisterUser(r.Context(), user.Username, user.Email, user.Password, locale)
+	if err == repositories.ErrUserRepoExists {
+		h.Metrics.Registration(metrics.ResultConflict)
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	}
 	if err != nil {
+		h.Metrics.Registration(metrics.ResultError)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
+	h.Metrics.Registration(metrics.ResultSuccess)
 	w.WriteHeader(http.StatusCreated)
 }
 
@@ -76,6 +107,7 @@ func (h *VerificationHandler) VerifyUser(w http.ResponseWriter, r *http.Request)
 	code_byte, code_decode_err := b64.RawURLEncoding.DecodeString(r.URL.Query().Get("code"))
 
 	if mail_decode_err != nil || code_decode_err != nil {
+		h.Metrics.Verification(metrics.ResultInvalid)
 		http.Error(w, "Invalid email or code", http.StatusBadRequest)
 		return
 	}
@@ -83,16 +115,19 @@ func (h *VerificationHandler) VerifyUser(w http.ResponseWriter, r *http.Request)
 	email := string(email_byte)
 	code := string(code_byte)
 
-	error_type, err := h.VerificationRepository.Verify(email, code)
+	error_type, err := h.VerificationRepository.Verify(r.Context(), email, code)
 	if err != nil {
 		if error_type == "system" {
+			h.Metrics.Verification(metrics.ResultError)
 			http.Error(w, "Verification failed", http.StatusInternalServerError)
 			return
 		} else {
+			h.Metrics.Verification(metrics.ResultInvalid)
 			http.Error(w, err.Error(), http.StatusBadRequest)
 			return
 		}
 	}
+	h.Metrics.Verification(metrics.ResultSuccess)
 
 	// Write success response
 	w.WriteHeader(http.StatusOK)
@@ -129,25 +164,29 @@ func (h *UserHandler) LoginUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.Repo.LoginUser(identifier, password)
+	token, err := h.Repo.LoginUser(r.Context(), identifier, password)
 	if err != nil {
 		if err == repositories.ErrUserRepoNotFound {
+			h.Metrics.Login(metrics.ResultFailure)
 			http.Error(w, "User not found", http.StatusNotFound)
 			return
 		}
+		h.Metrics.Login(metrics.ResultError)
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
+	h.Metrics.Login(metrics.ResultSuccess)
 
 	// Create a cookie
 	expiration := time.Now().Add(24 * time.Hour) // Set expiration to 24 hours from now
 	cookie := http.Cookie{
-		Name:     "token",    // Name of the cookie
-		Value:    token,      // Token value
-		Expires:  expiration, // Expiration time
-		HttpOnly: true,       // Make the cookie HTTP-only (not accessible via JavaScript)
-		Path:     "/",        // Cookie path
-		// Secure:   true,     // Uncomment this if you are serving your site over HTTPS
+		Name:     auth.TokenCookie,        // Name of the cookie
+		Value:    token,                   // Token value
+		Expires:  expiration,              // Expiration time
+		HttpOnly: true,                    // Make the cookie HTTP-only (not accessible via JavaScript)
+		Path:     "/",                     // Cookie path
+		SameSite: http.SameSiteStrictMode, // Never send the cookie with cross-site requests
+		Secure:   h.SecureCookie,          // Only send the cookie over HTTPS
 	}
 
 	// Set the cookie in the response header
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+// maxEnvironmentSize limits the size of AAS environments sent in a request.
+const maxEnvironmentSize = 32 << 20
+
+type ValidationResult struct {
+	Valid      bool            `json:"valid"`
+	Violations []aas.Violation `json:"violations"`
+}
+
+type ValidationHandler struct{}
+
+// Validate checks an AAS environment against the metamodel constraints.
+// @Summary Validate an AAS environment
+// @Description Checks an environment in the JSON or XML serialization of the metamodel v3.0, as given by the Content-Type, against the constraints of the metamodel, e.g. the idShort format (AASd-002) or the uniqueness of idShorts (AASd-022). Every violation is reported with the JSON path of the offending value.
+// @Tags aas
+// @Accept json,xml
+// @Produce json
+// @Param environment body aas.Environment true "Environment to validate"
+// @Success 200 {object} ValidationResult "The validation result"
+// @Failure 400 {string} string "The body is not an AAS environment"
+// @Router /validate [post]
+func (h *ValidationHandler) Validate(w http.ResponseWriter, r *http.Request) {
+	var env aas.Environment
+	if _, err := readModel(w, r, "environment", &env); err != nil {
+		http.Error(w, "Invalid environment: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	violations := aas.Validate(&env)
+	if violations == nil {
+		violations = []aas.Violation{}
+	}
+	writeJSON(w, http.StatusOK, ValidationResult{Valid: len(violations) == 0, Violations: violations})
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/aas-hub-org/aashub/internal/database"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/aas-hub-org/aashub/internal/logging"
+	mail "github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/aas-hub-org/aashub/internal/models"
+)
+
+const adminUsage = `usage: aashub admin [flags] <command> [command flags]
+
+commands:
+  create-user      create an already verified user
+  reset-password   set a new password for a user
+  verify           mark the e-mail address of a user as verified
+  grant-role       assign a role to a user
+  list-unverified  list users that have not verified their e-mail address
+  purge-stale      delete unverified users older than -older-than
+
+Passwords are read from standard input unless -password is given.
+Run "aashub admin <command> -h" for the flags of a command.`
+
+// adminCommand bundles the repositories the admin commands work on.
+type adminCommand struct {
+	users         *repositories.UserRepository
+	verifications *repositories.VerificationRepository
+}
+
+// runAdmin implements the "aashub admin" subcommand.
+func runAdmin(args []string) {
+	cfg, rest, err := config.Load(args)
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log))
+	if len(rest) == 0 {
+		fmt.Fprintln(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+
+	db, err := database.NewDB(cfg.Database)
+	if err != nil {
+		fatal("Could not connect to the database", err)
+	}
+	defer db.Close()
+
+	verificationRepo := &repositories.VerificationRepository{DB: db, QueryTimeout: cfg.Database.QueryTimeout}
+	admin := &adminCommand{
+		users: &repositories.UserRepository{
+			DB:           db,
+			QueryTimeout: cfg.Database.QueryTimeout,
+		},
+		verifications: verificationRepo,
+	}
+
+	commands := map[string]func(ctx context.Context, args []string) error{
+		"create-user":     admin.createUser,
+		"reset-password":  admin.resetPassword,
+		"verify":          admin.verify,
+		"grant-role":      admin.grantRole,
+		"list-unverified": admin.listUnverified,
+		"purge-stale":     admin.purgeStale,
+	}
+	command, ok := commands[rest[0]]
+	if !ok {
+		fmt.Fprintln(os.Stderr, adminUsage)
+		os.Exit(2)
+	}
+	if err := command(context.Background(), rest[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(2)
+		}
+		fatal(rest[0]+" failed", err)
+	}
+}
+
+func (a *adminCommand) createUser(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("create-user", flag.ContinueOnError)
+	username := flags.String("username", "", "name of the new user")
+	email := flags.String("email", "", "e-mail address of the new user")
+	password := flags.String("password", "", "password of the new user")
+	locale := flags.String("locale", mail.DefaultLocale, "locale used for e-mails to the user")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *username == "" || *email == "" {
+		return errors.New("-username and -email are required")
+	}
+	if err := mail.ValidateAddress(*email); err != nil {
+		return err
+	}
+	if err := readPassword(password); err != nil {
+		return err
+	}
+
+	user, err := a.users.CreateUser(ctx, *username, *email, *password, *locale)
+	if err != nil {
+		return err
+	}
+	if err := a.verifications.MarkVerified(ctx, user.Email); err != nil {
+		return err
+	}
+	fmt.Printf("Created user %s (%s)\n", user.Username, user.ID)
+	return nil
+}
+
+func (a *adminCommand) resetPassword(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("reset-password", flag.ContinueOnError)
+	login := flags.String("user", "", "username or e-mail address")
+	password := flags.String("password", "", "new password")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	user, err := a.findUser(ctx, *login)
+	if err != nil {
+		return err
+	}
+	if err := readPassword(password); err != nil {
+		return err
+	}
+
+	if err := a.users.SetPassword(ctx, user.ID, *password); err != nil {
+		return err
+	}
+	fmt.Printf("Reset password of %s\n", user.Username)
+	return nil
+}
+
+func (a *adminCommand) verify(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("verify", flag.ContinueOnError)
+	login := flags.String("user", "", "username or e-mail address")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	user, err := a.findUser(ctx, *login)
+	if err != nil {
+		return err
+	}
+
+	if err := a.verifications.MarkVerified(ctx, user.Email); err != nil {
+		return err
+	}
+	fmt.Printf("Verified %s\n", user.Email)
+	return nil
+}
+
+func (a *adminCommand) grantRole(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("grant-role", flag.ContinueOnError)
+	login := flags.String("user", "", "username or e-mail address")
+	role := flags.String("role", "", "role to grant, e.g. admin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *role == "" {
+		return errors.New("-role is required")
+	}
+	user, err := a.findUser(ctx, *login)
+	if err != nil {
+		return err
+	}
+
+	if err := a.users.GrantRole(ctx, user.ID, *role); err != nil {
+		return err
+	}
+	roles, err := a.users.Roles(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Roles of %s: %s\n", user.Username, strings.Join(roles, ", "))
+	return nil
+}
+
+func (a *adminCommand) listUnverified(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("list-unverified", flag.ContinueOnError)
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	users, err := a.users.ListUnverified(ctx)
+	if err != nil {
+		return err
+	}
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ID\tUSERNAME\tEMAIL\tREGISTERED")
+	for _, user := range users {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", user.ID, user.Username, user.Email, user.CreatedAt.Format(time.RFC3339))
+	}
+	return writer.Flush()
+}
+
+func (a *adminCommand) purgeStale(ctx context.Context, args []string) error {
+	flags := flag.NewFlagSet("purge-stale", flag.ContinueOnError)
+	olderThan := flags.Duration("older-than", 7*24*time.Hour, "minimum age of the unverified registrations to delete")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *olderThan <= 0 {
+		return errors.New("-older-than must be positive")
+	}
+
+	deleted, err := a.users.DeleteStaleRegistrations(ctx, time.Now().Add(-*olderThan))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Deleted %d stale registration(s)\n", deleted)
+	return nil
+}
+
+// findUser looks a user up by username first and by e-mail address second.
+func (a *adminCommand) findUser(ctx context.Context, login string) (*models.User, error) {
+	if login == "" {
+		return nil, errors.New("-user is required")
+	}
+	user, err := a.users.GetByUsername(ctx, login)
+	if errors.Is(err, repositories.ErrUserNotFound) {
+		user, err = a.users.GetByEmail(ctx, login)
+	}
+	if errors.Is(err, repositories.ErrUserNotFound) {
+		return nil, fmt.Errorf("no user %q", login)
+	}
+	return user, err
+}
+
+// readPassword reads the password from the first line of standard input if
+// it was not passed as a flag, so it does not end up in the shell history.
+func readPassword(password *string) error {
+	if *password != "" {
+		return nil
+	}
+	fmt.Fprint(os.Stderr, "Password: ")
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && line == "" {
+		return fmt.Errorf("could not read password: %w", err)
+	}
+	*password = strings.TrimRight(line, "\r\n")
+	if *password == "" {
+		return errors.New("password must not be empty")
+	}
+	return nil
+}
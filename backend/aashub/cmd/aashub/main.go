@@ -1,13 +1,28 @@
 package main
 
 import (
-	"log"
+	"context"
+	"errors"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	"github.com/aas-hub-org/aashub/internal/config"
 	"github.com/aas-hub-org/aashub/internal/database"
+	"github.com/aas-hub-org/aashub/internal/database/migrations"
 	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/aas-hub-org/aashub/internal/health"
+	"github.com/aas-hub-org/aashub/internal/logging"
+	mail "github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/aas-hub-org/aashub/internal/metrics"
+	"github.com/aas-hub-org/aashub/internal/tracing"
+	utils "github.com/aas-hub-org/aashub/internal/utils"
 
 	docs "github.com/aas-hub-org/aashub/docs"
 	"github.com/gin-contrib/cors"
@@ -30,33 +45,134 @@ func Health(g *gin.Context) {
 }
 
 func main() {
-	r := gin.Default()
+	args := os.Args[1:]
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			runMigrate(args[1:])
+			return
+		case "admin":
+			runAdmin(args[1:])
+			return
+		}
+	}
+	runServer(args)
+}
+
+func runServer(args []string) {
+	// Load configuration
+	cfg, _, err := config.Load(args)
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+
+	// Log JSON with request IDs to stderr
+	logger := logging.New(os.Stderr, cfg.Log)
+	slog.SetDefault(logger)
+
+	// Gin's debug output is plain text, only show it when debugging
+	if _, ok := os.LookupEnv(gin.EnvGinMode); !ok && logging.ParseLevel(cfg.Log.Level) > slog.LevelDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	// Propagate W3C trace context and export spans if configured
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.Tracing)
+	if err != nil {
+		fatal("Could not configure tracing", err)
+	}
+
+	r := gin.New()
+	r.Use(gin.Recovery(), tracing.Middleware(cfg.Tracing.ServiceName), logging.RequestIDMiddleware(), logging.AccessLog(logger))
+
+	// Collect request metrics for every route
+	appMetrics := metrics.New()
+	r.Use(appMetrics.Middleware())
 
-	// Configure CORS
-	corsConfig := cors.Config{
-		AllowAllOrigins:  true,
-		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length"},
-		AllowCredentials: true,
-		MaxAge:           12 * time.Hour,
+	// Only the hub itself and the configured origins may call the API from a
+	// browser, mutating requests included
+	trustedOrigins := append([]string{auth.Origin(cfg.Server.PublicURL)}, cfg.CORS.AllowedOrigins...)
+	if len(cfg.CORS.AllowedOrigins) > 0 {
+		r.Use(cors.New(cors.Config{
+			AllowOrigins:     trustedOrigins,
+			AllowMethods:     cfg.CORS.AllowedMethods,
+			AllowHeaders:     cfg.CORS.AllowedHeaders,
+			ExposeHeaders:    []string{"Content-Length", logging.RequestIDHeader},
+			AllowCredentials: cfg.CORS.AllowCredentials,
+			MaxAge:           cfg.CORS.MaxAge,
+		}))
 	}
-	r.Use(cors.New(corsConfig))
+	r.Use(auth.CSRF(trustedOrigins))
 
 	// Initialize database
-	database, err := database.NewDB()
+	database, err := database.NewDB(cfg.Database)
 	if err != nil {
-		log.Fatalf("Could not connect to the database: %v", err)
+		fatal("Could not connect to the database", err)
+	}
+
+	// Apply pending schema migrations
+	if cfg.Database.AutoMigrate {
+		migrator, err := migrations.NewMigrator(database)
+		if err != nil {
+			fatal("Could not load migrations", err)
+		}
+		if _, err := migrator.Up(context.Background()); err != nil {
+			fatal("Could not migrate the database", err)
+		}
+	}
+
+	if err := appMetrics.RegisterDB(database.DB, "aashub"); err != nil {
+		fatal("Could not register database metrics", err)
+	}
+
+	// Initialize mailer
+	transport, err := mail.NewMailer(cfg.Mail)
+	if err != nil {
+		fatal("Could not configure the mailer", err)
+	}
+	mailer := &metrics.Mailer{Mailer: &tracing.Mailer{Mailer: transport}, Metrics: appMetrics}
+
+	// Read the JWT signing key
+	jwtSecret, err := utils.ReadFile(cfg.Auth.JWTKeyFile)
+	if err != nil {
+		fatal("Could not read the JWT key", err)
 	}
 
 	// Initialize repositories
-	verificationRepo := &repositories.VerificationRepository{DB: database}
-	mailVerificationRepo := &repositories.EmailVerificationRepository{VerificationRepository: verificationRepo}
-	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: mailVerificationRepo}
+	verificationRepo := &repositories.VerificationRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	mailVerificationRepo := &repositories.EmailVerificationRepository{VerificationRepository: verificationRepo, Mailer: mailer, ServerAddress: cfg.Server.PublicURL}
+	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: mailVerificationRepo, JWTSecret: jwtSecret, QueryTimeout: cfg.Database.QueryTimeout}
+	packageRepo := &repositories.PackageRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	shellRepo := &repositories.ShellRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	submodelRepo := &repositories.SubmodelRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	conceptDescriptionRepo := &repositories.ConceptDescriptionRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	shellDescriptorRepo := &repositories.ShellDescriptorRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	submodelDescriptorRepo := &repositories.SubmodelDescriptorRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+	assetLinkRepo := &repositories.AssetLinkRepository{DB: database, QueryTimeout: cfg.Database.QueryTimeout}
+
+	// Readiness depends on the database and the mail transport
+	checker := &health.Checker{
+		Checks: map[string]health.Check{
+			"database": database.PingContext,
+			"mail": func(ctx context.Context) error {
+				return mail.Ping(ctx, mailer)
+			},
+		},
+	}
 
 	// Initialize handlers
-	userHandler := &api.UserHandler{Repo: userRepo}
-	verificationHandler := &api.VerificationHandler{VerificationRepository: verificationRepo}
+	userHandler := &api.UserHandler{Repo: userRepo, Metrics: appMetrics, SecureCookie: strings.HasPrefix(cfg.Server.PublicURL, "https://")}
+	verificationHandler := &api.VerificationHandler{VerificationRepository: verificationRepo, Metrics: appMetrics}
+	healthHandler := &api.HealthHandler{Checker: checker}
+	validationHandler := &api.ValidationHandler{}
+	conversionHandler := &api.ConversionHandler{}
+	packageHandler := &api.PackageHandler{Repo: packageRepo}
+	shellHandler := &api.ShellHandler{Repo: shellRepo}
+	submodelHandler := &api.SubmodelHandler{Repo: submodelRepo, ConceptDescriptions: conceptDescriptionRepo}
+	conceptDescriptionHandler := &api.ConceptDescriptionHandler{Repo: conceptDescriptionRepo}
+	shellDescriptorHandler := &api.ShellDescriptorHandler{Repo: shellDescriptorRepo}
+	submodelDescriptorHandler := &api.SubmodelDescriptorHandler{Repo: submodelDescriptorRepo}
+	lookupHandler := &api.LookupHandler{Repo: assetLinkRepo}
+	requireUser := auth.RequireUser(jwtSecret)
 
 	docs.SwaggerInfo.BasePath = "/api/v1"
 	v1 := r.Group("/api/v1")
@@ -70,8 +186,140 @@ func main() {
 		{
 			vg.GET("/", gin.WrapF(verificationHandler.VerifyUser))
 		}
+		v1.POST("/validate", gin.WrapF(validationHandler.Validate))
+		v1.POST("/convert", gin.WrapF(conversionHandler.Convert))
+		pg := v1.Group("/packages")
+		{
+			pg.POST("", requireUser, gin.WrapF(packageHandler.UploadPackage))
+			pg.POST("/assemble", gin.WrapF(packageHandler.AssemblePackage))
+			pg.GET("/:id", api.WithPathValues(packageHandler.GetPackage))
+			pg.GET("/:id/download", api.WithPathValues(packageHandler.DownloadPackage))
+		}
+		sg := v1.Group("/shells")
+		{
+			sg.GET("", gin.WrapF(shellHandler.GetShells))
+			sg.POST("", requireUser, gin.WrapF(shellHandler.PostShell))
+			sg.GET("/:aasIdentifier", api.WithPathValues(shellHandler.GetShell))
+			sg.PUT("/:aasIdentifier", requireUser, api.WithPathValues(shellHandler.PutShell))
+			sg.DELETE("/:aasIdentifier", requireUser, api.WithPathValues(shellHandler.DeleteShell))
+			sg.GET("/:aasIdentifier/asset-information", api.WithPathValues(shellHandler.GetAssetInformation))
+			sg.PUT("/:aasIdentifier/asset-information", requireUser, api.WithPathValues(shellHandler.PutAssetInformation))
+			sg.GET("/:aasIdentifier/submodel-refs", api.WithPathValues(shellHandler.GetSubmodelRefs))
+			sg.POST("/:aasIdentifier/submodel-refs", requireUser, api.WithPathValues(shellHandler.PostSubmodelRef))
+			sg.DELETE("/:aasIdentifier/submodel-refs/:submodelIdentifier", requireUser, api.WithPathValues(shellHandler.DeleteSubmodelRef))
+		}
+		smg := v1.Group("/submodels")
+		{
+			smg.GET("", gin.WrapF(submodelHandler.GetSubmodels))
+			smg.POST("", requireUser, gin.WrapF(submodelHandler.PostSubmodel))
+			smg.GET("/:submodelIdentifier", api.WithPathValues(submodelHandler.GetSubmodel))
+			smg.PUT("/:submodelIdentifier", requireUser, api.WithPathValues(submodelHandler.PutSubmodel))
+			smg.DELETE("/:submodelIdentifier", requireUser, api.WithPathValues(submodelHandler.DeleteSubmodel))
+			smg.GET("/:submodelIdentifier/$metadata", api.WithPathValues(submodelHandler.GetSubmodelMetadata))
+			smg.GET("/:submodelIdentifier/$value", api.WithPathValues(submodelHandler.GetSubmodelValue))
+			smg.GET("/:submodelIdentifier/$reference", api.WithPathValues(submodelHandler.GetSubmodelReference))
+			smg.GET("/:submodelIdentifier/$path", api.WithPathValues(submodelHandler.GetSubmodelPaths))
+			smg.GET("/:submodelIdentifier/submodel-elements", api.WithPathValues(submodelHandler.GetSubmodelElements))
+			smg.POST("/:submodelIdentifier/submodel-elements", requireUser, api.WithPathValues(submodelHandler.PostSubmodelElement))
+			smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath", api.WithPathValues(submodelHandler.GetSubmodelElement))
+			smg.PUT("/:submodelIdentifier/submodel-elements/:idShortPath", requireUser, api.WithPathValues(submodelHandler.PutSubmodelElement))
+			smg.POST("/:submodelIdentifier/submodel-elements/:idShortPath", requireUser, api.WithPathValues(submodelHandler.PostSubmodelElementAtPath))
+			smg.DELETE("/:submodelIdentifier/submodel-elements/:idShortPath", requireUser, api.WithPathValues(submodelHandler.DeleteSubmodelElement))
+			smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$metadata", api.WithPathValues(submodelHandler.GetSubmodelElementMetadata))
+			smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$value", api.WithPathValues(submodelHandler.GetSubmodelElementValue))
+			smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$reference", api.WithPathValues(submodelHandler.GetSubmodelElementReference))
+			smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$path", api.WithPathValues(submodelHandler.GetSubmodelElementPaths))
+		}
+		cdg := v1.Group("/concept-descriptions")
+		{
+			cdg.GET("", gin.WrapF(conceptDescriptionHandler.GetConceptDescriptions))
+			cdg.POST("", requireUser, gin.WrapF(conceptDescriptionHandler.PostConceptDescription))
+			cdg.GET("/:cdIdentifier", api.WithPathValues(conceptDescriptionHandler.GetConceptDescription))
+			cdg.PUT("/:cdIdentifier", requireUser, api.WithPathValues(conceptDescriptionHandler.PutConceptDescription))
+			cdg.DELETE("/:cdIdentifier", requireUser, api.WithPathValues(conceptDescriptionHandler.DeleteConceptDescription))
+		}
+		sdg := v1.Group("/shell-descriptors")
+		{
+			sdg.GET("", gin.WrapF(shellDescriptorHandler.GetShellDescriptors))
+			sdg.POST("", requireUser, gin.WrapF(shellDescriptorHandler.PostShellDescriptor))
+			sdg.GET("/:aasIdentifier", api.WithPathValues(shellDescriptorHandler.GetShellDescriptor))
+			sdg.PUT("/:aasIdentifier", requireUser, api.WithPathValues(shellDescriptorHandler.PutShellDescriptor))
+			sdg.DELETE("/:aasIdentifier", requireUser, api.WithPathValues(shellDescriptorHandler.DeleteShellDescriptor))
+			sdg.GET("/:aasIdentifier/submodel-descriptors", api.WithPathValues(shellDescriptorHandler.GetSubmodelDescriptors))
+			sdg.POST("/:aasIdentifier/submodel-descriptors", requireUser, api.WithPathValues(shellDescriptorHandler.PostSubmodelDescriptor))
+			sdg.GET("/:aasIdentifier/submodel-descriptors/:submodelIdentifier", api.WithPathValues(shellDescriptorHandler.GetSubmodelDescriptor))
+			sdg.PUT("/:aasIdentifier/submodel-descriptors/:submodelIdentifier", requireUser, api.WithPathValues(shellDescriptorHandler.PutSubmodelDescriptor))
+			sdg.DELETE("/:aasIdentifier/submodel-descriptors/:submodelIdentifier", requireUser, api.WithPathValues(shellDescriptorHandler.DeleteSubmodelDescriptor))
+		}
+		smdg := v1.Group("/submodel-descriptors")
+		{
+			smdg.GET("", gin.WrapF(submodelDescriptorHandler.GetSubmodelDescriptors))
+			smdg.POST("", requireUser, gin.WrapF(submodelDescriptorHandler.PostSubmodelDescriptor))
+			smdg.GET("/:submodelIdentifier", api.WithPathValues(submodelDescriptorHandler.GetSubmodelDescriptor))
+			smdg.PUT("/:submodelIdentifier", requireUser, api.WithPathValues(submodelDescriptorHandler.PutSubmodelDescriptor))
+			smdg.DELETE("/:submodelIdentifier", requireUser, api.WithPathValues(submodelDescriptorHandler.DeleteSubmodelDescriptor))
+		}
+		lg := v1.Group("/lookup/shells")
+		{
+			lg.GET("", gin.WrapF(lookupHandler.GetShellIdsByAssetLink))
+			lg.GET("/:aasIdentifier", api.WithPathValues(lookupHandler.GetAssetLinks))
+			lg.POST("/:aasIdentifier", requireUser, api.WithPathValues(lookupHandler.PostAssetLinks))
+			lg.DELETE("/:aasIdentifier", requireUser, api.WithPathValues(lookupHandler.DeleteAssetLinks))
+		}
 	}
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerfiles.Handler))
 	r.GET("/health", Health)
-	r.Run(":9000")
+	r.GET("/livez", gin.WrapF(healthHandler.Livez))
+	r.GET("/readyz", gin.WrapF(healthHandler.Readyz))
+	r.GET("/metrics", gin.WrapH(appMetrics.Handler()))
+
+	server := &http.Server{
+		Addr:              cfg.Server.ListenAddress,
+		Handler:           r,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+	slog.Info("Listening", "address", cfg.Server.ListenAddress)
+
+	select {
+	case err := <-serverErr:
+		fatal("Server failed", err)
+	case <-ctx.Done():
+	}
+	stop()
+
+	// Report not ready and keep serving until the load balancers have noticed,
+	// then let the in-flight requests finish before the database goes away
+	slog.Info("Shutting down, draining traffic", "drain_delay", cfg.Server.DrainDelay)
+	checker.Drain()
+	time.Sleep(cfg.Server.DrainDelay)
+	slog.Info("Waiting for in-flight requests", "timeout", cfg.Server.ShutdownTimeout)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.ShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		slog.Error("Could not drain all requests", "error", err)
+	}
+	if err := <-serverErr; err != nil && !errors.Is(err, http.ErrServerClosed) {
+		slog.Error("Server failed", "error", err)
+	}
+	if err := database.Close(); err != nil {
+		slog.Error("Could not close the database", "error", err)
+	}
+	if err := shutdownTracing(shutdownCtx); err != nil {
+		slog.Error("Could not flush traces", "error", err)
+	}
+	slog.Info("Server stopped")
+}
+
+// fatal logs err and exits.
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	os.Exit(1)
 }
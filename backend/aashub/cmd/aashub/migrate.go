@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strconv"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/aas-hub-org/aashub/internal/database"
+	"github.com/aas-hub-org/aashub/internal/database/migrations"
+	"github.com/aas-hub-org/aashub/internal/logging"
+)
+
+const migrateUsage = `usage: aashub migrate [flags] <command>
+
+commands:
+  up          apply all pending migrations
+  down [n]    revert the last n migrations (default 1)
+  status      list migrations and when they were applied`
+
+// runMigrate implements the "aashub migrate" subcommand.
+func runMigrate(args []string) {
+	cfg, rest, err := config.Load(args)
+	if err != nil {
+		fatal("Invalid configuration", err)
+	}
+	slog.SetDefault(logging.New(os.Stderr, cfg.Log))
+	if len(rest) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	db, err := database.NewDB(cfg.Database)
+	if err != nil {
+		fatal("Could not connect to the database", err)
+	}
+	defer db.Close()
+
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		fatal("Could not load migrations", err)
+	}
+
+	ctx := context.Background()
+	switch rest[0] {
+	case "up":
+		applied, err := migrator.Up(ctx)
+		if err != nil {
+			fatal("Migration failed", err)
+		}
+		fmt.Printf("Applied %d migration(s)\n", applied)
+	case "down":
+		steps := 1
+		if len(rest) > 1 {
+			steps, err = strconv.Atoi(rest[1])
+			if err != nil || steps < 1 {
+				fatal("Invalid number of migrations to revert", fmt.Errorf("%q is not a positive number", rest[1]))
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		if err != nil {
+			fatal("Migration failed", err)
+		}
+		fmt.Printf("Reverted %d migration(s)\n", reverted)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			fatal("Could not read migration status", err)
+		}
+		for _, status := range statuses {
+			applied := "pending"
+			if status.AppliedAt != nil {
+				applied = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", status.Migration.Version, status.Migration.Name, applied)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
@@ -15,61 +15,226 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/health": {
+        "/concept-descriptions": {
             "get": {
-                "description": "Responds with OK if the service is up and running",
+                "description": "Returns the concept descriptions ordered by an internal key. isCaseOf and dataSpecificationRef are references serialized as JSON and encoded as base64url; they match if one of the isCaseOf references or data specifications equals them in type and keys.",
                 "produces": [
-                    "text/plain"
+                    "application/json"
                 ],
                 "tags": [
-                    "health"
+                    "concept-descriptions"
+                ],
+                "summary": "List concept descriptions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only concept descriptions with this idShort",
+                        "name": "idShort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only concept descriptions that are a case of this reference",
+                        "name": "isCaseOf",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only concept descriptions with this data specification",
+                        "name": "dataSpecificationRef",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of concept descriptions",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Health Check",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "A page of concept descriptions",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api_handler.GetConceptDescriptionsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
                         }
                     }
                 }
-            }
-        },
-        "/users/login": {
+            },
             "post": {
-                "description": "Logs in a user by identifier (username or email) and password, sets a cookie with a JWT token if successful, and returns the JWT token in the response.",
+                "description": "Stores a concept description given in JSON or XML. It must satisfy the metamodel constraints. Requires the session cookie or an \"Authorization: Bearer\" token.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json",
+                    "text/xml"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "users"
+                    "concept-descriptions"
                 ],
-                "summary": "User login and set cookie",
+                "summary": "Create a concept description",
+                "parameters": [
+                    {
+                        "description": "The concept description",
+                        "name": "conceptDescription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.ConceptDescription"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The stored concept description",
+                        "schema": {
+                            "$ref": "#/definitions/aas.ConceptDescription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid concept description",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A concept description with the same id exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/concept-descriptions/{cdIdentifier}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "concept-descriptions"
+                ],
+                "summary": "Get a concept description",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Username or Email",
-                        "name": "identifier",
-                        "in": "formData",
+                        "description": "The concept description's id, base64url encoded",
+                        "name": "cdIdentifier",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The concept description",
+                        "schema": {
+                            "$ref": "#/definitions/aas.ConceptDescription"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Concept description not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a concept description with the one given in JSON or XML, which must have the same id. Only the owner may replace a concept description.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "concept-descriptions"
+                ],
+                "summary": "Replace a concept description",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "Password",
-                        "name": "password",
-                        "in": "formData",
+                        "description": "The concept description's id, base64url encoded",
+                        "name": "cdIdentifier",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "The concept description",
+                        "name": "conceptDescription",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.ConceptDescription"
+                        }
                     }
                 ],
                 "responses": {
                     "204": {
-                        "description": "Successfully logged in"
+                        "description": "Concept description replaced"
                     },
                     "400": {
-                        "description": "Missing required field(s) or bad request",
+                        "description": "Invalid concept description",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -77,66 +242,122 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "403": {
+                        "description": "The concept description belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "Concept description not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/api_handler.Result"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner may delete a concept description. Semantic ids referring to it are kept.",
+                "tags": [
+                    "concept-descriptions"
+                ],
+                "summary": "Delete a concept description",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The concept description's id, base64url encoded",
+                        "name": "cdIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Concept description deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
                                 "type": "string"
                             }
                         }
+                    },
+                    "403": {
+                        "description": "The concept description belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Concept description not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
                     }
                 }
             }
         },
-        "/users/register": {
+        "/convert": {
             "post": {
-                "description": "Registers a new user with the provided username, email, and password.",
+                "description": "Reads an environment in the serialization given by the Content-Type and writes it in the one requested by the Accept header. Without a preference, JSON is converted to XML and XML to JSON. The environment is not validated; use /validate for that.",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/xml"
                 ],
                 "tags": [
-                    "users"
+                    "aas"
                 ],
-                "summary": "Register a new user",
+                "summary": "Convert an AAS environment between JSON and XML",
                 "parameters": [
                     {
-                        "description": "User to register",
-                        "name": "user",
+                        "description": "Environment to convert",
+                        "name": "environment",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/api_handler.APIUser"
+                            "$ref": "#/definitions/aas.Environment"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully registered the user",
+                    "200": {
+                        "description": "The converted environment",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/aas.Environment"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
+                        "description": "The body is not an AAS environment",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
                         "schema": {
                             "type": "string"
                         }
@@ -144,72 +365,4680 @@ const docTemplate = `{
                 }
             }
         },
-        "/verify": {
+        "/health": {
             "get": {
-                "description": "Verifies a user using base64 URL encoded email and verification code.",
-                "consumes": [
+                "description": "Responds with OK if the service is up and running",
+                "produces": [
+                    "text/plain"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Health Check",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/livez": {
+            "get": {
+                "description": "Responds with OK as long as the server is able to handle requests. It does not check any dependency.",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "Server is alive",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/lookup/shells": {
+            "get": {
+                "description": "Returns the ids of the shells that all given asset ids are linked to, ordered by an internal key. Each assetIds is an asset id like {\"name\": \"serialNumber\", \"value\": \"4711\"} serialized as JSON and encoded as base64url; a globalAssetId is looked up under the name \"globalAssetId\". Without assetIds the ids of all shells with asset ids are returned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "verification"
+                    "lookup"
                 ],
-                "summary": "Verify user",
+                "summary": "Look up shells by asset ids",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Base64 URL Encoded Email",
-                        "name": "email",
-                        "in": "query",
-                        "required": true
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Asset ids, base64url encoded",
+                        "name": "assetIds",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of shell ids",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Base64 URL Encoded Verification Code",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User verified successfully",
+                        "description": "A page of shell ids",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api_handler.GetShellIdsResult"
                         }
                     },
                     "400": {
-                        "description": "Invalid email or code",
+                        "description": "Invalid parameters",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api_handler.Result"
                         }
                     },
                     "500": {
-                        "description": "Verification failed",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/api_handler.Result"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "api_handler.APIUser": {
-            "type": "object",
-            "properties": {
-                "email": {
-                    "type": "string"
-                },
-                "password": {
-                    "type": "string"
-                },
+        },
+        "/lookup/shells/{aasIdentifier}": {
+            "get": {
+                "description": "Returns the asset ids linked to a shell id explicitly together with those of the stored shell and of its registered descriptor.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lookup"
+                ],
+                "summary": "Get the asset ids of a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The asset ids",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/aas.SpecificAssetId"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "No asset ids are linked to the shell",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Replaces the asset ids linked explicitly to a shell id, which need not belong to a stored shell. Only the owner of a stored shell or shell descriptor with that id, or otherwise the user who linked the first asset ids to it, may change them. Requires the session cookie or an \"Authorization: Bearer\" token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "lookup"
+                ],
+                "summary": "Link asset ids to a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The asset ids",
+                        "name": "assetIds",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/aas.SpecificAssetId"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The linked asset ids",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/aas.SpecificAssetId"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid asset ids",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The links belong to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes the asset ids linked explicitly to a shell id. The asset ids of the stored shell and of its registered descriptor are kept. Only the user who may change the asset ids may remove them.",
+                "tags": [
+                    "lookup"
+                ],
+                "summary": "Unlink the asset ids of a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Asset ids unlinked"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The links belong to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "No asset ids are linked to the shell",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages": {
+            "post": {
+                "description": "Parses the OPC relationships of the package to find its AAS spec parts (JSON or XML), supplementary files and thumbnail. The package is validated like with /validate and stored together with its shells and submodels, which are owned by the uploader. Requires the session cookie or an \"Authorization: Bearer\" token.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Upload an AASX package",
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "AASX package",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The stored package",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.APIPackage"
+                        }
+                    },
+                    "400": {
+                        "description": "Not an AASX package",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "A shell or submodel with the same id already exists",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "413": {
+                        "description": "Package too large",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "422": {
+                        "description": "The package violates metamodel constraints",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.ValidationResult"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/assemble": {
+            "post": {
+                "description": "Writes the selected shells and submodels into the JSON spec part of a new package. Attachments and the thumbnail are taken from uploaded packages; File elements should refer to the attachments by their path.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/asset-administration-shell-package"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Assemble an AASX package",
+                "parameters": [
+                    {
+                        "description": "Content of the package",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.AssembleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The AASX package",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "A shell, submodel or file does not exist",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}": {
+            "get": {
+                "description": "Returns the metadata of an uploaded package and the ids of the shells and submodels extracted from it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Get an AASX package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The package",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.APIPackage"
+                        }
+                    },
+                    "404": {
+                        "description": "Package not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/packages/{id}/download": {
+            "get": {
+                "description": "Returns the AASX file of an uploaded package.",
+                "produces": [
+                    "application/asset-administration-shell-package"
+                ],
+                "tags": [
+                    "packages"
+                ],
+                "summary": "Download an AASX package",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Package ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The AASX package",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "404": {
+                        "description": "Package not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Checks the database and the mail transport and reports the result of every check. Responds with 503 if a check fails or the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "Server is ready",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "A dependency is unavailable or the server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/shell-descriptors": {
+            "get": {
+                "description": "Returns the shell descriptors ordered by an internal key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "List shell descriptors",
+                "parameters": [
+                    {
+                        "enum": [
+                            "Type",
+                            "Instance",
+                            "NotApplicable"
+                        ],
+                        "type": "string",
+                        "description": "Only descriptors of shells with this asset kind",
+                        "name": "assetKind",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only descriptors of shells with this asset type, base64url encoded",
+                        "name": "assetType",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of descriptors",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of shell descriptors",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetShellDescriptorsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers a shell descriptor with its submodel descriptors. Requires the session cookie or an \"Authorization: Bearer\" token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Register a shell descriptor",
+                "parameters": [
+                    {
+                        "description": "The shell descriptor",
+                        "name": "descriptor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShellDescriptor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The registered descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShellDescriptor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "A descriptor with the same id exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shell-descriptors/{aasIdentifier}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Get a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The shell descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShellDescriptor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a shell descriptor, including its submodel descriptors, with one that has the same id. Only the owner may replace a descriptor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Replace a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The shell descriptor",
+                        "name": "descriptor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShellDescriptor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Shell descriptor replaced"
+                    },
+                    "400": {
+                        "description": "Invalid descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner may delete a descriptor. Its submodel descriptors are deleted with it.",
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Delete a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Shell descriptor deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shell-descriptors/{aasIdentifier}/submodel-descriptors": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "List the submodel descriptors of a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of descriptors",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of submodel descriptors",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetSubmodelDescriptorsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Only the owner of the shell descriptor may add submodel descriptors.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Add a submodel descriptor to a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel descriptor",
+                        "name": "descriptor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The added descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "409": {
+                        "description": "A submodel descriptor with the same id exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shell-descriptors/{aasIdentifier}/submodel-descriptors/{submodelIdentifier}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Get a submodel descriptor of a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The submodel descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell or submodel descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a submodel descriptor with one that has the same id. Only the owner of the shell descriptor may replace it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Replace a submodel descriptor of a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel descriptor",
+                        "name": "descriptor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submodel descriptor replaced"
+                    },
+                    "400": {
+                        "description": "Invalid descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell or submodel descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner of the shell descriptor may delete its submodel descriptors.",
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Delete a submodel descriptor of a shell descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submodel descriptor deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell or submodel descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shells": {
+            "get": {
+                "description": "Returns the shells ordered by an internal key. If there are more than limit shells, paging_metadata.cursor is to be passed as cursor to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "List shells",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only shells with this idShort",
+                        "name": "idShort",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of shells",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of shells",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetShellsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores a shell given in JSON or XML. The shell must satisfy the metamodel constraints. Requires the session cookie or an \"Authorization: Bearer\" token.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Create a shell",
+                "parameters": [
+                    {
+                        "description": "The shell",
+                        "name": "shell",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShell"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The stored shell",
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShell"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid shell",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A shell with the same id exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shells/{aasIdentifier}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Get a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The shell",
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShell"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a shell with the one given in JSON or XML, which must have the same id. Only the owner may replace a shell.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Replace a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The shell",
+                        "name": "shell",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetAdministrationShell"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Shell replaced"
+                    },
+                    "400": {
+                        "description": "Invalid shell",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner may delete a shell. The submodels it refers to are kept.",
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Delete a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Shell deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shells/{aasIdentifier}/asset-information": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Get the asset information of a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The asset information",
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetInformation"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Only the owner of the shell may replace its asset information.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Replace the asset information of a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The asset information",
+                        "name": "assetInformation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.AssetInformation"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Asset information replaced"
+                    },
+                    "400": {
+                        "description": "Invalid asset information",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shells/{aasIdentifier}/submodel-refs": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "List the submodel references of a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of references",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of references",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetReferencesResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Only the owner of the shell may add references. The reference must be a model reference to a submodel.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Add a submodel reference to a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The reference",
+                        "name": "reference",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.Reference"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The added reference",
+                        "schema": {
+                            "$ref": "#/definitions/aas.Reference"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid reference",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The shell already refers to the submodel",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/shells/{aasIdentifier}/submodel-refs/{submodelIdentifier}": {
+            "delete": {
+                "description": "Only the owner of the shell may remove references. The submodel itself is kept.",
+                "tags": [
+                    "shells"
+                ],
+                "summary": "Remove a submodel reference from a shell",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The shell's id, base64url encoded",
+                        "name": "aasIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Reference removed"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The shell belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Shell or reference not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodel-descriptors": {
+            "get": {
+                "description": "Returns the submodel descriptors registered without a shell, ordered by an internal key.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "List submodel descriptors",
+                "parameters": [
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of descriptors",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of submodel descriptors",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetSubmodelDescriptorsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Requires the session cookie or an \"Authorization: Bearer\" token.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Register a submodel descriptor",
+                "parameters": [
+                    {
+                        "description": "The submodel descriptor",
+                        "name": "descriptor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The registered descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "409": {
+                        "description": "A descriptor with the same id exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodel-descriptors/{submodelIdentifier}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Get a submodel descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The submodel descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a submodel descriptor with one that has the same id. Only the owner may replace a descriptor.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Replace a submodel descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel descriptor",
+                        "name": "descriptor",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.SubmodelDescriptor"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submodel descriptor replaced"
+                    },
+                    "400": {
+                        "description": "Invalid descriptor",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner may delete a descriptor.",
+                "tags": [
+                    "registry"
+                ],
+                "summary": "Delete a submodel descriptor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submodel descriptor deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The descriptor belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel descriptor not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels": {
+            "get": {
+                "description": "Returns the submodels ordered by an internal key. If there are more than limit submodels, paging_metadata.cursor is to be passed as cursor to get the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "List submodels",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only submodels with this idShort",
+                        "name": "idShort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only submodels whose semantic id has this first key, base64url encoded",
+                        "name": "semanticId",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "Elements to return",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "withBlobValue",
+                            "withoutBlobValue"
+                        ],
+                        "type": "string",
+                        "default": "withoutBlobValue",
+                        "description": "Whether to return the content of blobs",
+                        "name": "extent",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of submodels",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of submodels",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetSubmodelsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Stores a submodel given in JSON or XML. The submodel must satisfy the metamodel constraints. Requires the session cookie or an \"Authorization: Bearer\" token.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Create a submodel",
+                "parameters": [
+                    {
+                        "description": "The submodel",
+                        "name": "submodel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.Submodel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The stored submodel",
+                        "schema": {
+                            "$ref": "#/definitions/aas.Submodel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid submodel",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "A submodel with the same id exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "Elements to return",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "withBlobValue",
+                            "withoutBlobValue"
+                        ],
+                        "type": "string",
+                        "default": "withoutBlobValue",
+                        "description": "Whether to return the content of blobs",
+                        "name": "extent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return a ResolvedResult in JSON with the concept descriptions of the semantic ids",
+                        "name": "resolveSemanticIds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The submodel",
+                        "schema": {
+                            "$ref": "#/definitions/aas.Submodel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a submodel with the one given in JSON or XML, which must have the same id. Only the owner may replace a submodel.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Replace a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel",
+                        "name": "submodel",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.Submodel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submodel replaced"
+                    },
+                    "400": {
+                        "description": "Invalid submodel",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The submodel belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner may delete a submodel. References of shells to it are kept.",
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Delete a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Submodel deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The submodel belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/$metadata": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get the metadata of a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The submodel without submodelElements",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/$path": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get the idShortPaths of a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "core for the direct children only",
+                        "name": "level",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The idShortPaths",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/$reference": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get a reference to a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The model reference",
+                        "schema": {
+                            "$ref": "#/definitions/aas.Reference"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/$value": {
+            "get": {
+                "description": "Returns the value-only serialization: an object of the values of the submodel elements by their idShort.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get the value of a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "Elements to return",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "withBlobValue",
+                            "withoutBlobValue"
+                        ],
+                        "type": "string",
+                        "default": "withoutBlobValue",
+                        "description": "Whether to return the content of blobs",
+                        "name": "extent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The values by idShort",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/submodel-elements": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "List the elements of a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "Elements to return",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "withBlobValue",
+                            "withoutBlobValue"
+                        ],
+                        "type": "string",
+                        "default": "withoutBlobValue",
+                        "description": "Whether to return the content of blobs",
+                        "name": "extent",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum number of elements",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of elements",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.GetSubmodelElementsResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Only the owner of the submodel may add elements. The idShort of the element must be unique in the submodel.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Add an element to a submodel",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel element",
+                        "name": "element",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The added element",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid element",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The submodel belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "An element with the same idShort exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/submodel-elements/{idShortPath}": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "Elements to return",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "withBlobValue",
+                            "withoutBlobValue"
+                        ],
+                        "type": "string",
+                        "default": "withoutBlobValue",
+                        "description": "Whether to return the content of blobs",
+                        "name": "extent",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return a ResolvedResult in JSON with the concept descriptions of the semantic ids",
+                        "name": "resolveSemanticIds",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The submodel element",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier, path or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Only the owner of the submodel may replace elements. The element must keep its idShort.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Replace a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel element",
+                        "name": "element",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Element replaced"
+                    },
+                    "400": {
+                        "description": "Invalid element or path",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The submodel belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Only the owner of the submodel may add elements. The element at the idShortPath must be a collection, list, entity or annotated relationship; in all but lists, the idShort of the new element must be unique.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Add an element below a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the parent, e.g. Documents",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "The submodel element",
+                        "name": "element",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "object"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "The added element",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid element or path",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The submodel belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "406": {
+                        "description": "Neither JSON nor XML is acceptable",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "An element with the same idShort exists",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Only the owner of the submodel may delete elements. Deleting an element of a list shifts the indices of the following elements.",
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Delete a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Element deleted"
+                    },
+                    "400": {
+                        "description": "Invalid identifier or path",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "The submodel belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$metadata": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get the metadata of a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The element without its value",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or path",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$path": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get the idShortPaths of a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "core for the direct children only",
+                        "name": "level",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The idShortPaths",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier, path or parameters",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$reference": {
+            "get": {
+                "produces": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get a reference to a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The model reference",
+                        "schema": {
+                            "$ref": "#/definitions/aas.Reference"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid identifier or path",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/submodels/{submodelIdentifier}/submodel-elements/{idShortPath}/$value": {
+            "get": {
+                "description": "Returns the value-only serialization of the element. Operations and capabilities have no value.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "submodels"
+                ],
+                "summary": "Get the value of a submodel element",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "The submodel's id, base64url encoded",
+                        "name": "submodelIdentifier",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "The idShortPath of the element, e.g. Documents[0].File",
+                        "name": "idShortPath",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "core",
+                            "deep"
+                        ],
+                        "type": "string",
+                        "default": "deep",
+                        "description": "Elements to return",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "withBlobValue",
+                            "withoutBlobValue"
+                        ],
+                        "type": "string",
+                        "default": "withoutBlobValue",
+                        "description": "Whether to return the content of blobs",
+                        "name": "extent",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The value",
+                        "schema": {}
+                    },
+                    "400": {
+                        "description": "Invalid identifier, path or parameters, or the element has no value",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "404": {
+                        "description": "Submodel or element not found",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.Result"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/login": {
+            "post": {
+                "description": "Logs in a user by identifier (username or email) and password, sets a cookie with a JWT token if successful, and returns the JWT token in the response.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "User login and set cookie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username or Email",
+                        "name": "identifier",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Password",
+                        "name": "password",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Successfully logged in"
+                    },
+                    "400": {
+                        "description": "Missing required field(s) or bad request",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/users/register": {
+            "post": {
+                "description": "Registers a new user with the provided username, email, and password. The verification e-mail is sent in the given locale, falling back to the Accept-Language header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User to register",
+                        "name": "user",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.APIUser"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "Preferred locale of the verification e-mail",
+                        "name": "Accept-Language",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully registered the user",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already registered",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/validate": {
+            "post": {
+                "description": "Checks an environment in the JSON or XML serialization of the metamodel v3.0, as given by the Content-Type, against the constraints of the metamodel, e.g. the idShort format (AASd-002) or the uniqueness of idShorts (AASd-022). Every violation is reported with the JSON path of the offending value.",
+                "consumes": [
+                    "application/json",
+                    "text/xml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "aas"
+                ],
+                "summary": "Validate an AAS environment",
+                "parameters": [
+                    {
+                        "description": "Environment to validate",
+                        "name": "environment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/aas.Environment"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The validation result",
+                        "schema": {
+                            "$ref": "#/definitions/api_handler.ValidationResult"
+                        }
+                    },
+                    "400": {
+                        "description": "The body is not an AAS environment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify": {
+            "get": {
+                "description": "Verifies a user using base64 URL encoded email and verification code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "verification"
+                ],
+                "summary": "Verify user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Base64 URL Encoded Email",
+                        "name": "email",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Base64 URL Encoded Verification Code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User verified successfully",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid email or code",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Verification failed",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "aas.AdministrativeInformation": {
+            "type": "object",
+            "properties": {
+                "creator": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "embeddedDataSpecifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.EmbeddedDataSpecification"
+                    }
+                },
+                "revision": {
+                    "type": "string"
+                },
+                "templateId": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.AssetAdministrationShell": {
+            "type": "object",
+            "properties": {
+                "administration": {
+                    "$ref": "#/definitions/aas.AdministrativeInformation"
+                },
+                "assetInformation": {
+                    "$ref": "#/definitions/aas.AssetInformation"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "derivedFrom": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "description": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "displayName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "embeddedDataSpecifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.EmbeddedDataSpecification"
+                    }
+                },
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Extension"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idShort": {
+                    "type": "string"
+                },
+                "submodels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                }
+            }
+        },
+        "aas.AssetAdministrationShellDescriptor": {
+            "type": "object",
+            "properties": {
+                "administration": {
+                    "$ref": "#/definitions/aas.AdministrativeInformation"
+                },
+                "assetKind": {
+                    "$ref": "#/definitions/aas.AssetKind"
+                },
+                "assetType": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "displayName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Endpoint"
+                    }
+                },
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Extension"
+                    }
+                },
+                "globalAssetId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idShort": {
+                    "type": "string"
+                },
+                "specificAssetIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.SpecificAssetId"
+                    }
+                },
+                "submodelDescriptors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.SubmodelDescriptor"
+                    }
+                }
+            }
+        },
+        "aas.AssetInformation": {
+            "type": "object",
+            "properties": {
+                "assetKind": {
+                    "$ref": "#/definitions/aas.AssetKind"
+                },
+                "assetType": {
+                    "type": "string"
+                },
+                "defaultThumbnail": {
+                    "$ref": "#/definitions/aas.Resource"
+                },
+                "globalAssetId": {
+                    "type": "string"
+                },
+                "specificAssetIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.SpecificAssetId"
+                    }
+                }
+            }
+        },
+        "aas.AssetKind": {
+            "type": "string",
+            "enum": [
+                "Type",
+                "Instance",
+                "NotApplicable"
+            ],
+            "x-enum-varnames": [
+                "AssetKindType",
+                "AssetKindInstance",
+                "AssetKindNotApplicable"
+            ]
+        },
+        "aas.ConceptDescription": {
+            "type": "object",
+            "properties": {
+                "administration": {
+                    "$ref": "#/definitions/aas.AdministrativeInformation"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "displayName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "embeddedDataSpecifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.EmbeddedDataSpecification"
+                    }
+                },
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Extension"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idShort": {
+                    "type": "string"
+                },
+                "isCaseOf": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                }
+            }
+        },
+        "aas.DataSpecificationIec61360": {
+            "type": "object",
+            "properties": {
+                "dataType": {
+                    "$ref": "#/definitions/aas.DataTypeIec61360"
+                },
+                "definition": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "levelType": {
+                    "$ref": "#/definitions/aas.LevelType"
+                },
+                "preferredName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "shortName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "sourceOfDefinition": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "unit": {
+                    "type": "string"
+                },
+                "unitId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "valueFormat": {
+                    "type": "string"
+                },
+                "valueList": {
+                    "$ref": "#/definitions/aas.ValueList"
+                }
+            }
+        },
+        "aas.DataTypeDefXsd": {
+            "type": "string",
+            "enum": [
+                "xs:anyURI",
+                "xs:base64Binary",
+                "xs:boolean",
+                "xs:byte",
+                "xs:date",
+                "xs:dateTime",
+                "xs:decimal",
+                "xs:double",
+                "xs:duration",
+                "xs:float",
+                "xs:gDay",
+                "xs:gMonth",
+                "xs:gMonthDay",
+                "xs:gYear",
+                "xs:gYearMonth",
+                "xs:hexBinary",
+                "xs:int",
+                "xs:integer",
+                "xs:long",
+                "xs:negativeInteger",
+                "xs:nonNegativeInteger",
+                "xs:nonPositiveInteger",
+                "xs:positiveInteger",
+                "xs:short",
+                "xs:string",
+                "xs:time",
+                "xs:unsignedByte",
+                "xs:unsignedInt",
+                "xs:unsignedLong",
+                "xs:unsignedShort"
+            ],
+            "x-enum-varnames": [
+                "DataTypeDefXsdAnyURI",
+                "DataTypeDefXsdBase64Binary",
+                "DataTypeDefXsdBoolean",
+                "DataTypeDefXsdByte",
+                "DataTypeDefXsdDate",
+                "DataTypeDefXsdDateTime",
+                "DataTypeDefXsdDecimal",
+                "DataTypeDefXsdDouble",
+                "DataTypeDefXsdDuration",
+                "DataTypeDefXsdFloat",
+                "DataTypeDefXsdGDay",
+                "DataTypeDefXsdGMonth",
+                "DataTypeDefXsdGMonthDay",
+                "DataTypeDefXsdGYear",
+                "DataTypeDefXsdGYearMonth",
+                "DataTypeDefXsdHexBinary",
+                "DataTypeDefXsdInt",
+                "DataTypeDefXsdInteger",
+                "DataTypeDefXsdLong",
+                "DataTypeDefXsdNegativeInteger",
+                "DataTypeDefXsdNonNegativeInteger",
+                "DataTypeDefXsdNonPositiveInteger",
+                "DataTypeDefXsdPositiveInteger",
+                "DataTypeDefXsdShort",
+                "DataTypeDefXsdString",
+                "DataTypeDefXsdTime",
+                "DataTypeDefXsdUnsignedByte",
+                "DataTypeDefXsdUnsignedInt",
+                "DataTypeDefXsdUnsignedLong",
+                "DataTypeDefXsdUnsignedShort"
+            ]
+        },
+        "aas.DataTypeIec61360": {
+            "type": "string",
+            "enum": [
+                "DATE",
+                "STRING",
+                "STRING_TRANSLATABLE",
+                "INTEGER_MEASURE",
+                "INTEGER_COUNT",
+                "INTEGER_CURRENCY",
+                "REAL_MEASURE",
+                "REAL_COUNT",
+                "REAL_CURRENCY",
+                "BOOLEAN",
+                "IRI",
+                "IRDI",
+                "RATIONAL",
+                "RATIONAL_MEASURE",
+                "TIME",
+                "TIMESTAMP",
+                "FILE",
+                "HTML",
+                "BLOB"
+            ],
+            "x-enum-varnames": [
+                "DataTypeIec61360Date",
+                "DataTypeIec61360String",
+                "DataTypeIec61360StringTranslatable",
+                "DataTypeIec61360IntegerMeasure",
+                "DataTypeIec61360IntegerCount",
+                "DataTypeIec61360IntegerCurrency",
+                "DataTypeIec61360RealMeasure",
+                "DataTypeIec61360RealCount",
+                "DataTypeIec61360RealCurrency",
+                "DataTypeIec61360Boolean",
+                "DataTypeIec61360Iri",
+                "DataTypeIec61360Irdi",
+                "DataTypeIec61360Rational",
+                "DataTypeIec61360RationalMeasure",
+                "DataTypeIec61360Time",
+                "DataTypeIec61360Timestamp",
+                "DataTypeIec61360File",
+                "DataTypeIec61360Html",
+                "DataTypeIec61360Blob"
+            ]
+        },
+        "aas.EmbeddedDataSpecification": {
+            "type": "object",
+            "properties": {
+                "dataSpecification": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "dataSpecificationContent": {
+                    "$ref": "#/definitions/aas.DataSpecificationIec61360"
+                }
+            }
+        },
+        "aas.Endpoint": {
+            "type": "object",
+            "properties": {
+                "interface": {
+                    "type": "string"
+                },
+                "protocolInformation": {
+                    "$ref": "#/definitions/aas.ProtocolInformation"
+                }
+            }
+        },
+        "aas.Environment": {
+            "type": "object",
+            "properties": {
+                "assetAdministrationShells": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.AssetAdministrationShell"
+                    }
+                },
+                "conceptDescriptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.ConceptDescription"
+                    }
+                },
+                "submodels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Submodel"
+                    }
+                }
+            }
+        },
+        "aas.Extension": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "refersTo": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                },
+                "semanticId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "supplementalSemanticIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                },
+                "value": {
+                    "type": "string"
+                },
+                "valueType": {
+                    "$ref": "#/definitions/aas.DataTypeDefXsd"
+                }
+            }
+        },
+        "aas.Key": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "$ref": "#/definitions/aas.KeyTypes"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.KeyTypes": {
+            "type": "string",
+            "enum": [
+                "AnnotatedRelationshipElement",
+                "AssetAdministrationShell",
+                "BasicEventElement",
+                "Blob",
+                "Capability",
+                "ConceptDescription",
+                "DataElement",
+                "Entity",
+                "EventElement",
+                "File",
+                "FragmentReference",
+                "GlobalReference",
+                "Identifiable",
+                "MultiLanguageProperty",
+                "Operation",
+                "Property",
+                "Range",
+                "Referable",
+                "ReferenceElement",
+                "RelationshipElement",
+                "Submodel",
+                "SubmodelElement",
+                "SubmodelElementCollection",
+                "SubmodelElementList"
+            ],
+            "x-enum-varnames": [
+                "KeyTypesAnnotatedRelationshipElement",
+                "KeyTypesAssetAdministrationShell",
+                "KeyTypesBasicEventElement",
+                "KeyTypesBlob",
+                "KeyTypesCapability",
+                "KeyTypesConceptDescription",
+                "KeyTypesDataElement",
+                "KeyTypesEntity",
+                "KeyTypesEventElement",
+                "KeyTypesFile",
+                "KeyTypesFragmentReference",
+                "KeyTypesGlobalReference",
+                "KeyTypesIdentifiable",
+                "KeyTypesMultiLanguageProperty",
+                "KeyTypesOperation",
+                "KeyTypesProperty",
+                "KeyTypesRange",
+                "KeyTypesReferable",
+                "KeyTypesReferenceElement",
+                "KeyTypesRelationshipElement",
+                "KeyTypesSubmodel",
+                "KeyTypesSubmodelElement",
+                "KeyTypesSubmodelElementCollection",
+                "KeyTypesSubmodelElementList"
+            ]
+        },
+        "aas.LangString": {
+            "type": "object",
+            "properties": {
+                "language": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.LevelType": {
+            "type": "object",
+            "properties": {
+                "max": {
+                    "type": "boolean"
+                },
+                "min": {
+                    "type": "boolean"
+                },
+                "nom": {
+                    "type": "boolean"
+                },
+                "typ": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "aas.ModellingKind": {
+            "type": "string",
+            "enum": [
+                "Template",
+                "Instance"
+            ],
+            "x-enum-varnames": [
+                "ModellingKindTemplate",
+                "ModellingKindInstance"
+            ]
+        },
+        "aas.ProtocolInformation": {
+            "type": "object",
+            "properties": {
+                "endpointProtocol": {
+                    "type": "string"
+                },
+                "endpointProtocolVersion": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "href": {
+                    "type": "string"
+                },
+                "securityAttributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.SecurityAttribute"
+                    }
+                },
+                "subprotocol": {
+                    "type": "string"
+                },
+                "subprotocolBody": {
+                    "type": "string"
+                },
+                "subprotocolBodyEncoding": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.Qualifier": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "$ref": "#/definitions/aas.QualifierKind"
+                },
+                "semanticId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "supplementalSemanticIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                },
+                "valueId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "valueType": {
+                    "$ref": "#/definitions/aas.DataTypeDefXsd"
+                }
+            }
+        },
+        "aas.QualifierKind": {
+            "type": "string",
+            "enum": [
+                "ValueQualifier",
+                "ConceptQualifier",
+                "TemplateQualifier"
+            ],
+            "x-enum-varnames": [
+                "QualifierKindValueQualifier",
+                "QualifierKindConceptQualifier",
+                "QualifierKindTemplateQualifier"
+            ]
+        },
+        "aas.Reference": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Key"
+                    }
+                },
+                "referredSemanticId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "type": {
+                    "$ref": "#/definitions/aas.ReferenceTypes"
+                }
+            }
+        },
+        "aas.ReferenceTypes": {
+            "type": "string",
+            "enum": [
+                "ExternalReference",
+                "ModelReference"
+            ],
+            "x-enum-varnames": [
+                "ReferenceTypesExternalReference",
+                "ReferenceTypesModelReference"
+            ]
+        },
+        "aas.Resource": {
+            "type": "object",
+            "properties": {
+                "contentType": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.SecurityAttribute": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "type": {
+                    "$ref": "#/definitions/aas.SecurityType"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.SecurityType": {
+            "type": "string",
+            "enum": [
+                "NONE",
+                "RFC_TLSA",
+                "W3C_DID"
+            ],
+            "x-enum-varnames": [
+                "SecurityTypeNone",
+                "SecurityTypeRfcTlsa",
+                "SecurityTypeW3cDid"
+            ]
+        },
+        "aas.SpecificAssetId": {
+            "type": "object",
+            "properties": {
+                "externalSubjectId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "semanticId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "supplementalSemanticIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "aas.Submodel": {
+            "type": "object",
+            "properties": {
+                "administration": {
+                    "$ref": "#/definitions/aas.AdministrativeInformation"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "displayName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "embeddedDataSpecifications": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.EmbeddedDataSpecification"
+                    }
+                },
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Extension"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idShort": {
+                    "type": "string"
+                },
+                "kind": {
+                    "$ref": "#/definitions/aas.ModellingKind"
+                },
+                "qualifiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Qualifier"
+                    }
+                },
+                "semanticId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "submodelElements": {
+                    "type": "array",
+                    "items": {}
+                },
+                "supplementalSemanticIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                }
+            }
+        },
+        "aas.SubmodelDescriptor": {
+            "type": "object",
+            "properties": {
+                "administration": {
+                    "$ref": "#/definitions/aas.AdministrativeInformation"
+                },
+                "description": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "displayName": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.LangString"
+                    }
+                },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Endpoint"
+                    }
+                },
+                "extensions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Extension"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "idShort": {
+                    "type": "string"
+                },
+                "semanticId": {
+                    "$ref": "#/definitions/aas.Reference"
+                },
+                "supplementalSemanticIds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                }
+            }
+        },
+        "aas.ValueList": {
+            "type": "object",
+            "properties": {
+                "valueReferencePairs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.ValueReferencePair"
+                    }
+                }
+            }
+        },
+        "aas.ValueReferencePair": {
+            "type": "object",
+            "properties": {
+                "value": {
+                    "type": "string"
+                },
+                "valueId": {
+                    "$ref": "#/definitions/aas.Reference"
+                }
+            }
+        },
+        "aas.Violation": {
+            "type": "object",
+            "properties": {
+                "constraint": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_handler.APIPackage": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "files": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "shells": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "submodels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api_handler.APIUser": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "locale": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                },
                 "username": {
                     "type": "string"
                 }
             }
+        },
+        "api_handler.AssembleRequest": {
+            "type": "object",
+            "properties": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api_handler.PackageFileRef"
+                    }
+                },
+                "filename": {
+                    "type": "string"
+                },
+                "shells": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "submodels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "thumbnail": {
+                    "$ref": "#/definitions/api_handler.PackageFileRef"
+                }
+            }
+        },
+        "api_handler.GetConceptDescriptionsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.ConceptDescription"
+                    }
+                }
+            }
+        },
+        "api_handler.GetReferencesResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Reference"
+                    }
+                }
+            }
+        },
+        "api_handler.GetShellDescriptorsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.AssetAdministrationShellDescriptor"
+                    }
+                }
+            }
+        },
+        "api_handler.GetShellIdsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "api_handler.GetShellsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.AssetAdministrationShell"
+                    }
+                }
+            }
+        },
+        "api_handler.GetSubmodelDescriptorsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.SubmodelDescriptor"
+                    }
+                }
+            }
+        },
+        "api_handler.GetSubmodelElementsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {}
+                }
+            }
+        },
+        "api_handler.GetSubmodelsResult": {
+            "type": "object",
+            "properties": {
+                "paging_metadata": {
+                    "$ref": "#/definitions/api_handler.PagingMetadata"
+                },
+                "result": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Submodel"
+                    }
+                }
+            }
+        },
+        "api_handler.Message": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "correlationId": {
+                    "type": "string"
+                },
+                "messageType": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_handler.PackageFileRef": {
+            "type": "object",
+            "properties": {
+                "packageId": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_handler.PagingMetadata": {
+            "type": "object",
+            "properties": {
+                "cursor": {
+                    "type": "string"
+                }
+            }
+        },
+        "api_handler.Result": {
+            "type": "object",
+            "properties": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/api_handler.Message"
+                    }
+                }
+            }
+        },
+        "api_handler.ValidationResult": {
+            "type": "object",
+            "properties": {
+                "valid": {
+                    "type": "boolean"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/aas.Violation"
+                    }
+                }
+            }
+        },
+        "health.CheckResult": {
+            "type": "object",
+            "properties": {
+                "duration": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/health.CheckResult"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
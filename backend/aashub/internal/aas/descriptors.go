@@ -0,0 +1,164 @@
+package aas
+
+import "fmt"
+
+// The descriptors of the AAS Registry and Submodel Registry services
+// (IDTA-01002 Part 2) tell where shells and submodels hosted elsewhere can be
+// fetched. They are only serialized as JSON.
+
+// AssetAdministrationShellDescriptor describes a shell and the endpoints
+// serving it.
+type AssetAdministrationShellDescriptor struct {
+	Description         []LangString               `json:"description,omitempty"`
+	DisplayName         []LangString               `json:"displayName,omitempty"`
+	Extensions          []Extension                `json:"extensions,omitempty"`
+	Administration      *AdministrativeInformation `json:"administration,omitempty"`
+	AssetKind           *AssetKind                 `json:"assetKind,omitempty"`
+	AssetType           *string                    `json:"assetType,omitempty"`
+	Endpoints           []Endpoint                 `json:"endpoints,omitempty"`
+	GlobalAssetId       *string                    `json:"globalAssetId,omitempty"`
+	IdShort             *string                    `json:"idShort,omitempty"`
+	Id                  string                     `json:"id"`
+	SpecificAssetIds    []SpecificAssetId          `json:"specificAssetIds,omitempty"`
+	SubmodelDescriptors []SubmodelDescriptor       `json:"submodelDescriptors,omitempty"`
+}
+
+// SubmodelDescriptor describes a submodel and the endpoints serving it.
+type SubmodelDescriptor struct {
+	Description             []LangString               `json:"description,omitempty"`
+	DisplayName             []LangString               `json:"displayName,omitempty"`
+	Extensions              []Extension                `json:"extensions,omitempty"`
+	Administration          *AdministrativeInformation `json:"administration,omitempty"`
+	IdShort                 *string                    `json:"idShort,omitempty"`
+	Id                      string                     `json:"id"`
+	SemanticId              *Reference                 `json:"semanticId,omitempty"`
+	SupplementalSemanticIds []Reference                `json:"supplementalSemanticIds,omitempty"`
+	Endpoints               []Endpoint                 `json:"endpoints"`
+}
+
+// Endpoint is an address of a service implementing interface, e.g.
+// "AAS-3.0" or "SUBMODEL-3.0".
+type Endpoint struct {
+	Interface           string              `json:"interface"`
+	ProtocolInformation ProtocolInformation `json:"protocolInformation"`
+}
+
+type ProtocolInformation struct {
+	Href                    string              `json:"href"`
+	EndpointProtocol        *string             `json:"endpointProtocol,omitempty"`
+	EndpointProtocolVersion []string            `json:"endpointProtocolVersion,omitempty"`
+	Subprotocol             *string             `json:"subprotocol,omitempty"`
+	SubprotocolBody         *string             `json:"subprotocolBody,omitempty"`
+	SubprotocolBodyEncoding *string             `json:"subprotocolBodyEncoding,omitempty"`
+	SecurityAttributes      []SecurityAttribute `json:"securityAttributes,omitempty"`
+}
+
+type SecurityAttribute struct {
+	Type  SecurityType `json:"type"`
+	Key   string       `json:"key"`
+	Value string       `json:"value"`
+}
+
+// SecurityType is the kind of a SecurityAttribute.
+type SecurityType string
+
+const (
+	SecurityTypeNone    SecurityType = "NONE"
+	SecurityTypeRfcTlsa SecurityType = "RFC_TLSA"
+	SecurityTypeW3cDid  SecurityType = "W3C_DID"
+)
+
+// Limits of the descriptors' strings set by the API specification.
+const (
+	maxInterfaceLength = 128
+	maxHrefLength      = 2048
+)
+
+// ValidateShellDescriptor checks a shell descriptor and its submodel
+// descriptors like Validate checks an environment. Paths start at the
+// descriptor.
+func ValidateShellDescriptor(descriptor *AssetAdministrationShellDescriptor) []Violation {
+	v := &validator{}
+	v.descriptor("$", descriptor.Description, descriptor.DisplayName, descriptor.Extensions, descriptor.Administration, descriptor.IdShort, descriptor.Id)
+	if kind := descriptor.AssetKind; kind != nil {
+		switch *kind {
+		case AssetKindType, AssetKindInstance, AssetKindNotApplicable:
+		default:
+			v.report("$.assetKind", constraintSchema, "invalid asset kind %q", *kind)
+		}
+	}
+	if descriptor.AssetType != nil {
+		v.nonEmpty("$.assetType", *descriptor.AssetType)
+	}
+	if descriptor.GlobalAssetId != nil {
+		v.nonEmpty("$.globalAssetId", *descriptor.GlobalAssetId)
+	}
+	v.specificAssetIds("$.specificAssetIds", descriptor.SpecificAssetIds)
+	v.endpoints("$.endpoints", descriptor.Endpoints, false)
+
+	ids := map[string]bool{}
+	for i := range descriptor.SubmodelDescriptors {
+		submodel := &descriptor.SubmodelDescriptors[i]
+		path := fmt.Sprintf("$.submodelDescriptors[%d]", i)
+		v.submodelDescriptor(path, submodel)
+		if ids[submodel.Id] {
+			v.report(path+".id", constraintSchema, "id %q is not unique among the submodel descriptors", submodel.Id)
+		}
+		ids[submodel.Id] = true
+	}
+	return v.violations
+}
+
+// ValidateSubmodelDescriptor checks a submodel descriptor like Validate
+// checks an environment. Paths start at the descriptor.
+func ValidateSubmodelDescriptor(descriptor *SubmodelDescriptor) []Violation {
+	v := &validator{}
+	v.submodelDescriptor("$", descriptor)
+	return v.violations
+}
+
+func (v *validator) submodelDescriptor(path string, descriptor *SubmodelDescriptor) {
+	v.descriptor(path, descriptor.Description, descriptor.DisplayName, descriptor.Extensions, descriptor.Administration, descriptor.IdShort, descriptor.Id)
+	v.semantics(path, descriptor.SemanticId, descriptor.SupplementalSemanticIds)
+	v.endpoints(path+".endpoints", descriptor.Endpoints, true)
+}
+
+// descriptor checks the attributes that descriptors share with identifiables.
+func (v *validator) descriptor(path string, description, displayName []LangString, extensions []Extension, administration *AdministrativeInformation, idShort *string, id string) {
+	v.identifiable(path, &Identifiable{
+		Referable:      Referable{Extensions: extensions, IdShort: idShort, DisplayName: displayName, Description: description},
+		Administration: administration,
+		Id:             id,
+	})
+}
+
+func (v *validator) endpoints(path string, endpoints []Endpoint, required bool) {
+	if required && len(endpoints) == 0 {
+		v.report(path, constraintSchema, "at least one endpoint is required")
+	}
+	for i := range endpoints {
+		endpoint := &endpoints[i]
+		endpointPath := fmt.Sprintf("%s[%d]", path, i)
+		v.nonEmpty(endpointPath+".interface", endpoint.Interface)
+		if len(endpoint.Interface) > maxInterfaceLength {
+			v.report(endpointPath+".interface", constraintSchema, "interface is longer than %d characters", maxInterfaceLength)
+		}
+
+		info := &endpoint.ProtocolInformation
+		infoPath := endpointPath + ".protocolInformation"
+		v.nonEmpty(infoPath+".href", info.Href)
+		if len(info.Href) > maxHrefLength {
+			v.report(infoPath+".href", constraintSchema, "href is longer than %d characters", maxHrefLength)
+		}
+		for j, attribute := range info.SecurityAttributes {
+			attributePath := fmt.Sprintf("%s.securityAttributes[%d]", infoPath, j)
+			switch attribute.Type {
+			case SecurityTypeNone, SecurityTypeRfcTlsa, SecurityTypeW3cDid:
+			default:
+				v.report(attributePath+".type", constraintSchema, "invalid security type %q", attribute.Type)
+			}
+			v.nonEmpty(attributePath+".key", attribute.Key)
+			v.nonEmpty(attributePath+".value", attribute.Value)
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package aas
+
+// SubmodelElement is implemented by pointers to the concrete element types,
+// e.g. *Property. ModelType returns the name used as "modelType" in JSON.
+type SubmodelElement interface {
+	ModelType() string
+	Base() *SubmodelElementBase
+}
+
+// SubmodelElementBase holds the attributes shared by all submodel elements.
+type SubmodelElementBase struct {
+	Referable
+	SemanticId                 *Reference                  `json:"semanticId,omitempty" xml:"semanticId,omitempty"`
+	SupplementalSemanticIds    []Reference                 `json:"supplementalSemanticIds,omitempty" xml:"supplementalSemanticIds>reference,omitempty"`
+	Qualifiers                 []Qualifier                 `json:"qualifiers,omitempty" xml:"qualifiers>qualifier,omitempty"`
+	EmbeddedDataSpecifications []EmbeddedDataSpecification `json:"embeddedDataSpecifications,omitempty" xml:"embeddedDataSpecifications>embeddedDataSpecification,omitempty"`
+}
+
+func (b *SubmodelElementBase) Base() *SubmodelElementBase { return b }
+
+type Property struct {
+	SubmodelElementBase
+	ValueType DataTypeDefXsd `json:"valueType" xml:"valueType"`
+	Value     *string        `json:"value,omitempty" xml:"value,omitempty"`
+	ValueId   *Reference     `json:"valueId,omitempty" xml:"valueId,omitempty"`
+}
+
+type MultiLanguageProperty struct {
+	SubmodelElementBase
+	Value   []LangString `json:"value,omitempty" xml:"value>langStringTextType,omitempty"`
+	ValueId *Reference   `json:"valueId,omitempty" xml:"valueId,omitempty"`
+}
+
+type Range struct {
+	SubmodelElementBase
+	ValueType DataTypeDefXsd `json:"valueType" xml:"valueType"`
+	Min       *string        `json:"min,omitempty" xml:"min,omitempty"`
+	Max       *string        `json:"max,omitempty" xml:"max,omitempty"`
+}
+
+// Blob holds its content inline; it is base64 encoded in JSON.
+type Blob struct {
+	SubmodelElementBase
+	Value       []byte `json:"value,omitempty" xml:"value,omitempty"`
+	ContentType string `json:"contentType" xml:"contentType"`
+}
+
+type File struct {
+	SubmodelElementBase
+	Value       *string `json:"value,omitempty" xml:"value,omitempty"`
+	ContentType string  `json:"contentType" xml:"contentType"`
+}
+
+type ReferenceElement struct {
+	SubmodelElementBase
+	Value *Reference `json:"value,omitempty" xml:"value,omitempty"`
+}
+
+type RelationshipElement struct {
+	SubmodelElementBase
+	First  *Reference `json:"first,omitempty" xml:"first,omitempty"`
+	Second *Reference `json:"second,omitempty" xml:"second,omitempty"`
+}
+
+// AnnotatedRelationshipElement repeats the fields of RelationshipElement
+// rather than embedding it, so that it does not inherit its JSON methods.
+type AnnotatedRelationshipElement struct {
+	SubmodelElementBase
+	First       *Reference       `json:"first,omitempty" xml:"first,omitempty"`
+	Second      *Reference       `json:"second,omitempty" xml:"second,omitempty"`
+	Annotations SubmodelElements `json:"annotations,omitempty" xml:"annotations,omitempty"`
+}
+
+type Entity struct {
+	SubmodelElementBase
+	Statements       SubmodelElements  `json:"statements,omitempty" xml:"statements,omitempty"`
+	EntityType       EntityType        `json:"entityType" xml:"entityType"`
+	GlobalAssetId    *string           `json:"globalAssetId,omitempty" xml:"globalAssetId,omitempty"`
+	SpecificAssetIds []SpecificAssetId `json:"specificAssetIds,omitempty" xml:"specificAssetIds>specificAssetId,omitempty"`
+}
+
+type BasicEventElement struct {
+	SubmodelElementBase
+	Observed      Reference    `json:"observed" xml:"observed"`
+	Direction     Direction    `json:"direction" xml:"direction"`
+	State         StateOfEvent `json:"state" xml:"state"`
+	MessageTopic  *string      `json:"messageTopic,omitempty" xml:"messageTopic,omitempty"`
+	MessageBroker *Reference   `json:"messageBroker,omitempty" xml:"messageBroker,omitempty"`
+	LastUpdate    *string      `json:"lastUpdate,omitempty" xml:"lastUpdate,omitempty"`
+	MinInterval   *string      `json:"minInterval,omitempty" xml:"minInterval,omitempty"`
+	MaxInterval   *string      `json:"maxInterval,omitempty" xml:"maxInterval,omitempty"`
+}
+
+type Capability struct {
+	SubmodelElementBase
+}
+
+type Operation struct {
+	SubmodelElementBase
+	InputVariables    []OperationVariable `json:"inputVariables,omitempty" xml:"inputVariables>operationVariable,omitempty"`
+	OutputVariables   []OperationVariable `json:"outputVariables,omitempty" xml:"outputVariables>operationVariable,omitempty"`
+	InoutputVariables []OperationVariable `json:"inoutputVariables,omitempty" xml:"inoutputVariables>operationVariable,omitempty"`
+}
+
+type OperationVariable struct {
+	Value SubmodelElement `json:"value" xml:"value"`
+}
+
+type SubmodelElementCollection struct {
+	SubmodelElementBase
+	Value SubmodelElements `json:"value,omitempty" xml:"value,omitempty"`
+}
+
+type SubmodelElementList struct {
+	SubmodelElementBase
+	OrderRelevant         *bool               `json:"orderRelevant,omitempty" xml:"orderRelevant,omitempty"`
+	SemanticIdListElement *Reference          `json:"semanticIdListElement,omitempty" xml:"semanticIdListElement,omitempty"`
+	TypeValueListElement  AasSubmodelElements `json:"typeValueListElement" xml:"typeValueListElement"`
+	ValueTypeListElement  *DataTypeDefXsd     `json:"valueTypeListElement,omitempty" xml:"valueTypeListElement,omitempty"`
+	Value                 SubmodelElements    `json:"value,omitempty" xml:"value,omitempty"`
+}
+
+func (*Property) ModelType() string                     { return "Property" }
+func (*MultiLanguageProperty) ModelType() string        { return "MultiLanguageProperty" }
+func (*Range) ModelType() string                        { return "Range" }
+func (*Blob) ModelType() string                         { return "Blob" }
+func (*File) ModelType() string                         { return "File" }
+func (*ReferenceElement) ModelType() string             { return "ReferenceElement" }
+func (*RelationshipElement) ModelType() string          { return "RelationshipElement" }
+func (*AnnotatedRelationshipElement) ModelType() string { return "AnnotatedRelationshipElement" }
+func (*Entity) ModelType() string                       { return "Entity" }
+func (*BasicEventElement) ModelType() string            { return "BasicEventElement" }
+func (*Capability) ModelType() string                   { return "Capability" }
+func (*Operation) ModelType() string                    { return "Operation" }
+func (*SubmodelElementCollection) ModelType() string    { return "SubmodelElementCollection" }
+func (*SubmodelElementList) ModelType() string          { return "SubmodelElementList" }
+
+// NewSubmodelElement returns an empty element of the given model type, or nil
+// if the type is not a submodel element.
+func NewSubmodelElement(modelType string) SubmodelElement {
+	switch modelType {
+	case "Property":
+		return &Property{}
+	case "MultiLanguageProperty":
+		return &MultiLanguageProperty{}
+	case "Range":
+		return &Range{}
+	case "Blob":
+		return &Blob{}
+	case "File":
+		return &File{}
+	case "ReferenceElement":
+		return &ReferenceElement{}
+	case "RelationshipElement":
+		return &RelationshipElement{}
+	case "AnnotatedRelationshipElement":
+		return &AnnotatedRelationshipElement{}
+	case "Entity":
+		return &Entity{}
+	case "BasicEventElement":
+		return &BasicEventElement{}
+	case "Capability":
+		return &Capability{}
+	case "Operation":
+		return &Operation{}
+	case "SubmodelElementCollection":
+		return &SubmodelElementCollection{}
+	case "SubmodelElementList":
+		return &SubmodelElementList{}
+	}
+	return nil
+}
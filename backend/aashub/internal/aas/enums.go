@@ -0,0 +1,170 @@
+package aas
+
+// ModellingKind tells whether an element is a template or an instance.
+type ModellingKind string
+
+const (
+	ModellingKindTemplate ModellingKind = "Template"
+	ModellingKindInstance ModellingKind = "Instance"
+)
+
+// AssetKind tells whether the asset of a shell is a type or an instance.
+type AssetKind string
+
+const (
+	AssetKindType          AssetKind = "Type"
+	AssetKindInstance      AssetKind = "Instance"
+	AssetKindNotApplicable AssetKind = "NotApplicable"
+)
+
+type QualifierKind string
+
+const (
+	QualifierKindValueQualifier    QualifierKind = "ValueQualifier"
+	QualifierKindConceptQualifier  QualifierKind = "ConceptQualifier"
+	QualifierKindTemplateQualifier QualifierKind = "TemplateQualifier"
+)
+
+type EntityType string
+
+const (
+	EntityTypeCoManagedEntity   EntityType = "CoManagedEntity"
+	EntityTypeSelfManagedEntity EntityType = "SelfManagedEntity"
+)
+
+type Direction string
+
+const (
+	DirectionInput  Direction = "input"
+	DirectionOutput Direction = "output"
+)
+
+type StateOfEvent string
+
+const (
+	StateOfEventOn  StateOfEvent = "on"
+	StateOfEventOff StateOfEvent = "off"
+)
+
+type ReferenceTypes string
+
+const (
+	ReferenceTypesExternalReference ReferenceTypes = "ExternalReference"
+	ReferenceTypesModelReference    ReferenceTypes = "ModelReference"
+)
+
+// KeyTypes are the types of the keys of a Reference.
+type KeyTypes string
+
+const (
+	KeyTypesAnnotatedRelationshipElement KeyTypes = "AnnotatedRelationshipElement"
+	KeyTypesAssetAdministrationShell     KeyTypes = "AssetAdministrationShell"
+	KeyTypesBasicEventElement            KeyTypes = "BasicEventElement"
+	KeyTypesBlob                         KeyTypes = "Blob"
+	KeyTypesCapability                   KeyTypes = "Capability"
+	KeyTypesConceptDescription           KeyTypes = "ConceptDescription"
+	KeyTypesDataElement                  KeyTypes = "DataElement"
+	KeyTypesEntity                       KeyTypes = "Entity"
+	KeyTypesEventElement                 KeyTypes = "EventElement"
+	KeyTypesFile                         KeyTypes = "File"
+	KeyTypesFragmentReference            KeyTypes = "FragmentReference"
+	KeyTypesGlobalReference              KeyTypes = "GlobalReference"
+	KeyTypesIdentifiable                 KeyTypes = "Identifiable"
+	KeyTypesMultiLanguageProperty        KeyTypes = "MultiLanguageProperty"
+	KeyTypesOperation                    KeyTypes = "Operation"
+	KeyTypesProperty                     KeyTypes = "Property"
+	KeyTypesRange                        KeyTypes = "Range"
+	KeyTypesReferable                    KeyTypes = "Referable"
+	KeyTypesReferenceElement             KeyTypes = "ReferenceElement"
+	KeyTypesRelationshipElement          KeyTypes = "RelationshipElement"
+	KeyTypesSubmodel                     KeyTypes = "Submodel"
+	KeyTypesSubmodelElement              KeyTypes = "SubmodelElement"
+	KeyTypesSubmodelElementCollection    KeyTypes = "SubmodelElementCollection"
+	KeyTypesSubmodelElementList          KeyTypes = "SubmodelElementList"
+)
+
+// AasSubmodelElements names the kinds of submodel elements, e.g. the type of
+// the items of a SubmodelElementList.
+type AasSubmodelElements string
+
+const (
+	AasSubmodelElementsAnnotatedRelationshipElement AasSubmodelElements = "AnnotatedRelationshipElement"
+	AasSubmodelElementsBasicEventElement            AasSubmodelElements = "BasicEventElement"
+	AasSubmodelElementsBlob                         AasSubmodelElements = "Blob"
+	AasSubmodelElementsCapability                   AasSubmodelElements = "Capability"
+	AasSubmodelElementsDataElement                  AasSubmodelElements = "DataElement"
+	AasSubmodelElementsEntity                       AasSubmodelElements = "Entity"
+	AasSubmodelElementsEventElement                 AasSubmodelElements = "EventElement"
+	AasSubmodelElementsFile                         AasSubmodelElements = "File"
+	AasSubmodelElementsMultiLanguageProperty        AasSubmodelElements = "MultiLanguageProperty"
+	AasSubmodelElementsOperation                    AasSubmodelElements = "Operation"
+	AasSubmodelElementsProperty                     AasSubmodelElements = "Property"
+	AasSubmodelElementsRange                        AasSubmodelElements = "Range"
+	AasSubmodelElementsReferenceElement             AasSubmodelElements = "ReferenceElement"
+	AasSubmodelElementsRelationshipElement          AasSubmodelElements = "RelationshipElement"
+	AasSubmodelElementsSubmodelElement              AasSubmodelElements = "SubmodelElement"
+	AasSubmodelElementsSubmodelElementCollection    AasSubmodelElements = "SubmodelElementCollection"
+	AasSubmodelElementsSubmodelElementList          AasSubmodelElements = "SubmodelElementList"
+)
+
+// DataTypeDefXsd is the XML Schema datatype of a value.
+type DataTypeDefXsd string
+
+const (
+	DataTypeDefXsdAnyURI             DataTypeDefXsd = "xs:anyURI"
+	DataTypeDefXsdBase64Binary       DataTypeDefXsd = "xs:base64Binary"
+	DataTypeDefXsdBoolean            DataTypeDefXsd = "xs:boolean"
+	DataTypeDefXsdByte               DataTypeDefXsd = "xs:byte"
+	DataTypeDefXsdDate               DataTypeDefXsd = "xs:date"
+	DataTypeDefXsdDateTime           DataTypeDefXsd = "xs:dateTime"
+	DataTypeDefXsdDecimal            DataTypeDefXsd = "xs:decimal"
+	DataTypeDefXsdDouble             DataTypeDefXsd = "xs:double"
+	DataTypeDefXsdDuration           DataTypeDefXsd = "xs:duration"
+	DataTypeDefXsdFloat              DataTypeDefXsd = "xs:float"
+	DataTypeDefXsdGDay               DataTypeDefXsd = "xs:gDay"
+	DataTypeDefXsdGMonth             DataTypeDefXsd = "xs:gMonth"
+	DataTypeDefXsdGMonthDay          DataTypeDefXsd = "xs:gMonthDay"
+	DataTypeDefXsdGYear              DataTypeDefXsd = "xs:gYear"
+	DataTypeDefXsdGYearMonth         DataTypeDefXsd = "xs:gYearMonth"
+	DataTypeDefXsdHexBinary          DataTypeDefXsd = "xs:hexBinary"
+	DataTypeDefXsdInt                DataTypeDefXsd = "xs:int"
+	DataTypeDefXsdInteger            DataTypeDefXsd = "xs:integer"
+	DataTypeDefXsdLong               DataTypeDefXsd = "xs:long"
+	DataTypeDefXsdNegativeInteger    DataTypeDefXsd = "xs:negativeInteger"
+	DataTypeDefXsdNonNegativeInteger DataTypeDefXsd = "xs:nonNegativeInteger"
+	DataTypeDefXsdNonPositiveInteger DataTypeDefXsd = "xs:nonPositiveInteger"
+	DataTypeDefXsdPositiveInteger    DataTypeDefXsd = "xs:positiveInteger"
+	DataTypeDefXsdShort              DataTypeDefXsd = "xs:short"
+	DataTypeDefXsdString             DataTypeDefXsd = "xs:string"
+	DataTypeDefXsdTime               DataTypeDefXsd = "xs:time"
+	DataTypeDefXsdUnsignedByte       DataTypeDefXsd = "xs:unsignedByte"
+	DataTypeDefXsdUnsignedInt        DataTypeDefXsd = "xs:unsignedInt"
+	DataTypeDefXsdUnsignedLong       DataTypeDefXsd = "xs:unsignedLong"
+	DataTypeDefXsdUnsignedShort      DataTypeDefXsd = "xs:unsignedShort"
+)
+
+// DataTypeIec61360 is the datatype of a concept in the IEC 61360 data
+// specification.
+type DataTypeIec61360 string
+
+const (
+	DataTypeIec61360Date               DataTypeIec61360 = "DATE"
+	DataTypeIec61360String             DataTypeIec61360 = "STRING"
+	DataTypeIec61360StringTranslatable DataTypeIec61360 = "STRING_TRANSLATABLE"
+	DataTypeIec61360IntegerMeasure     DataTypeIec61360 = "INTEGER_MEASURE"
+	DataTypeIec61360IntegerCount       DataTypeIec61360 = "INTEGER_COUNT"
+	DataTypeIec61360IntegerCurrency    DataTypeIec61360 = "INTEGER_CURRENCY"
+	DataTypeIec61360RealMeasure        DataTypeIec61360 = "REAL_MEASURE"
+	DataTypeIec61360RealCount          DataTypeIec61360 = "REAL_COUNT"
+	DataTypeIec61360RealCurrency       DataTypeIec61360 = "REAL_CURRENCY"
+	DataTypeIec61360Boolean            DataTypeIec61360 = "BOOLEAN"
+	DataTypeIec61360Iri                DataTypeIec61360 = "IRI"
+	DataTypeIec61360Irdi               DataTypeIec61360 = "IRDI"
+	DataTypeIec61360Rational           DataTypeIec61360 = "RATIONAL"
+	DataTypeIec61360RationalMeasure    DataTypeIec61360 = "RATIONAL_MEASURE"
+	DataTypeIec61360Time               DataTypeIec61360 = "TIME"
+	DataTypeIec61360Timestamp          DataTypeIec61360 = "TIMESTAMP"
+	DataTypeIec61360File               DataTypeIec61360 = "FILE"
+	DataTypeIec61360Html               DataTypeIec61360 = "HTML"
+	DataTypeIec61360Blob               DataTypeIec61360 = "BLOB"
+)
@@ -0,0 +1,184 @@
+package aas
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownModelType is returned when a submodel element has a missing or
+// unsupported "modelType".
+var ErrUnknownModelType = errors.New("unknown model type")
+
+// SubmodelElements decodes a JSON array of submodel elements into their
+// concrete types, based on the "modelType" of every item.
+type SubmodelElements []SubmodelElement
+
+func (s *SubmodelElements) UnmarshalJSON(data []byte) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if items == nil {
+		*s = nil
+		return nil
+	}
+
+	elements := make(SubmodelElements, len(items))
+	for i, item := range items {
+		element, err := UnmarshalSubmodelElement(item)
+		if err != nil {
+			return fmt.Errorf("[%d]: %w", i, err)
+		}
+		elements[i] = element
+	}
+	*s = elements
+	return nil
+}
+
+func (v *OperationVariable) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	element, err := UnmarshalSubmodelElement(raw.Value)
+	if err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	v.Value = element
+	return nil
+}
+
+// UnmarshalSubmodelElement decodes a single submodel element.
+func UnmarshalSubmodelElement(data []byte) (SubmodelElement, error) {
+	var header struct {
+		ModelType string `json:"modelType"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, err
+	}
+	element := NewSubmodelElement(header.ModelType)
+	if element == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownModelType, header.ModelType)
+	}
+	if err := json.Unmarshal(data, element); err != nil {
+		return nil, err
+	}
+	return element, nil
+}
+
+// marshalWithModelType encodes v and puts "modelType" first. v must not
+// implement json.Marshaler itself, which is why the MarshalJSON methods
+// below pass an alias of their receiver's type.
+func marshalWithModelType(modelType string, v any) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	name, err := json.Marshal(modelType)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"modelType":`)
+	buf.Write(name)
+	if len(data) > len("{}") {
+		buf.WriteByte(',')
+	}
+	buf.Write(data[1:])
+	return buf.Bytes(), nil
+}
+
+func (s AssetAdministrationShell) MarshalJSON() ([]byte, error) {
+	type alias AssetAdministrationShell
+	return marshalWithModelType("AssetAdministrationShell", alias(s))
+}
+
+func (s Submodel) MarshalJSON() ([]byte, error) {
+	type alias Submodel
+	return marshalWithModelType("Submodel", alias(s))
+}
+
+func (c ConceptDescription) MarshalJSON() ([]byte, error) {
+	type alias ConceptDescription
+	return marshalWithModelType("ConceptDescription", alias(c))
+}
+
+func (d DataSpecificationIec61360) MarshalJSON() ([]byte, error) {
+	type alias DataSpecificationIec61360
+	return marshalWithModelType("DataSpecificationIec61360", alias(d))
+}
+
+func (e Property) MarshalJSON() ([]byte, error) {
+	type alias Property
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e MultiLanguageProperty) MarshalJSON() ([]byte, error) {
+	type alias MultiLanguageProperty
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e Range) MarshalJSON() ([]byte, error) {
+	type alias Range
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e Blob) MarshalJSON() ([]byte, error) {
+	type alias Blob
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e File) MarshalJSON() ([]byte, error) {
+	type alias File
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e ReferenceElement) MarshalJSON() ([]byte, error) {
+	type alias ReferenceElement
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e RelationshipElement) MarshalJSON() ([]byte, error) {
+	type alias RelationshipElement
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e AnnotatedRelationshipElement) MarshalJSON() ([]byte, error) {
+	type alias AnnotatedRelationshipElement
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e Entity) MarshalJSON() ([]byte, error) {
+	type alias Entity
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e BasicEventElement) MarshalJSON() ([]byte, error) {
+	type alias BasicEventElement
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e Capability) MarshalJSON() ([]byte, error) {
+	type alias Capability
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e Operation) MarshalJSON() ([]byte, error) {
+	type alias Operation
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e SubmodelElementCollection) MarshalJSON() ([]byte, error) {
+	type alias SubmodelElementCollection
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
+
+func (e SubmodelElementList) MarshalJSON() ([]byte, error) {
+	type alias SubmodelElementList
+	return marshalWithModelType(e.ModelType(), alias(e))
+}
@@ -0,0 +1,183 @@
+package aas
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"regexp"
+)
+
+// The serialization modifiers of the AAS API (IDTA-01002 Part 2) select
+// parts of submodels and submodel elements: $value leaves out everything but
+// the values, $metadata everything but the values, level=core stops at the
+// direct children and extent=withoutBlobValue leaves out the content of
+// blobs.
+
+// jsonNumberRe matches the values of numeric types that are valid JSON
+// numbers, which excludes e.g. "+1", ".5" and "INF".
+var jsonNumberRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][+-]?[0-9]+)?$`)
+
+// valueAttributes are the attributes left out by $metadata.
+var valueAttributes = map[string][]string{
+	"Submodel":                     {"submodelElements"},
+	"Property":                     {"value", "valueId"},
+	"MultiLanguageProperty":        {"value", "valueId"},
+	"Range":                        {"min", "max"},
+	"Blob":                         {"value"},
+	"File":                         {"value"},
+	"ReferenceElement":             {"value"},
+	"RelationshipElement":          {"first", "second"},
+	"AnnotatedRelationshipElement": {"first", "second", "annotations"},
+	"Entity":                       {"statements", "globalAssetId", "specificAssetIds"},
+	"BasicEventElement":            {"observed"},
+	"SubmodelElementCollection":    {"value"},
+	"SubmodelElementList":          {"value"},
+}
+
+// Metadata returns the JSON object of a submodel or submodel element without
+// its values.
+func Metadata(v any) (map[string]any, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var object map[string]any
+	if err := json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	modelType, _ := object["modelType"].(string)
+	for _, attribute := range valueAttributes[modelType] {
+		delete(object, attribute)
+	}
+	return object, nil
+}
+
+// ValueOnly returns the value of an element in the value-only serialization,
+// or false for elements without a value, i.e. operations and capabilities.
+func ValueOnly(element SubmodelElement) (any, bool) {
+	switch e := element.(type) {
+	case *Property:
+		return typedValue(e.ValueType, e.Value), true
+	case *MultiLanguageProperty:
+		texts := []map[string]string{}
+		for _, text := range e.Value {
+			texts = append(texts, map[string]string{text.Language: text.Text})
+		}
+		return texts, true
+	case *Range:
+		value := map[string]any{}
+		if e.Min != nil {
+			value["min"] = typedValue(e.ValueType, e.Min)
+		}
+		if e.Max != nil {
+			value["max"] = typedValue(e.ValueType, e.Max)
+		}
+		return value, true
+	case *Blob:
+		value := map[string]any{"contentType": e.ContentType}
+		if e.Value != nil {
+			value["value"] = base64.StdEncoding.EncodeToString(e.Value)
+		}
+		return value, true
+	case *File:
+		value := map[string]any{"contentType": e.ContentType}
+		if e.Value != nil {
+			value["value"] = *e.Value
+		}
+		return value, true
+	case *ReferenceElement:
+		return e.Value, true
+	case *RelationshipElement:
+		return map[string]any{"first": e.First, "second": e.Second}, true
+	case *AnnotatedRelationshipElement:
+		annotations := []map[string]any{}
+		for _, annotation := range e.Annotations {
+			if value, ok := ValueOnly(annotation); ok && annotation.Base().IdShort != nil {
+				annotations = append(annotations, map[string]any{*annotation.Base().IdShort: value})
+			}
+		}
+		return map[string]any{"first": e.First, "second": e.Second, "annotations": annotations}, true
+	case *Entity:
+		value := map[string]any{"statements": ElementsValueOnly(e.Statements), "entityType": e.EntityType}
+		if e.GlobalAssetId != nil {
+			value["globalAssetId"] = *e.GlobalAssetId
+		}
+		if len(e.SpecificAssetIds) > 0 {
+			ids := []map[string]string{}
+			for _, id := range e.SpecificAssetIds {
+				ids = append(ids, map[string]string{id.Name: id.Value})
+			}
+			value["specificAssetIds"] = ids
+		}
+		return value, true
+	case *BasicEventElement:
+		return map[string]any{"observed": e.Observed}, true
+	case *SubmodelElementCollection:
+		return ElementsValueOnly(e.Value), true
+	case *SubmodelElementList:
+		values := []any{}
+		for _, child := range e.Value {
+			if value, ok := ValueOnly(child); ok {
+				values = append(values, value)
+			}
+		}
+		return values, true
+	}
+	return nil, false
+}
+
+// ElementsValueOnly returns the values of elements by their idShort, e.g. the
+// value-only serialization of a submodel.
+func ElementsValueOnly(elements SubmodelElements) map[string]any {
+	values := map[string]any{}
+	for _, element := range elements {
+		if value, ok := ValueOnly(element); ok && element.Base().IdShort != nil {
+			values[*element.Base().IdShort] = value
+		}
+	}
+	return values
+}
+
+// typedValue returns numbers and booleans as such and everything else as a
+// string.
+func typedValue(valueType DataTypeDefXsd, value *string) any {
+	if value == nil {
+		return nil
+	}
+	switch valueType {
+	case DataTypeDefXsdBoolean:
+		switch *value {
+		case "true", "1":
+			return true
+		case "false", "0":
+			return false
+		}
+	case DataTypeDefXsdString, DataTypeDefXsdAnyURI, DataTypeDefXsdBase64Binary, DataTypeDefXsdHexBinary,
+		DataTypeDefXsdDate, DataTypeDefXsdDateTime, DataTypeDefXsdTime, DataTypeDefXsdDuration,
+		DataTypeDefXsdGDay, DataTypeDefXsdGMonth, DataTypeDefXsdGMonthDay, DataTypeDefXsdGYear, DataTypeDefXsdGYearMonth:
+	default:
+		if jsonNumberRe.MatchString(*value) {
+			return json.Number(*value)
+		}
+	}
+	return *value
+}
+
+// CoreLevel leaves out the children of the given elements, so that only
+// these elements themselves are serialized.
+func CoreLevel(elements SubmodelElements) {
+	for _, element := range elements {
+		if children := Children(element); children != nil {
+			*children = nil
+		}
+	}
+}
+
+// WithoutBlobValues leaves out the content of the blobs among and below the
+// given elements.
+func WithoutBlobValues(elements SubmodelElements) {
+	Walk(elements, func(element SubmodelElement) {
+		if blob, ok := element.(*Blob); ok {
+			blob.Value = nil
+		}
+	})
+}
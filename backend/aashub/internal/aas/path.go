@@ -0,0 +1,204 @@
+package aas
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrInvalidIdShortPath = errors.New("invalid idShortPath")
+	ErrElementNotFound    = errors.New("submodel element not found")
+)
+
+// pathSegment is a step of an idShortPath: the idShort of an element or, in
+// a SubmodelElementList, the index of an element.
+type pathSegment struct {
+	idShort string
+	index   int
+	isIndex bool
+}
+
+// parseIdShortPath splits an idShortPath like "Documents[0].File" into its
+// segments. The path starts with the idShort of a submodel element.
+func parseIdShortPath(path string) ([]pathSegment, error) {
+	var segments []pathSegment
+	rest := path
+	for rest != "" {
+		switch {
+		case rest[0] == '[':
+			end := strings.IndexByte(rest, ']')
+			if len(segments) == 0 || end < 0 {
+				return nil, fmt.Errorf("%w %q", ErrInvalidIdShortPath, path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil || index < 0 || rest[1] == '+' {
+				return nil, fmt.Errorf("%w %q: invalid index %q", ErrInvalidIdShortPath, path, rest[1:end])
+			}
+			segments = append(segments, pathSegment{index: index, isIndex: true})
+			rest = rest[end+1:]
+		case len(segments) == 0 || rest[0] == '.':
+			if len(segments) > 0 {
+				rest = rest[1:]
+			}
+			end := strings.IndexAny(rest, ".[")
+			if end < 0 {
+				end = len(rest)
+			}
+			if !idShortRe.MatchString(rest[:end]) {
+				return nil, fmt.Errorf("%w %q: invalid idShort %q", ErrInvalidIdShortPath, path, rest[:end])
+			}
+			segments = append(segments, pathSegment{idShort: rest[:end]})
+			rest = rest[end:]
+		default:
+			return nil, fmt.Errorf("%w %q", ErrInvalidIdShortPath, path)
+		}
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("%w: empty path", ErrInvalidIdShortPath)
+	}
+	return segments, nil
+}
+
+// Children returns the elements contained in element, i.e. the value of a
+// collection or list, the statements of an entity or the annotations of a
+// relationship, or nil if element cannot contain elements.
+func Children(element SubmodelElement) *SubmodelElements {
+	switch e := element.(type) {
+	case *SubmodelElementCollection:
+		return &e.Value
+	case *SubmodelElementList:
+		return &e.Value
+	case *Entity:
+		return &e.Statements
+	case *AnnotatedRelationshipElement:
+		return &e.Annotations
+	}
+	return nil
+}
+
+// Walk calls visit for each of the given elements and all elements below
+// them, including the values of operation variables, parents first.
+func Walk(elements SubmodelElements, visit func(SubmodelElement)) {
+	for _, element := range elements {
+		visit(element)
+		if operation, ok := element.(*Operation); ok {
+			for _, variables := range [][]OperationVariable{operation.InputVariables, operation.OutputVariables, operation.InoutputVariables} {
+				for _, variable := range variables {
+					if variable.Value != nil {
+						Walk(SubmodelElements{variable.Value}, visit)
+					}
+				}
+			}
+		}
+		if children := Children(element); children != nil {
+			Walk(*children, visit)
+		}
+	}
+}
+
+// Locate finds the element at an idShortPath. It returns the elements
+// containing it, e.g. the value of its collection, and its index therein.
+func (s *Submodel) Locate(path string) (*SubmodelElements, int, error) {
+	container, index, _, err := s.walk(path)
+	return container, index, err
+}
+
+// Reference returns the model reference to the element at an idShortPath,
+// or to the submodel itself if path is empty.
+func (s *Submodel) Reference(path string) (*Reference, error) {
+	ref := &Reference{Type: ReferenceTypesModelReference, Keys: []Key{{Type: KeyTypesSubmodel, Value: s.Id}}}
+	if path == "" {
+		return ref, nil
+	}
+	_, _, keys, err := s.walk(path)
+	if err != nil {
+		return nil, err
+	}
+	ref.Keys = append(ref.Keys, keys...)
+	return ref, nil
+}
+
+// walk follows an idShortPath like Locate and also returns a key for each
+// element on the way. Elements of lists are keyed by their index.
+func (s *Submodel) walk(path string) (*SubmodelElements, int, []Key, error) {
+	segments, err := parseIdShortPath(path)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	var keys []Key
+	container, inList := &s.SubmodelElements, false
+	for i, segment := range segments {
+		index := -1
+		switch {
+		case segment.isIndex && inList:
+			if segment.index < len(*container) {
+				index = segment.index
+			}
+		case !segment.isIndex && !inList:
+			for j, element := range *container {
+				if idShort := element.Base().IdShort; idShort != nil && *idShort == segment.idShort {
+					index = j
+					break
+				}
+			}
+		}
+		if index < 0 {
+			return nil, 0, nil, fmt.Errorf("%w: %s", ErrElementNotFound, path)
+		}
+
+		element := (*container)[index]
+		key := Key{Type: KeyTypes(element.ModelType()), Value: segment.idShort}
+		if segment.isIndex {
+			key.Value = strconv.Itoa(segment.index)
+		}
+		keys = append(keys, key)
+		if i == len(segments)-1 {
+			return container, index, keys, nil
+		}
+
+		if container = Children(element); container == nil {
+			return nil, 0, nil, fmt.Errorf("%w: %s", ErrElementNotFound, path)
+		}
+		_, inList = element.(*SubmodelElementList)
+	}
+	return nil, 0, nil, fmt.Errorf("%w: %s", ErrElementNotFound, path)
+}
+
+// Element returns the element at an idShortPath.
+func (s *Submodel) Element(path string) (SubmodelElement, error) {
+	container, index, err := s.Locate(path)
+	if err != nil {
+		return nil, err
+	}
+	return (*container)[index], nil
+}
+
+// IdShortPaths returns the idShortPaths of the given elements, which are
+// contained in the element at prefix, and, if deep, of all elements below
+// them. prefix is empty for the elements of a submodel; inList is true for
+// the elements of a SubmodelElementList.
+func IdShortPaths(prefix string, elements SubmodelElements, inList bool, deep bool) []string {
+	paths := []string{}
+	for i, element := range elements {
+		var path string
+		switch {
+		case inList:
+			path = fmt.Sprintf("%s[%d]", prefix, i)
+		case element.Base().IdShort == nil:
+			continue
+		case prefix == "":
+			path = *element.Base().IdShort
+		default:
+			path = prefix + "." + *element.Base().IdShort
+		}
+		paths = append(paths, path)
+		if children := Children(element); deep && children != nil {
+			_, isList := element.(*SubmodelElementList)
+			paths = append(paths, IdShortPaths(path, *children, isList, deep)...)
+		}
+	}
+	return paths
+}
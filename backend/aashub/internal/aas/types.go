@@ -0,0 +1,159 @@
+// Package aas models the Asset Administration Shell metamodel v3.0 of the
+// IDTA together with its JSON and XML serializations.
+//
+// The field names follow the official JSON and XML schemas. Optional
+// attributes are omitted when they are empty, since the schemas require lists
+// to have at least one item.
+package aas
+
+// Environment is the container serialized in JSON files and AASX packages.
+type Environment struct {
+	AssetAdministrationShells []AssetAdministrationShell `json:"assetAdministrationShells,omitempty" xml:"assetAdministrationShells>assetAdministrationShell,omitempty"`
+	Submodels                 []Submodel                 `json:"submodels,omitempty" xml:"submodels>submodel,omitempty"`
+	ConceptDescriptions       []ConceptDescription       `json:"conceptDescriptions,omitempty" xml:"conceptDescriptions>conceptDescription,omitempty"`
+}
+
+// Referable holds the attributes shared by all elements that have an idShort.
+type Referable struct {
+	Extensions  []Extension  `json:"extensions,omitempty" xml:"extensions>extension,omitempty"`
+	Category    *string      `json:"category,omitempty" xml:"category,omitempty"`
+	IdShort     *string      `json:"idShort,omitempty" xml:"idShort,omitempty"`
+	DisplayName []LangString `json:"displayName,omitempty" xml:"displayName>langStringNameType,omitempty"`
+	Description []LangString `json:"description,omitempty" xml:"description>langStringTextType,omitempty"`
+}
+
+// Identifiable holds the attributes of globally identified elements.
+type Identifiable struct {
+	Referable
+	Administration *AdministrativeInformation `json:"administration,omitempty" xml:"administration,omitempty"`
+	Id             string                     `json:"id" xml:"id"`
+}
+
+type AdministrativeInformation struct {
+	EmbeddedDataSpecifications []EmbeddedDataSpecification `json:"embeddedDataSpecifications,omitempty" xml:"embeddedDataSpecifications>embeddedDataSpecification,omitempty"`
+	Version                    *string                     `json:"version,omitempty" xml:"version,omitempty"`
+	Revision                   *string                     `json:"revision,omitempty" xml:"revision,omitempty"`
+	Creator                    *Reference                  `json:"creator,omitempty" xml:"creator,omitempty"`
+	TemplateId                 *string                     `json:"templateId,omitempty" xml:"templateId,omitempty"`
+}
+
+type AssetAdministrationShell struct {
+	Identifiable
+	EmbeddedDataSpecifications []EmbeddedDataSpecification `json:"embeddedDataSpecifications,omitempty" xml:"embeddedDataSpecifications>embeddedDataSpecification,omitempty"`
+	DerivedFrom                *Reference                  `json:"derivedFrom,omitempty" xml:"derivedFrom,omitempty"`
+	AssetInformation           AssetInformation            `json:"assetInformation" xml:"assetInformation"`
+	Submodels                  []Reference                 `json:"submodels,omitempty" xml:"submodels>reference,omitempty"`
+}
+
+type AssetInformation struct {
+	AssetKind        AssetKind         `json:"assetKind" xml:"assetKind"`
+	GlobalAssetId    *string           `json:"globalAssetId,omitempty" xml:"globalAssetId,omitempty"`
+	SpecificAssetIds []SpecificAssetId `json:"specificAssetIds,omitempty" xml:"specificAssetIds>specificAssetId,omitempty"`
+	AssetType        *string           `json:"assetType,omitempty" xml:"assetType,omitempty"`
+	DefaultThumbnail *Resource         `json:"defaultThumbnail,omitempty" xml:"defaultThumbnail,omitempty"`
+}
+
+type SpecificAssetId struct {
+	SemanticId              *Reference  `json:"semanticId,omitempty" xml:"semanticId,omitempty"`
+	SupplementalSemanticIds []Reference `json:"supplementalSemanticIds,omitempty" xml:"supplementalSemanticIds>reference,omitempty"`
+	Name                    string      `json:"name" xml:"name"`
+	Value                   string      `json:"value" xml:"value"`
+	ExternalSubjectId       *Reference  `json:"externalSubjectId,omitempty" xml:"externalSubjectId,omitempty"`
+}
+
+type Resource struct {
+	Path        string  `json:"path" xml:"path"`
+	ContentType *string `json:"contentType,omitempty" xml:"contentType,omitempty"`
+}
+
+type Submodel struct {
+	Identifiable
+	Kind                       *ModellingKind              `json:"kind,omitempty" xml:"kind,omitempty"`
+	SemanticId                 *Reference                  `json:"semanticId,omitempty" xml:"semanticId,omitempty"`
+	SupplementalSemanticIds    []Reference                 `json:"supplementalSemanticIds,omitempty" xml:"supplementalSemanticIds>reference,omitempty"`
+	Qualifiers                 []Qualifier                 `json:"qualifiers,omitempty" xml:"qualifiers>qualifier,omitempty"`
+	EmbeddedDataSpecifications []EmbeddedDataSpecification `json:"embeddedDataSpecifications,omitempty" xml:"embeddedDataSpecifications>embeddedDataSpecification,omitempty"`
+	SubmodelElements           SubmodelElements            `json:"submodelElements,omitempty" xml:"submodelElements,omitempty"`
+}
+
+type ConceptDescription struct {
+	Identifiable
+	EmbeddedDataSpecifications []EmbeddedDataSpecification `json:"embeddedDataSpecifications,omitempty" xml:"embeddedDataSpecifications>embeddedDataSpecification,omitempty"`
+	IsCaseOf                   []Reference                 `json:"isCaseOf,omitempty" xml:"isCaseOf>reference,omitempty"`
+}
+
+// Reference points either to an element of the model (ModelReference) or to
+// something outside of it (ExternalReference).
+type Reference struct {
+	Type               ReferenceTypes `json:"type" xml:"type"`
+	ReferredSemanticId *Reference     `json:"referredSemanticId,omitempty" xml:"referredSemanticId,omitempty"`
+	Keys               []Key          `json:"keys" xml:"keys>key"`
+}
+
+type Key struct {
+	Type  KeyTypes `json:"type" xml:"type"`
+	Value string   `json:"value" xml:"value"`
+}
+
+// LangString is a text in a language identified by its BCP 47 tag.
+type LangString struct {
+	Language string `json:"language" xml:"language"`
+	Text     string `json:"text" xml:"text"`
+}
+
+type Qualifier struct {
+	SemanticId              *Reference     `json:"semanticId,omitempty" xml:"semanticId,omitempty"`
+	SupplementalSemanticIds []Reference    `json:"supplementalSemanticIds,omitempty" xml:"supplementalSemanticIds>reference,omitempty"`
+	Kind                    *QualifierKind `json:"kind,omitempty" xml:"kind,omitempty"`
+	Type                    string         `json:"type" xml:"type"`
+	ValueType               DataTypeDefXsd `json:"valueType" xml:"valueType"`
+	Value                   *string        `json:"value,omitempty" xml:"value,omitempty"`
+	ValueId                 *Reference     `json:"valueId,omitempty" xml:"valueId,omitempty"`
+}
+
+type Extension struct {
+	SemanticId              *Reference      `json:"semanticId,omitempty" xml:"semanticId,omitempty"`
+	SupplementalSemanticIds []Reference     `json:"supplementalSemanticIds,omitempty" xml:"supplementalSemanticIds>reference,omitempty"`
+	Name                    string          `json:"name" xml:"name"`
+	ValueType               *DataTypeDefXsd `json:"valueType,omitempty" xml:"valueType,omitempty"`
+	Value                   *string         `json:"value,omitempty" xml:"value,omitempty"`
+	RefersTo                []Reference     `json:"refersTo,omitempty" xml:"refersTo>reference,omitempty"`
+}
+
+type EmbeddedDataSpecification struct {
+	DataSpecification        Reference                  `json:"dataSpecification" xml:"dataSpecification"`
+	DataSpecificationContent *DataSpecificationIec61360 `json:"dataSpecificationContent" xml:"dataSpecificationContent>dataSpecificationIec61360"`
+}
+
+// DataSpecificationIec61360 is the only data specification content defined
+// by the metamodel v3.0.
+type DataSpecificationIec61360 struct {
+	PreferredName      []LangString      `json:"preferredName" xml:"preferredName>langStringPreferredNameTypeIec61360"`
+	ShortName          []LangString      `json:"shortName,omitempty" xml:"shortName>langStringShortNameTypeIec61360,omitempty"`
+	Unit               *string           `json:"unit,omitempty" xml:"unit,omitempty"`
+	UnitId             *Reference        `json:"unitId,omitempty" xml:"unitId,omitempty"`
+	SourceOfDefinition *string           `json:"sourceOfDefinition,omitempty" xml:"sourceOfDefinition,omitempty"`
+	Symbol             *string           `json:"symbol,omitempty" xml:"symbol,omitempty"`
+	DataType           *DataTypeIec61360 `json:"dataType,omitempty" xml:"dataType,omitempty"`
+	Definition         []LangString      `json:"definition,omitempty" xml:"definition>langStringDefinitionTypeIec61360,omitempty"`
+	ValueFormat        *string           `json:"valueFormat,omitempty" xml:"valueFormat,omitempty"`
+	ValueList          *ValueList        `json:"valueList,omitempty" xml:"valueList,omitempty"`
+	Value              *string           `json:"value,omitempty" xml:"value,omitempty"`
+	LevelType          *LevelType        `json:"levelType,omitempty" xml:"levelType,omitempty"`
+}
+
+type ValueList struct {
+	ValueReferencePairs []ValueReferencePair `json:"valueReferencePairs" xml:"valueReferencePairs>valueReferencePair"`
+}
+
+type ValueReferencePair struct {
+	Value   string     `json:"value" xml:"value"`
+	ValueId *Reference `json:"valueId,omitempty" xml:"valueId,omitempty"`
+}
+
+type LevelType struct {
+	Min bool `json:"min" xml:"min"`
+	Nom bool `json:"nom" xml:"nom"`
+	Typ bool `json:"typ" xml:"typ"`
+	Max bool `json:"max" xml:"max"`
+}
@@ -0,0 +1,433 @@
+package aas
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// Violation is a breach of a metamodel constraint. Path is the JSON path of
+// the offending value, e.g. $.submodels[0].submodelElements[1].idShort, and
+// Constraint names the rule, e.g. AASd-002. Constraints that the JSON schema
+// already expresses, such as required attributes, are reported as "schema".
+type Violation struct {
+	Path       string `json:"path"`
+	Constraint string `json:"constraint"`
+	Message    string `json:"message"`
+}
+
+func (v Violation) Error() string {
+	return fmt.Sprintf("%s: %s (%s)", v.Path, v.Message, v.Constraint)
+}
+
+const constraintSchema = "schema"
+
+var idShortRe = regexp.MustCompile(`^[a-zA-Z]([a-zA-Z0-9_-]*[a-zA-Z0-9_])?$`)
+
+const maxIdShortLength = 128
+
+// Validate checks env against the constraints of the metamodel and returns
+// every violation found, in document order. A nil result means env is valid.
+func Validate(env *Environment) []Violation {
+	v := &validator{}
+	for i := range env.AssetAdministrationShells {
+		v.shell(fmt.Sprintf("$.assetAdministrationShells[%d]", i), &env.AssetAdministrationShells[i])
+	}
+	for i := range env.Submodels {
+		v.submodel(fmt.Sprintf("$.submodels[%d]", i), &env.Submodels[i])
+	}
+	for i := range env.ConceptDescriptions {
+		v.conceptDescription(fmt.Sprintf("$.conceptDescriptions[%d]", i), &env.ConceptDescriptions[i])
+	}
+	v.uniqueIds(env)
+	return v.violations
+}
+
+// ValidateSpecificAssetIds checks a list of asset ids like Validate checks an
+// environment, e.g. the asset links of the Discovery Service. Paths start at
+// the list.
+func ValidateSpecificAssetIds(ids []SpecificAssetId) []Violation {
+	v := &validator{}
+	v.specificAssetIds("$", ids)
+	return v.violations
+}
+
+type validator struct {
+	violations []Violation
+}
+
+func (v *validator) report(path, constraint, format string, args ...any) {
+	v.violations = append(v.violations, Violation{Path: path, Constraint: constraint, Message: fmt.Sprintf(format, args...)})
+}
+
+// uniqueIds reports identifiables that share their id with an earlier one.
+func (v *validator) uniqueIds(env *Environment) {
+	seen := map[string]bool{}
+	check := func(path, id string) {
+		if id != "" && seen[id] {
+			v.report(path+".id", constraintSchema, "id %q is not unique in the environment", id)
+		}
+		seen[id] = true
+	}
+	for i, shell := range env.AssetAdministrationShells {
+		check(fmt.Sprintf("$.assetAdministrationShells[%d]", i), shell.Id)
+	}
+	for i, submodel := range env.Submodels {
+		check(fmt.Sprintf("$.submodels[%d]", i), submodel.Id)
+	}
+	for i, cd := range env.ConceptDescriptions {
+		check(fmt.Sprintf("$.conceptDescriptions[%d]", i), cd.Id)
+	}
+}
+
+func (v *validator) shell(path string, shell *AssetAdministrationShell) {
+	v.identifiable(path, &shell.Identifiable)
+	v.dataSpecifications(path, shell.EmbeddedDataSpecifications)
+	if shell.DerivedFrom != nil {
+		v.reference(path+".derivedFrom", shell.DerivedFrom)
+	}
+
+	info := &shell.AssetInformation
+	infoPath := path + ".assetInformation"
+	switch info.AssetKind {
+	case AssetKindType, AssetKindInstance, AssetKindNotApplicable:
+	default:
+		v.report(infoPath+".assetKind", constraintSchema, "invalid asset kind %q", info.AssetKind)
+	}
+	if info.GlobalAssetId == nil && len(info.SpecificAssetIds) == 0 {
+		v.report(infoPath, "AASd-131", "either globalAssetId or at least one specificAssetId shall be defined")
+	}
+	if info.GlobalAssetId != nil {
+		v.nonEmpty(infoPath+".globalAssetId", *info.GlobalAssetId)
+	}
+	v.specificAssetIds(infoPath+".specificAssetIds", info.SpecificAssetIds)
+	if info.DefaultThumbnail != nil {
+		v.nonEmpty(infoPath+".defaultThumbnail.path", info.DefaultThumbnail.Path)
+	}
+
+	for i := range shell.Submodels {
+		ref := &shell.Submodels[i]
+		refPath := fmt.Sprintf("%s.submodels[%d]", path, i)
+		v.reference(refPath, ref)
+		if ref.Type != ReferenceTypesModelReference || len(ref.Keys) == 0 || ref.Keys[0].Type != KeyTypesSubmodel {
+			v.report(refPath, constraintSchema, "a submodel reference shall be a model reference to a Submodel")
+		}
+	}
+}
+
+func (v *validator) submodel(path string, submodel *Submodel) {
+	v.identifiable(path, &submodel.Identifiable)
+	template := false
+	if submodel.Kind != nil {
+		switch *submodel.Kind {
+		case ModellingKindTemplate:
+			template = true
+		case ModellingKindInstance:
+		default:
+			v.report(path+".kind", constraintSchema, "invalid modelling kind %q", *submodel.Kind)
+		}
+	}
+	v.semantics(path, submodel.SemanticId, submodel.SupplementalSemanticIds)
+	v.qualifiers(path, submodel.Qualifiers, template, "AASd-119")
+	v.dataSpecifications(path, submodel.EmbeddedDataSpecifications)
+	v.elements(path+".submodelElements", submodel.SubmodelElements, template)
+}
+
+func (v *validator) conceptDescription(path string, cd *ConceptDescription) {
+	v.identifiable(path, &cd.Identifiable)
+	v.dataSpecifications(path, cd.EmbeddedDataSpecifications)
+	for i := range cd.IsCaseOf {
+		v.reference(fmt.Sprintf("%s.isCaseOf[%d]", path, i), &cd.IsCaseOf[i])
+	}
+}
+
+func (v *validator) identifiable(path string, identifiable *Identifiable) {
+	v.referable(path, &identifiable.Referable)
+	v.nonEmpty(path+".id", identifiable.Id)
+
+	if admin := identifiable.Administration; admin != nil {
+		adminPath := path + ".administration"
+		if admin.Version == nil && admin.Revision != nil {
+			v.report(adminPath+".revision", "AASd-005", "a revision requires a version")
+		}
+		if admin.Creator != nil {
+			v.reference(adminPath+".creator", admin.Creator)
+		}
+		v.dataSpecifications(adminPath, admin.EmbeddedDataSpecifications)
+	}
+}
+
+func (v *validator) referable(path string, referable *Referable) {
+	if idShort := referable.IdShort; idShort != nil {
+		if len(*idShort) > maxIdShortLength || !idShortRe.MatchString(*idShort) {
+			v.report(path+".idShort", "AASd-002", "idShort %q shall start with a letter, followed by letters, digits, underscores or hyphens, and not end with a hyphen", *idShort)
+		}
+	}
+	v.langStrings(path+".displayName", referable.DisplayName)
+	v.langStrings(path+".description", referable.Description)
+
+	names := map[string]bool{}
+	for i := range referable.Extensions {
+		extension := &referable.Extensions[i]
+		extensionPath := fmt.Sprintf("%s.extensions[%d]", path, i)
+		v.nonEmpty(extensionPath+".name", extension.Name)
+		if names[extension.Name] {
+			v.report(extensionPath+".name", "AASd-077", "extension name %q is not unique", extension.Name)
+		}
+		names[extension.Name] = true
+		v.semantics(extensionPath, extension.SemanticId, extension.SupplementalSemanticIds)
+		if extension.ValueType != nil && v.valueType(extensionPath+".valueType", *extension.ValueType) {
+			v.value(extensionPath+".value", *extension.ValueType, extension.Value, constraintSchema)
+		}
+		for j := range extension.RefersTo {
+			ref := &extension.RefersTo[j]
+			refPath := fmt.Sprintf("%s.refersTo[%d]", extensionPath, j)
+			v.reference(refPath, ref)
+			if ref.Type != ReferenceTypesModelReference {
+				v.report(refPath+".type", constraintSchema, "refersTo shall be a model reference")
+			}
+		}
+	}
+}
+
+// semantics checks the attributes of elements that have semantics.
+func (v *validator) semantics(path string, semanticId *Reference, supplemental []Reference) {
+	if semanticId != nil {
+		v.reference(path+".semanticId", semanticId)
+	}
+	if len(supplemental) > 0 && semanticId == nil {
+		v.report(path+".supplementalSemanticIds", "AASd-118", "supplemental semantic ids require a semanticId")
+	}
+	for i := range supplemental {
+		v.reference(fmt.Sprintf("%s.supplementalSemanticIds[%d]", path, i), &supplemental[i])
+	}
+}
+
+// qualifiers checks the qualifiers of an element. Template qualifiers are
+// only allowed within templates, which is reported as templateConstraint.
+func (v *validator) qualifiers(path string, qualifiers []Qualifier, template bool, templateConstraint string) {
+	types := map[string]bool{}
+	for i := range qualifiers {
+		qualifier := &qualifiers[i]
+		qualifierPath := fmt.Sprintf("%s.qualifiers[%d]", path, i)
+		v.nonEmpty(qualifierPath+".type", qualifier.Type)
+		if types[qualifier.Type] {
+			v.report(qualifierPath+".type", "AASd-021", "qualifier type %q is not unique", qualifier.Type)
+		}
+		types[qualifier.Type] = true
+		v.semantics(qualifierPath, qualifier.SemanticId, qualifier.SupplementalSemanticIds)
+		if v.valueType(qualifierPath+".valueType", qualifier.ValueType) {
+			v.value(qualifierPath+".value", qualifier.ValueType, qualifier.Value, "AASd-020")
+		}
+		if qualifier.ValueId != nil {
+			v.reference(qualifierPath+".valueId", qualifier.ValueId)
+		}
+		if qualifier.Kind != nil {
+			switch *qualifier.Kind {
+			case QualifierKindTemplateQualifier:
+				if !template {
+					v.report(qualifierPath+".kind", templateConstraint, "template qualifiers are only allowed in templates")
+				}
+			case QualifierKindValueQualifier, QualifierKindConceptQualifier:
+			default:
+				v.report(qualifierPath+".kind", constraintSchema, "invalid qualifier kind %q", *qualifier.Kind)
+			}
+		}
+	}
+}
+
+func (v *validator) dataSpecifications(path string, specifications []EmbeddedDataSpecification) {
+	for i := range specifications {
+		specification := &specifications[i]
+		specificationPath := fmt.Sprintf("%s.embeddedDataSpecifications[%d]", path, i)
+		v.reference(specificationPath+".dataSpecification", &specification.DataSpecification)
+
+		content := specification.DataSpecificationContent
+		contentPath := specificationPath + ".dataSpecificationContent"
+		if content == nil {
+			v.report(contentPath, constraintSchema, "dataSpecificationContent is required")
+			continue
+		}
+		if len(content.PreferredName) == 0 {
+			v.report(contentPath+".preferredName", constraintSchema, "preferredName is required")
+		}
+		v.langStrings(contentPath+".preferredName", content.PreferredName)
+		v.langStrings(contentPath+".shortName", content.ShortName)
+		v.langStrings(contentPath+".definition", content.Definition)
+		if content.UnitId != nil {
+			v.reference(contentPath+".unitId", content.UnitId)
+		}
+		if content.ValueList != nil {
+			for j := range content.ValueList.ValueReferencePairs {
+				pair := &content.ValueList.ValueReferencePairs[j]
+				if pair.ValueId != nil {
+					v.reference(fmt.Sprintf("%s.valueList.valueReferencePairs[%d].valueId", contentPath, j), pair.ValueId)
+				}
+			}
+		}
+	}
+}
+
+func (v *validator) specificAssetIds(path string, ids []SpecificAssetId) {
+	for i := range ids {
+		id := &ids[i]
+		idPath := fmt.Sprintf("%s[%d]", path, i)
+		v.nonEmpty(idPath+".name", id.Name)
+		v.nonEmpty(idPath+".value", id.Value)
+		v.semantics(idPath, id.SemanticId, id.SupplementalSemanticIds)
+		if id.ExternalSubjectId != nil {
+			v.reference(idPath+".externalSubjectId", id.ExternalSubjectId)
+			if id.ExternalSubjectId.Type != ReferenceTypesExternalReference {
+				v.report(idPath+".externalSubjectId.type", "AASd-133", "externalSubjectId shall be an external reference")
+			}
+		}
+	}
+}
+
+func (v *validator) langStrings(path string, texts []LangString) {
+	languages := map[string]bool{}
+	for i, text := range texts {
+		textPath := fmt.Sprintf("%s[%d]", path, i)
+		v.nonEmpty(textPath+".language", text.Language)
+		v.nonEmpty(textPath+".text", text.Text)
+		if languages[text.Language] {
+			v.report(textPath+".language", constraintSchema, "language %q is used more than once", text.Language)
+		}
+		languages[text.Language] = true
+	}
+}
+
+// valueType reports value types that are not XML Schema datatypes.
+func (v *validator) valueType(path string, valueType DataTypeDefXsd) bool {
+	if !IsValidDataType(valueType) {
+		v.report(path, constraintSchema, "invalid value type %q", valueType)
+		return false
+	}
+	return true
+}
+
+// value reports a value that is not valid for valueType as constraint.
+func (v *validator) value(path string, valueType DataTypeDefXsd, value *string, constraint string) {
+	if value != nil && !IsValidValue(valueType, *value) {
+		v.report(path, constraint, "value %q is not a valid %s", *value, valueType)
+	}
+}
+
+// nonEmpty reports empty strings, which are never allowed by the metamodel.
+func (v *validator) nonEmpty(path, value string) {
+	if value == "" {
+		v.report(path, "AASd-100", "value shall not be empty")
+	}
+}
+
+var (
+	globallyIdentifiables = map[KeyTypes]bool{
+		KeyTypesGlobalReference:          true,
+		KeyTypesAssetAdministrationShell: true,
+		KeyTypesConceptDescription:       true,
+		KeyTypesIdentifiable:             true,
+		KeyTypesSubmodel:                 true,
+	}
+	aasIdentifiables = map[KeyTypes]bool{
+		KeyTypesAssetAdministrationShell: true,
+		KeyTypesConceptDescription:       true,
+		KeyTypesIdentifiable:             true,
+		KeyTypesSubmodel:                 true,
+	}
+	fragmentKeys = map[KeyTypes]bool{
+		KeyTypesAnnotatedRelationshipElement: true,
+		KeyTypesBasicEventElement:            true,
+		KeyTypesBlob:                         true,
+		KeyTypesCapability:                   true,
+		KeyTypesDataElement:                  true,
+		KeyTypesEntity:                       true,
+		KeyTypesEventElement:                 true,
+		KeyTypesFile:                         true,
+		KeyTypesFragmentReference:            true,
+		KeyTypesMultiLanguageProperty:        true,
+		KeyTypesOperation:                    true,
+		KeyTypesProperty:                     true,
+		KeyTypesRange:                        true,
+		KeyTypesReferable:                    true,
+		KeyTypesReferenceElement:             true,
+		KeyTypesRelationshipElement:          true,
+		KeyTypesSubmodelElement:              true,
+		KeyTypesSubmodelElementCollection:    true,
+		KeyTypesSubmodelElementList:          true,
+	}
+)
+
+// reference checks the key chain of ref.
+func (v *validator) reference(path string, ref *Reference) {
+	if ref.ReferredSemanticId != nil {
+		v.reference(path+".referredSemanticId", ref.ReferredSemanticId)
+	}
+	if ref.Type != ReferenceTypesExternalReference && ref.Type != ReferenceTypesModelReference {
+		v.report(path+".type", constraintSchema, "invalid reference type %q", ref.Type)
+		return
+	}
+	if len(ref.Keys) == 0 {
+		v.report(path+".keys", constraintSchema, "a reference shall have at least one key")
+		return
+	}
+
+	for i, key := range ref.Keys {
+		keyPath := fmt.Sprintf("%s.keys[%d]", path, i)
+		v.nonEmpty(keyPath+".value", key.Value)
+		if !globallyIdentifiables[key.Type] && !fragmentKeys[key.Type] && key.Type != KeyTypesReferable {
+			v.report(keyPath+".type", constraintSchema, "invalid key type %q", key.Type)
+		}
+	}
+
+	first := ref.Keys[0].Type
+	last := len(ref.Keys) - 1
+	switch {
+	case !globallyIdentifiables[first]:
+		v.report(path+".keys[0].type", "AASd-121", "the first key shall be globally identifiable, not %s", first)
+	case ref.Type == ReferenceTypesExternalReference && first != KeyTypesGlobalReference:
+		v.report(path+".keys[0].type", "AASd-122", "the first key of an external reference shall be a GlobalReference, not %s", first)
+	case ref.Type == ReferenceTypesModelReference && !aasIdentifiables[first]:
+		v.report(path+".keys[0].type", "AASd-123", "the first key of a model reference shall be an identifiable, not %s", first)
+	}
+
+	if ref.Type == ReferenceTypesExternalReference {
+		if lastType := ref.Keys[last].Type; lastType != KeyTypesGlobalReference && lastType != KeyTypesFragmentReference {
+			v.report(fmt.Sprintf("%s.keys[%d].type", path, last), "AASd-124", "the last key of an external reference shall be a GlobalReference or FragmentReference, not %s", lastType)
+		}
+		return
+	}
+
+	for i := 1; i < len(ref.Keys); i++ {
+		key, previous := ref.Keys[i], ref.Keys[i-1]
+		keyPath := fmt.Sprintf("%s.keys[%d]", path, i)
+		if !fragmentKeys[key.Type] {
+			v.report(keyPath+".type", "AASd-125", "keys following the first key of a model reference shall be fragment keys, not %s", key.Type)
+		}
+		if key.Type == KeyTypesFragmentReference {
+			if i != last {
+				v.report(keyPath+".type", "AASd-126", "only the last key of a model reference may be a FragmentReference")
+			}
+			if previous.Type != KeyTypesFile && previous.Type != KeyTypesBlob {
+				v.report(keyPath+".type", "AASd-127", "a FragmentReference shall follow a File or Blob key")
+			}
+		}
+		if previous.Type == KeyTypesSubmodelElementList {
+			if index, err := strconv.Atoi(key.Value); err != nil || index < 0 {
+				v.report(keyPath+".value", "AASd-128", "a key following a SubmodelElementList key shall be an index, not %q", key.Value)
+			}
+		}
+	}
+}
+
+// keyValuesEqual compares references by the values of their keys.
+func keyValuesEqual(a, b *Reference) bool {
+	if len(a.Keys) != len(b.Keys) {
+		return false
+	}
+	for i := range a.Keys {
+		if a.Keys[i].Value != b.Keys[i].Value {
+			return false
+		}
+	}
+	return true
+}
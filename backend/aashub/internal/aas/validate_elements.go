@@ -0,0 +1,276 @@
+package aas
+
+import "fmt"
+
+// elements checks the elements of a namespace, i.e. of a submodel, a
+// collection, the statements of an entity or the annotations of a
+// relationship.
+func (v *validator) elements(path string, elements SubmodelElements, template bool) {
+	idShorts := map[string]bool{}
+	for i, element := range elements {
+		elementPath := fmt.Sprintf("%s[%d]", path, i)
+		if element == nil {
+			v.report(elementPath, constraintSchema, "submodel element is missing")
+			continue
+		}
+		v.idShortInNamespace(elementPath, element, idShorts)
+		v.element(elementPath, element, template)
+	}
+}
+
+// idShortInNamespace reports elements without an idShort and those sharing
+// their idShort with a sibling.
+func (v *validator) idShortInNamespace(path string, element SubmodelElement, idShorts map[string]bool) {
+	idShort := element.Base().IdShort
+	if idShort == nil {
+		v.report(path+".idShort", "AASd-117", "idShort is required outside of a SubmodelElementList")
+		return
+	}
+	if idShorts[*idShort] {
+		v.report(path+".idShort", "AASd-022", "idShort %q is not unique in its namespace", *idShort)
+	}
+	idShorts[*idShort] = true
+}
+
+func (v *validator) element(path string, element SubmodelElement, template bool) {
+	base := element.Base()
+	v.referable(path, &base.Referable)
+	v.semantics(path, base.SemanticId, base.SupplementalSemanticIds)
+	v.qualifiers(path, base.Qualifiers, template, "AASd-129")
+	v.dataSpecifications(path, base.EmbeddedDataSpecifications)
+
+	if isDataElement(element) && base.Category != nil {
+		switch *base.Category {
+		case "CONSTANT", "PARAMETER", "VARIABLE":
+		default:
+			v.report(path+".category", "AASd-090", "the category of a data element shall be CONSTANT, PARAMETER or VARIABLE, not %q", *base.Category)
+		}
+	}
+
+	switch e := element.(type) {
+	case *Property:
+		if v.valueType(path+".valueType", e.ValueType) {
+			v.value(path+".value", e.ValueType, e.Value, constraintSchema)
+		}
+		v.optionalReference(path+".valueId", e.ValueId)
+	case *MultiLanguageProperty:
+		v.langStrings(path+".value", e.Value)
+		v.optionalReference(path+".valueId", e.ValueId)
+	case *Range:
+		if v.valueType(path+".valueType", e.ValueType) {
+			v.value(path+".min", e.ValueType, e.Min, constraintSchema)
+			v.value(path+".max", e.ValueType, e.Max, constraintSchema)
+		}
+	case *Blob:
+		v.nonEmpty(path+".contentType", e.ContentType)
+	case *File:
+		v.nonEmpty(path+".contentType", e.ContentType)
+		if e.Value != nil {
+			v.nonEmpty(path+".value", *e.Value)
+		}
+	case *ReferenceElement:
+		v.optionalReference(path+".value", e.Value)
+	case *RelationshipElement:
+		v.requiredReference(path+".first", e.First)
+		v.requiredReference(path+".second", e.Second)
+	case *AnnotatedRelationshipElement:
+		v.requiredReference(path+".first", e.First)
+		v.requiredReference(path+".second", e.Second)
+		for i, annotation := range e.Annotations {
+			if annotation != nil && !isDataElement(annotation) {
+				v.report(fmt.Sprintf("%s.annotations[%d]", path, i), constraintSchema, "annotations shall be data elements, not %s", annotation.ModelType())
+			}
+		}
+		v.elements(path+".annotations", e.Annotations, template)
+	case *Entity:
+		v.entity(path, e, template)
+	case *BasicEventElement:
+		v.event(path, e)
+	case *Operation:
+		v.operation(path, e, template)
+	case *SubmodelElementCollection:
+		v.elements(path+".value", e.Value, template)
+	case *SubmodelElementList:
+		v.list(path, e, template)
+	}
+}
+
+func (v *validator) entity(path string, entity *Entity, template bool) {
+	hasAssetId := entity.GlobalAssetId != nil || len(entity.SpecificAssetIds) > 0
+	switch entity.EntityType {
+	case EntityTypeSelfManagedEntity:
+		if !hasAssetId {
+			v.report(path, "AASd-014", "a self-managed entity shall have a globalAssetId or specificAssetIds")
+		}
+	case EntityTypeCoManagedEntity:
+		if hasAssetId {
+			v.report(path, "AASd-014", "a co-managed entity shall have neither a globalAssetId nor specificAssetIds")
+		}
+	default:
+		v.report(path+".entityType", constraintSchema, "invalid entity type %q", entity.EntityType)
+	}
+	if entity.GlobalAssetId != nil {
+		v.nonEmpty(path+".globalAssetId", *entity.GlobalAssetId)
+	}
+	v.specificAssetIds(path+".specificAssetIds", entity.SpecificAssetIds)
+	v.elements(path+".statements", entity.Statements, template)
+}
+
+func (v *validator) event(path string, event *BasicEventElement) {
+	v.reference(path+".observed", &event.Observed)
+	if event.Observed.Type != ReferenceTypesModelReference {
+		v.report(path+".observed.type", constraintSchema, "observed shall be a model reference")
+	}
+	switch event.Direction {
+	case DirectionInput:
+		if event.MaxInterval != nil {
+			v.report(path+".maxInterval", constraintSchema, "maxInterval is not applicable to input events")
+		}
+	case DirectionOutput:
+	default:
+		v.report(path+".direction", constraintSchema, "invalid direction %q", event.Direction)
+	}
+	if event.State != StateOfEventOn && event.State != StateOfEventOff {
+		v.report(path+".state", constraintSchema, "invalid state %q", event.State)
+	}
+	if event.MessageTopic != nil {
+		v.nonEmpty(path+".messageTopic", *event.MessageTopic)
+	}
+	v.optionalReference(path+".messageBroker", event.MessageBroker)
+	v.value(path+".lastUpdate", DataTypeDefXsdDateTime, event.LastUpdate, constraintSchema)
+	v.value(path+".minInterval", DataTypeDefXsdDuration, event.MinInterval, constraintSchema)
+	v.value(path+".maxInterval", DataTypeDefXsdDuration, event.MaxInterval, constraintSchema)
+}
+
+// operation checks the variables of an operation, which share a single
+// namespace.
+func (v *validator) operation(path string, operation *Operation, template bool) {
+	idShorts := map[string]bool{}
+	for _, group := range []struct {
+		name      string
+		variables []OperationVariable
+	}{
+		{"inputVariables", operation.InputVariables},
+		{"outputVariables", operation.OutputVariables},
+		{"inoutputVariables", operation.InoutputVariables},
+	} {
+		for i, variable := range group.variables {
+			valuePath := fmt.Sprintf("%s.%s[%d].value", path, group.name, i)
+			if variable.Value == nil {
+				v.report(valuePath, constraintSchema, "value is required")
+				continue
+			}
+			if idShort := variable.Value.Base().IdShort; idShort != nil && idShorts[*idShort] {
+				v.report(valuePath+".idShort", "AASd-134", "idShort %q is not unique among the variables of the operation", *idShort)
+			} else {
+				v.idShortInNamespace(valuePath, variable.Value, idShorts)
+			}
+			v.element(valuePath, variable.Value, template)
+		}
+	}
+}
+
+func (v *validator) list(path string, list *SubmodelElementList, template bool) {
+	if !isValidElementType(list.TypeValueListElement) {
+		v.report(path+".typeValueListElement", constraintSchema, "invalid element type %q", list.TypeValueListElement)
+	}
+	needsValueType := list.TypeValueListElement == AasSubmodelElementsProperty || list.TypeValueListElement == AasSubmodelElementsRange
+	if list.ValueTypeListElement != nil {
+		v.valueType(path+".valueTypeListElement", *list.ValueTypeListElement)
+	} else if needsValueType {
+		v.report(path+".valueTypeListElement", "AASd-109", "valueTypeListElement is required for lists of %s", list.TypeValueListElement)
+	}
+	v.optionalReference(path+".semanticIdListElement", list.SemanticIdListElement)
+
+	var semanticId *Reference
+	for i, child := range list.Value {
+		childPath := fmt.Sprintf("%s.value[%d]", path, i)
+		if child == nil {
+			v.report(childPath, constraintSchema, "submodel element is missing")
+			continue
+		}
+		base := child.Base()
+		if base.IdShort != nil {
+			v.report(childPath+".idShort", "AASd-120", "the elements of a SubmodelElementList shall not have an idShort")
+		}
+		if !isOfElementType(child, list.TypeValueListElement) {
+			v.report(childPath, "AASd-108", "%s does not match typeValueListElement %s", child.ModelType(), list.TypeValueListElement)
+		}
+		if needsValueType && list.ValueTypeListElement != nil {
+			if valueType, ok := valueTypeOf(child); ok && valueType != *list.ValueTypeListElement {
+				v.report(childPath+".valueType", "AASd-109", "valueType %s does not match valueTypeListElement %s", valueType, *list.ValueTypeListElement)
+			}
+		}
+		if base.SemanticId != nil {
+			if list.SemanticIdListElement != nil && !keyValuesEqual(base.SemanticId, list.SemanticIdListElement) {
+				v.report(childPath+".semanticId", "AASd-107", "semanticId does not match semanticIdListElement")
+			}
+			if semanticId == nil {
+				semanticId = base.SemanticId
+			} else if !keyValuesEqual(base.SemanticId, semanticId) {
+				v.report(childPath+".semanticId", "AASd-114", "the elements of a SubmodelElementList shall have the same semanticId")
+			}
+		}
+		v.element(childPath, child, template)
+	}
+}
+
+func (v *validator) optionalReference(path string, ref *Reference) {
+	if ref != nil {
+		v.reference(path, ref)
+	}
+}
+
+// requiredReference checks a reference that the metamodel marks as mandatory
+// even though it is decoded into a pointer.
+func (v *validator) requiredReference(path string, ref *Reference) {
+	if ref == nil {
+		v.report(path, constraintSchema, "reference is required")
+		return
+	}
+	v.reference(path, ref)
+}
+
+func isDataElement(element SubmodelElement) bool {
+	switch element.(type) {
+	case *Property, *MultiLanguageProperty, *Range, *Blob, *File, *ReferenceElement:
+		return true
+	}
+	return false
+}
+
+func isValidElementType(t AasSubmodelElements) bool {
+	switch t {
+	case AasSubmodelElementsSubmodelElement, AasSubmodelElementsDataElement, AasSubmodelElementsEventElement:
+		return true
+	}
+	return NewSubmodelElement(string(t)) != nil
+}
+
+// isOfElementType reports whether element is an instance of t, taking the
+// abstract types and the inheritance between relationships into account.
+func isOfElementType(element SubmodelElement, t AasSubmodelElements) bool {
+	switch t {
+	case AasSubmodelElementsSubmodelElement:
+		return true
+	case AasSubmodelElementsDataElement:
+		return isDataElement(element)
+	case AasSubmodelElementsEventElement:
+		_, ok := element.(*BasicEventElement)
+		return ok
+	case AasSubmodelElementsRelationshipElement:
+		_, ok := element.(*AnnotatedRelationshipElement)
+		return ok || element.ModelType() == string(t)
+	}
+	return element.ModelType() == string(t)
+}
+
+func valueTypeOf(element SubmodelElement) (DataTypeDefXsd, bool) {
+	switch e := element.(type) {
+	case *Property:
+		return e.ValueType, true
+	case *Range:
+		return e.ValueType, true
+	}
+	return "", false
+}
@@ -0,0 +1,322 @@
+package aas
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Namespace is the XML namespace of the metamodel v3.0.
+const Namespace = "https://admin-shell.io/aas/3/0"
+
+// ErrUnsupportedNamespace is returned for XML documents of other versions of
+// the metamodel, e.g. AASX packages written for v2.0.
+var ErrUnsupportedNamespace = errors.New("unsupported XML namespace")
+
+// DecodeXML reads an environment in the XML serialization.
+func DecodeXML(r io.Reader) (*Environment, error) {
+	var env Environment
+	if err := DecodeXMLElement(r, "environment", &env); err != nil {
+		return nil, err
+	}
+	return &env, nil
+}
+
+// DecodeXMLElement reads a document whose root element has the given name,
+// e.g. a single submodel, into v.
+func DecodeXMLElement(r io.Reader, name string, v any) error {
+	decoder := xml.NewDecoder(r)
+	start, err := rootElement(decoder)
+	if err != nil {
+		return err
+	}
+	if start.Name.Local != name {
+		return fmt.Errorf("unexpected root element %q, expected %s", start.Name.Local, name)
+	}
+	return decoder.DecodeElement(v, &start)
+}
+
+// DecodeXMLSubmodelElement reads a document whose root element is a submodel
+// element of any type, e.g. <property>.
+func DecodeXMLSubmodelElement(r io.Reader) (SubmodelElement, error) {
+	decoder := xml.NewDecoder(r)
+	start, err := rootElement(decoder)
+	if err != nil {
+		return nil, err
+	}
+	return decodeSubmodelElement(decoder, start)
+}
+
+// rootElement skips to the root element of a document, which must be in the
+// metamodel's namespace.
+func rootElement(decoder *xml.Decoder) (xml.StartElement, error) {
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return xml.StartElement{}, err
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		if start.Name.Space != Namespace {
+			return xml.StartElement{}, fmt.Errorf("%w %q, expected %q", ErrUnsupportedNamespace, start.Name.Space, Namespace)
+		}
+		return start, nil
+	}
+}
+
+// EncodeXML writes an environment in the XML serialization.
+func EncodeXML(w io.Writer, env *Environment) error {
+	return EncodeXMLElement(w, "environment", env)
+}
+
+// EncodeXMLElement writes v as a document whose root element has the given
+// name in the metamodel's namespace.
+func EncodeXMLElement(w io.Writer, name string, v any) error {
+	var buf bytes.Buffer
+	if err := xml.NewEncoder(&buf).EncodeElement(v, xml.StartElement{Name: xml.Name{Space: Namespace, Local: name}}); err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := writeWithoutEmptyLists(encoder, xml.NewDecoder(&buf)); err != nil {
+		return err
+	}
+	return encoder.Close()
+}
+
+// listElements are the elements wrapping the items of a list, e.g. <keys>.
+// encoding/xml writes them even if the list is empty, which the schema does
+// not allow, so writeWithoutEmptyLists drops them. <value> only wraps a list
+// in a MultiLanguageProperty.
+var listElements = map[string]bool{
+	"assetAdministrationShells":  true,
+	"conceptDescriptions":        true,
+	"definition":                 true,
+	"description":                true,
+	"displayName":                true,
+	"embeddedDataSpecifications": true,
+	"extensions":                 true,
+	"inoutputVariables":          true,
+	"inputVariables":             true,
+	"isCaseOf":                   true,
+	"keys":                       true,
+	"outputVariables":            true,
+	"preferredName":              true,
+	"qualifiers":                 true,
+	"refersTo":                   true,
+	"shortName":                  true,
+	"specificAssetIds":           true,
+	"submodels":                  true,
+	"supplementalSemanticIds":    true,
+	"valueReferencePairs":        true,
+}
+
+// writeWithoutEmptyLists copies the tokens of d to e, leaving out list
+// elements without items.
+func writeWithoutEmptyLists(e *xml.Encoder, d *xml.Decoder) error {
+	var parents []string
+	var pending *xml.StartElement
+	for {
+		token, err := d.RawToken()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if _, ok := token.(xml.EndElement); ok && pending != nil {
+			pending = nil
+			parents = parents[:len(parents)-1]
+			continue
+		}
+		if pending != nil {
+			if err := e.EncodeToken(*pending); err != nil {
+				return err
+			}
+			pending = nil
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			isList := listElements[t.Name.Local] ||
+				t.Name.Local == "value" && len(parents) > 0 && parents[len(parents)-1] == "multiLanguageProperty"
+			parents = append(parents, t.Name.Local)
+			if isList {
+				start := t.Copy()
+				pending = &start
+				continue
+			}
+		case xml.EndElement:
+			parents = parents[:len(parents)-1]
+		}
+		if err := e.EncodeToken(xml.CopyToken(token)); err != nil {
+			return err
+		}
+	}
+}
+
+// XMLElementName returns the name of the XML element of a submodel element,
+// e.g. "property".
+func XMLElementName(element SubmodelElement) string {
+	return xmlElementName(element.ModelType())
+}
+
+// xmlElementName is the name of the XML element of a submodel element, its
+// model type starting with a lower case letter.
+func xmlElementName(modelType string) string {
+	first, size := utf8.DecodeRuneInString(modelType)
+	return string(unicode.ToLower(first)) + modelType[size:]
+}
+
+func (s *SubmodelElements) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var elements SubmodelElements
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			element, err := decodeSubmodelElement(d, t)
+			if err != nil {
+				return fmt.Errorf("%s[%d]: %w", start.Name.Local, len(elements), err)
+			}
+			elements = append(elements, element)
+		case xml.EndElement:
+			*s = elements
+			return nil
+		}
+	}
+}
+
+func (s SubmodelElements) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for i, element := range s {
+		if element == nil {
+			return fmt.Errorf("%s[%d]: submodel element is missing", start.Name.Local, i)
+		}
+		if err := encodeSubmodelElement(e, element); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+func decodeSubmodelElement(d *xml.Decoder, start xml.StartElement) (SubmodelElement, error) {
+	name := start.Name.Local
+	element := NewSubmodelElement(strings.ToUpper(name[:1]) + name[1:])
+	if element == nil || xmlElementName(element.ModelType()) != name {
+		return nil, fmt.Errorf("%w %q", ErrUnknownModelType, name)
+	}
+	if err := d.DecodeElement(element, &start); err != nil {
+		return nil, err
+	}
+	return element, nil
+}
+
+func encodeSubmodelElement(e *xml.Encoder, element SubmodelElement) error {
+	return e.EncodeElement(element, xml.StartElement{Name: xml.Name{Local: xmlElementName(element.ModelType())}})
+}
+
+// MarshalXML wraps the submodel element in <value>.
+func (v OperationVariable) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if v.Value == nil {
+		return errors.New("operation variable without value")
+	}
+	value := xml.StartElement{Name: xml.Name{Local: "value"}}
+	for _, token := range []xml.Token{start, value} {
+		if err := e.EncodeToken(token); err != nil {
+			return err
+		}
+	}
+	if err := encodeSubmodelElement(e, v.Value); err != nil {
+		return err
+	}
+	if err := e.EncodeToken(value.End()); err != nil {
+		return err
+	}
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalXML reads the single submodel element wrapped in <value>.
+func (v *OperationVariable) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	depth := 0
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			if depth == 0 && t.Name.Local == "value" {
+				depth++
+				continue
+			}
+			if depth == 1 && v.Value == nil {
+				element, err := decodeSubmodelElement(d, t)
+				if err != nil {
+					return fmt.Errorf("value: %w", err)
+				}
+				v.Value = element
+				continue
+			}
+			if err := d.Skip(); err != nil {
+				return err
+			}
+		case xml.EndElement:
+			if depth == 0 {
+				if v.Value == nil {
+					return errors.New("operation variable without value")
+				}
+				return nil
+			}
+			depth--
+		}
+	}
+}
+
+// blobXML is the XML form of a Blob, whose value is base64 encoded text.
+type blobXML struct {
+	SubmodelElementBase
+	Value       string `xml:"value,omitempty"`
+	ContentType string `xml:"contentType"`
+}
+
+func (b Blob) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return e.EncodeElement(blobXML{
+		SubmodelElementBase: b.SubmodelElementBase,
+		Value:               base64.StdEncoding.EncodeToString(b.Value),
+		ContentType:         b.ContentType,
+	}, start)
+}
+
+func (b *Blob) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var raw blobXML
+	if err := d.DecodeElement(&raw, &start); err != nil {
+		return err
+	}
+	value, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(raw.Value), ""))
+	if err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	if len(value) == 0 {
+		value = nil
+	}
+	*b = Blob{SubmodelElementBase: raw.SubmodelElementBase, Value: value, ContentType: raw.ContentType}
+	return nil
+}
@@ -0,0 +1,159 @@
+package aas
+
+import (
+	"encoding/base64"
+	"math/big"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	xsdTimezone = `(Z|[+-]((0[0-9]|1[0-3]):[0-5][0-9]|14:00))?`
+	xsdYear     = `-?([1-9][0-9]{3,}|0[0-9]{3})`
+	xsdTime     = `(([01][0-9]|2[0-3]):[0-5][0-9]:[0-5][0-9](\.[0-9]+)?|24:00:00(\.0+)?)`
+	xsdDuration = `(([0-9]+H)([0-9]+M)?([0-9]+(\.[0-9]+)?S)?|([0-9]+M)([0-9]+(\.[0-9]+)?S)?|([0-9]+(\.[0-9]+)?S))`
+)
+
+var (
+	xsdDateRe       = regexp.MustCompile(`^(` + xsdYear + `)-(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])` + xsdTimezone + `$`)
+	xsdDateTimeRe   = regexp.MustCompile(`^(` + xsdYear + `)-(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])T` + xsdTime + xsdTimezone + `$`)
+	xsdTimeRe       = regexp.MustCompile(`^` + xsdTime + xsdTimezone + `$`)
+	xsdDurationRe   = regexp.MustCompile(`^-?P((([0-9]+Y([0-9]+M)?([0-9]+D)?|([0-9]+M)([0-9]+D)?|([0-9]+D))(T` + xsdDuration + `)?)|(T` + xsdDuration + `))$`)
+	xsdGDayRe       = regexp.MustCompile(`^---(0[1-9]|[12][0-9]|3[01])` + xsdTimezone + `$`)
+	xsdGMonthRe     = regexp.MustCompile(`^--(0[1-9]|1[0-2])` + xsdTimezone + `$`)
+	xsdGMonthDayRe  = regexp.MustCompile(`^--(0[1-9]|1[0-2])-(0[1-9]|[12][0-9]|3[01])` + xsdTimezone + `$`)
+	xsdGYearRe      = regexp.MustCompile(`^` + xsdYear + xsdTimezone + `$`)
+	xsdGYearMonthRe = regexp.MustCompile(`^` + xsdYear + `-(0[1-9]|1[0-2])` + xsdTimezone + `$`)
+	xsdDecimalRe    = regexp.MustCompile(`^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$`)
+	xsdIntegerRe    = regexp.MustCompile(`^[+-]?[0-9]+$`)
+	xsdFloatRe      = regexp.MustCompile(`^([+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)([Ee][+-]?[0-9]+)?|-?INF|NaN)$`)
+	xsdHexBinaryRe  = regexp.MustCompile(`^([0-9a-fA-F]{2})*$`)
+)
+
+// integerBounds are the inclusive bounds of the integer types; a nil bound
+// is unlimited.
+var integerBounds = map[DataTypeDefXsd][2]*big.Int{
+	DataTypeDefXsdInteger:            {nil, nil},
+	DataTypeDefXsdLong:               {big.NewInt(-1 << 63), big.NewInt(1<<63 - 1)},
+	DataTypeDefXsdInt:                {big.NewInt(-1 << 31), big.NewInt(1<<31 - 1)},
+	DataTypeDefXsdShort:              {big.NewInt(-1 << 15), big.NewInt(1<<15 - 1)},
+	DataTypeDefXsdByte:               {big.NewInt(-1 << 7), big.NewInt(1<<7 - 1)},
+	DataTypeDefXsdUnsignedLong:       {big.NewInt(0), new(big.Int).SetUint64(1<<64 - 1)},
+	DataTypeDefXsdUnsignedInt:        {big.NewInt(0), big.NewInt(1<<32 - 1)},
+	DataTypeDefXsdUnsignedShort:      {big.NewInt(0), big.NewInt(1<<16 - 1)},
+	DataTypeDefXsdUnsignedByte:       {big.NewInt(0), big.NewInt(1<<8 - 1)},
+	DataTypeDefXsdNonNegativeInteger: {big.NewInt(0), nil},
+	DataTypeDefXsdPositiveInteger:    {big.NewInt(1), nil},
+	DataTypeDefXsdNonPositiveInteger: {nil, big.NewInt(0)},
+	DataTypeDefXsdNegativeInteger:    {nil, big.NewInt(-1)},
+}
+
+// IsValidDataType reports whether t is one of the XML Schema datatypes
+// supported by the metamodel.
+func IsValidDataType(t DataTypeDefXsd) bool {
+	switch t {
+	case DataTypeDefXsdAnyURI, DataTypeDefXsdBase64Binary, DataTypeDefXsdBoolean,
+		DataTypeDefXsdDate, DataTypeDefXsdDateTime, DataTypeDefXsdDecimal,
+		DataTypeDefXsdDouble, DataTypeDefXsdDuration, DataTypeDefXsdFloat,
+		DataTypeDefXsdGDay, DataTypeDefXsdGMonth, DataTypeDefXsdGMonthDay,
+		DataTypeDefXsdGYear, DataTypeDefXsdGYearMonth, DataTypeDefXsdHexBinary,
+		DataTypeDefXsdString, DataTypeDefXsdTime:
+		return true
+	}
+	_, ok := integerBounds[t]
+	return ok
+}
+
+// IsValidValue reports whether value is a valid lexical representation of
+// the XML Schema datatype t.
+func IsValidValue(t DataTypeDefXsd, value string) bool {
+	if bounds, ok := integerBounds[t]; ok {
+		if !xsdIntegerRe.MatchString(value) {
+			return false
+		}
+		n, ok := new(big.Int).SetString(strings.TrimPrefix(value, "+"), 10)
+		return ok &&
+			(bounds[0] == nil || n.Cmp(bounds[0]) >= 0) &&
+			(bounds[1] == nil || n.Cmp(bounds[1]) <= 0)
+	}
+
+	switch t {
+	case DataTypeDefXsdString:
+		return true
+	case DataTypeDefXsdAnyURI:
+		_, err := url.Parse(value)
+		return err == nil
+	case DataTypeDefXsdBase64Binary:
+		_, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(value), ""))
+		return err == nil
+	case DataTypeDefXsdHexBinary:
+		return xsdHexBinaryRe.MatchString(value)
+	case DataTypeDefXsdBoolean:
+		return value == "true" || value == "false" || value == "1" || value == "0"
+	case DataTypeDefXsdDecimal:
+		return xsdDecimalRe.MatchString(value)
+	case DataTypeDefXsdDouble:
+		return isValidFloat(value, 64)
+	case DataTypeDefXsdFloat:
+		return isValidFloat(value, 32)
+	case DataTypeDefXsdDate:
+		return isValidDate(xsdDateRe, value)
+	case DataTypeDefXsdDateTime:
+		return isValidDate(xsdDateTimeRe, value)
+	case DataTypeDefXsdTime:
+		return xsdTimeRe.MatchString(value)
+	case DataTypeDefXsdDuration:
+		return xsdDurationRe.MatchString(value)
+	case DataTypeDefXsdGDay:
+		return xsdGDayRe.MatchString(value)
+	case DataTypeDefXsdGMonth:
+		return xsdGMonthRe.MatchString(value)
+	case DataTypeDefXsdGMonthDay:
+		return xsdGMonthDayRe.MatchString(value) && isValidDay(2000, value[2:4], value[5:7])
+	case DataTypeDefXsdGYear:
+		return xsdGYearRe.MatchString(value)
+	case DataTypeDefXsdGYearMonth:
+		return xsdGYearMonthRe.MatchString(value)
+	}
+	return false
+}
+
+// isValidFloat rejects values that overflow the given precision.
+func isValidFloat(value string, bitSize int) bool {
+	if !xsdFloatRe.MatchString(value) {
+		return false
+	}
+	if strings.HasSuffix(value, "INF") || value == "NaN" {
+		return true
+	}
+	_, err := strconv.ParseFloat(value, bitSize)
+	return err == nil
+}
+
+// isValidDate checks the day of month of a value matched by re, whose first
+// three groups are the year, month and day.
+func isValidDate(re *regexp.Regexp, value string) bool {
+	match := re.FindStringSubmatch(value)
+	if match == nil {
+		return false
+	}
+	year, err := strconv.Atoi(match[1])
+	if err != nil {
+		// Years beyond the range of int are fine for every month but February
+		year = 2000
+	}
+	return isValidDay(year, match[3], match[4])
+}
+
+func isValidDay(year int, month, day string) bool {
+	m, _ := strconv.Atoi(month)
+	d, _ := strconv.Atoi(day)
+	days := [...]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}[m-1]
+	// XML Schema counts year 0 as the leap year 1 BCE
+	if m == 2 && year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		days = 29
+	}
+	return d <= days
+}
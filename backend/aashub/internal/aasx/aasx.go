@@ -0,0 +1,290 @@
+// Package aasx reads AASX packages, the Open Packaging Conventions (OPC)
+// container in which AAS environments are exchanged together with their
+// supplementary files.
+package aasx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+// Relationship types of the AASX format. Packages written for the metamodel
+// v2.0 use the same types on the www.admin-shell.io host.
+const (
+	RelationshipOrigin        = "http://admin-shell.io/aasx/relationships/aasx-origin"
+	RelationshipSpec          = "http://admin-shell.io/aasx/relationships/aas-spec"
+	RelationshipSupplementary = "http://admin-shell.io/aasx/relationships/aas-suppl"
+	RelationshipThumbnail     = "http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail"
+)
+
+// MaxUncompressedSize bounds the total size of the parts read from a package,
+// so that a small upload cannot expand into an arbitrary amount of memory.
+var MaxUncompressedSize int64 = 512 << 20
+
+var (
+	ErrInvalidPackage = errors.New("invalid AASX package")
+	ErrTooLarge       = errors.New("AASX package too large")
+)
+
+// File is a part of a package, e.g. a supplementary file or the thumbnail.
+// Path is the absolute part name, e.g. /aasx/files/manual.pdf.
+type File struct {
+	Path        string
+	ContentType string
+	Data        []byte
+}
+
+// Package is the content of an AASX package. The environments of all its
+// AAS spec parts are merged into Environment.
+type Package struct {
+	Environment   aas.Environment
+	Specs         []File
+	Supplementary []File
+	Thumbnail     *File
+}
+
+type relationships struct {
+	Relationships []relationship `xml:"Relationship"`
+}
+
+type relationship struct {
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+	Mode   string `xml:"TargetMode,attr"`
+}
+
+type contentTypes struct {
+	Defaults []struct {
+		Extension   string `xml:"Extension,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Default"`
+	Overrides []struct {
+		PartName    string `xml:"PartName,attr"`
+		ContentType string `xml:"ContentType,attr"`
+	} `xml:"Override"`
+}
+
+// reader resolves the parts of an open package.
+type reader struct {
+	parts        map[string]*zip.File
+	contentTypes map[string]string
+	remaining    int64
+}
+
+// Read parses the package in r. It follows the relationships from the
+// package root to the origin part, from there to the AAS spec parts, and
+// from those to their supplementary files.
+func Read(r io.ReaderAt, size int64) (*Package, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPackage, err)
+	}
+
+	rd := &reader{parts: make(map[string]*zip.File), contentTypes: make(map[string]string), remaining: MaxUncompressedSize}
+	for _, file := range archive.File {
+		rd.parts[strings.ToLower("/"+strings.TrimPrefix(file.Name, "/"))] = file
+	}
+	if err := rd.readContentTypes(); err != nil {
+		return nil, err
+	}
+
+	rootRels, err := rd.relationships("/")
+	if err != nil {
+		return nil, err
+	}
+	pkg := &Package{}
+	for _, rel := range rootRels {
+		if !isType(rel.Type, RelationshipThumbnail) || pkg.Thumbnail != nil {
+			continue
+		}
+		if pkg.Thumbnail, err = rd.file(resolve("/", rel.Target)); err != nil {
+			return nil, err
+		}
+	}
+
+	origins := targets(rootRels, RelationshipOrigin, "/")
+	if len(origins) == 0 {
+		return nil, fmt.Errorf("%w: no aasx-origin relationship", ErrInvalidPackage)
+	}
+	originRels, err := rd.relationships(origins[0])
+	if err != nil {
+		return nil, err
+	}
+
+	specs := targets(originRels, RelationshipSpec, origins[0])
+	if len(specs) == 0 {
+		return nil, fmt.Errorf("%w: no aas-spec relationship", ErrInvalidPackage)
+	}
+	seen := make(map[string]bool)
+	for _, name := range specs {
+		spec, err := rd.file(name)
+		if err != nil {
+			return nil, err
+		}
+		env, err := decodeSpec(spec)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, spec.Path, err)
+		}
+		pkg.Specs = append(pkg.Specs, *spec)
+		merge(&pkg.Environment, env)
+
+		specRels, err := rd.relationships(name)
+		if err != nil {
+			return nil, err
+		}
+		for _, target := range targets(specRels, RelationshipSupplementary, name) {
+			if seen[strings.ToLower(target)] {
+				continue
+			}
+			seen[strings.ToLower(target)] = true
+			file, err := rd.file(target)
+			if err != nil {
+				return nil, err
+			}
+			pkg.Supplementary = append(pkg.Supplementary, *file)
+		}
+	}
+	return pkg, nil
+}
+
+// isType compares relationship types, accepting the v2.0 host.
+func isType(relType, expected string) bool {
+	return relType == expected || relType == strings.Replace(expected, "http://admin-shell.io/", "http://www.admin-shell.io/", 1)
+}
+
+// targets returns the part names of the internal relationships of the given
+// type, resolved against the source part.
+func targets(rels []relationship, relType string, source string) []string {
+	var names []string
+	for _, rel := range rels {
+		if isType(rel.Type, relType) && rel.Mode != "External" {
+			names = append(names, resolve(source, rel.Target))
+		}
+	}
+	return names
+}
+
+// resolve turns a relationship target into an absolute part name. Relative
+// targets are relative to the directory of the source part.
+func resolve(source, target string) string {
+	if strings.HasPrefix(target, "/") {
+		return path.Clean(target)
+	}
+	base := "/"
+	if source != "/" {
+		base = path.Dir(source)
+	}
+	return path.Join(base, target)
+}
+
+// relsName returns the name of the relationships part of a source part, e.g.
+// /aasx/_rels/aasx-origin.rels for /aasx/aasx-origin.
+func relsName(source string) string {
+	if source == "/" {
+		return "/_rels/.rels"
+	}
+	return path.Join(path.Dir(source), "_rels", path.Base(source)+".rels")
+}
+
+func (rd *reader) relationships(source string) ([]relationship, error) {
+	part, ok := rd.parts[strings.ToLower(relsName(source))]
+	if !ok {
+		if source == "/" {
+			return nil, fmt.Errorf("%w: missing /_rels/.rels", ErrInvalidPackage)
+		}
+		return nil, nil
+	}
+	data, err := rd.read(part)
+	if err != nil {
+		return nil, err
+	}
+	var rels relationships
+	if err := xml.Unmarshal(data, &rels); err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, relsName(source), err)
+	}
+	return rels.Relationships, nil
+}
+
+func (rd *reader) readContentTypes() error {
+	part, ok := rd.parts["/[content_types].xml"]
+	if !ok {
+		return fmt.Errorf("%w: missing [Content_Types].xml", ErrInvalidPackage)
+	}
+	data, err := rd.read(part)
+	if err != nil {
+		return err
+	}
+	var types contentTypes
+	if err := xml.Unmarshal(data, &types); err != nil {
+		return fmt.Errorf("%w: [Content_Types].xml: %v", ErrInvalidPackage, err)
+	}
+	for _, d := range types.Defaults {
+		rd.contentTypes["."+strings.ToLower(d.Extension)] = d.ContentType
+	}
+	for _, o := range types.Overrides {
+		rd.contentTypes[strings.ToLower(o.PartName)] = o.ContentType
+	}
+	return nil
+}
+
+// file reads the part with the given name together with its content type.
+func (rd *reader) file(name string) (*File, error) {
+	part, ok := rd.parts[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: missing part %s", ErrInvalidPackage, name)
+	}
+	data, err := rd.read(part)
+	if err != nil {
+		return nil, err
+	}
+	contentType, ok := rd.contentTypes[strings.ToLower(name)]
+	if !ok {
+		contentType = rd.contentTypes[strings.ToLower(path.Ext(name))]
+	}
+	return &File{Path: name, ContentType: contentType, Data: data}, nil
+}
+
+func (rd *reader) read(part *zip.File) ([]byte, error) {
+	r, err := part.Open()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, part.Name, err)
+	}
+	defer r.Close()
+
+	data, err := io.ReadAll(io.LimitReader(r, rd.remaining+1))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPackage, part.Name, err)
+	}
+	rd.remaining -= int64(len(data))
+	if rd.remaining < 0 {
+		return nil, ErrTooLarge
+	}
+	return data, nil
+}
+
+// decodeSpec parses an AAS spec part, which is either JSON or XML.
+func decodeSpec(spec *File) (*aas.Environment, error) {
+	if strings.EqualFold(path.Ext(spec.Path), ".json") || strings.Contains(spec.ContentType, "json") {
+		var env aas.Environment
+		if err := json.Unmarshal(spec.Data, &env); err != nil {
+			return nil, err
+		}
+		return &env, nil
+	}
+	return aas.DecodeXML(bytes.NewReader(spec.Data))
+}
+
+func merge(dst, src *aas.Environment) {
+	dst.AssetAdministrationShells = append(dst.AssetAdministrationShells, src.AssetAdministrationShells...)
+	dst.Submodels = append(dst.Submodels, src.Submodels...)
+	dst.ConceptDescriptions = append(dst.ConceptDescriptions, src.ConceptDescriptions...)
+}
@@ -0,0 +1,169 @@
+package aasx
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+)
+
+// Part names and content types of the parts written by Write.
+const (
+	originPart      = "/aasx/aasx-origin"
+	specPart        = "/aasx/data.json"
+	relsContentType = "application/vnd.openxmlformats-package.relationships+xml"
+	defaultType     = "application/octet-stream"
+)
+
+// ContentType is the media type of AASX packages.
+const ContentType = "application/asset-administration-shell-package"
+
+type writtenRelationships struct {
+	XMLName       xml.Name              `xml:"http://schemas.openxmlformats.org/package/2006/relationships Relationships"`
+	Relationships []writtenRelationship `xml:"Relationship"`
+}
+
+type writtenRelationship struct {
+	Type   string `xml:"Type,attr"`
+	Target string `xml:"Target,attr"`
+	ID     string `xml:"Id,attr"`
+}
+
+type writtenContentTypes struct {
+	XMLName   xml.Name `xml:"http://schemas.openxmlformats.org/package/2006/content-types Types"`
+	Defaults  []writtenDefault
+	Overrides []writtenOverride
+}
+
+type writtenDefault struct {
+	XMLName     xml.Name `xml:"Default"`
+	Extension   string   `xml:"Extension,attr"`
+	ContentType string   `xml:"ContentType,attr"`
+}
+
+type writtenOverride struct {
+	XMLName     xml.Name `xml:"Override"`
+	PartName    string   `xml:"PartName,attr"`
+	ContentType string   `xml:"ContentType,attr"`
+}
+
+// writer collects the parts of a package and their content types.
+type writer struct {
+	archive *zip.Writer
+	types   writtenContentTypes
+	written map[string]bool
+}
+
+// Write serializes pkg as an AASX package. The environment is written as a
+// single JSON spec part, related to the supplementary files; pkg.Specs is
+// ignored. The thumbnail is related to the package root.
+func Write(w io.Writer, pkg *Package) error {
+	wr := &writer{
+		archive: zip.NewWriter(w),
+		types: writtenContentTypes{Defaults: []writtenDefault{
+			{Extension: "rels", ContentType: relsContentType},
+		}},
+		written: make(map[string]bool),
+	}
+
+	rootRels := []writtenRelationship{{Type: RelationshipOrigin, Target: originPart}}
+	if pkg.Thumbnail != nil {
+		if err := wr.file(*pkg.Thumbnail); err != nil {
+			return err
+		}
+		rootRels = append(rootRels, writtenRelationship{Type: RelationshipThumbnail, Target: pkg.Thumbnail.Path})
+	}
+	if err := wr.relationships("/", rootRels); err != nil {
+		return err
+	}
+
+	if err := wr.part(originPart, "text/plain", []byte("Intentionally empty.")); err != nil {
+		return err
+	}
+	if err := wr.relationships(originPart, []writtenRelationship{{Type: RelationshipSpec, Target: specPart}}); err != nil {
+		return err
+	}
+
+	spec, err := json.MarshalIndent(&pkg.Environment, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := wr.part(specPart, "application/json", spec); err != nil {
+		return err
+	}
+	var specRels []writtenRelationship
+	related := make(map[string]bool)
+	for _, file := range pkg.Supplementary {
+		if err := wr.file(file); err != nil {
+			return err
+		}
+		if related[strings.ToLower(file.Path)] {
+			continue
+		}
+		related[strings.ToLower(file.Path)] = true
+		specRels = append(specRels, writtenRelationship{Type: RelationshipSupplementary, Target: file.Path})
+	}
+	if len(specRels) > 0 {
+		if err := wr.relationships(specPart, specRels); err != nil {
+			return err
+		}
+	}
+
+	data, err := xml.Marshal(wr.types)
+	if err != nil {
+		return err
+	}
+	if err := wr.entry("/[Content_Types].xml", append([]byte(xml.Header), data...)); err != nil {
+		return err
+	}
+	return wr.archive.Close()
+}
+
+// file writes a supplementary file or thumbnail. A file written before, e.g.
+// a thumbnail that is also a supplementary file, is skipped.
+func (wr *writer) file(file File) error {
+	name := path.Clean("/" + file.Path)
+	if name != file.Path || strings.HasSuffix(name, ".rels") || strings.Contains(name, "/_rels/") ||
+		strings.EqualFold(name, "/[Content_Types].xml") || strings.EqualFold(name, originPart) || strings.EqualFold(name, specPart) {
+		return fmt.Errorf("invalid part name %q", file.Path)
+	}
+	if wr.written[strings.ToLower(name)] {
+		return nil
+	}
+	contentType := file.ContentType
+	if contentType == "" {
+		contentType = defaultType
+	}
+	return wr.part(name, contentType, file.Data)
+}
+
+func (wr *writer) part(name, contentType string, data []byte) error {
+	wr.types.Overrides = append(wr.types.Overrides, writtenOverride{PartName: name, ContentType: contentType})
+	return wr.entry(name, data)
+}
+
+// relationships writes the relationships part of source. The relationship
+// ids are numbered in order, starting at rId1.
+func (wr *writer) relationships(source string, rels []writtenRelationship) error {
+	for i := range rels {
+		rels[i].ID = fmt.Sprintf("rId%d", i+1)
+	}
+	data, err := xml.Marshal(writtenRelationships{Relationships: rels})
+	if err != nil {
+		return err
+	}
+	return wr.entry(relsName(source), append([]byte(xml.Header), data...))
+}
+
+func (wr *writer) entry(name string, data []byte) error {
+	wr.written[strings.ToLower(name)] = true
+	entry, err := wr.archive.Create(strings.TrimPrefix(name, "/"))
+	if err != nil {
+		return err
+	}
+	_, err = entry.Write(data)
+	return err
+}
@@ -46,3 +46,15 @@ func IsTokenValid(tokenString string, secretKey string) (bool, error) {
 	}
 	return true, nil
 }
+
+// ParseToken validates a token issued by GenerateJWT and returns its payload.
+func ParseToken(tokenString string, secretKey string) (string, error) {
+	claims := &CustomClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secretKey), nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return "", err
+	}
+	return claims.Payload, nil
+}
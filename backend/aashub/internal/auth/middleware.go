@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type userIDKey struct{}
+
+// WithUserID returns a copy of ctx that carries the authenticated user.
+func WithUserID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, userIDKey{}, id)
+}
+
+// UserID returns the ID of the user authenticated by RequireUser.
+func UserID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(userIDKey{}).(string)
+	return id, ok && id != ""
+}
+
+// RequireUser rejects requests without a valid session token with 401. The
+// token is taken from an "Authorization: Bearer" header or else from the
+// session cookie, and the user it was issued to is added to the request
+// context for UserID.
+func RequireUser(secretKey string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c.Request)
+		if token == "" {
+			if cookie, err := c.Request.Cookie(TokenCookie); err == nil {
+				token = cookie.Value
+			}
+		}
+		if token == "" {
+			c.Header("WWW-Authenticate", "Bearer")
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+
+		id, err := ParseToken(token, secretKey)
+		if err != nil || id == "" {
+			c.Header("WWW-Authenticate", `Bearer error="invalid_token"`)
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+			return
+		}
+		c.Request = c.Request.WithContext(WithUserID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+func bearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
@@ -0,0 +1,424 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds all settings of the hub. It is loaded once at startup and
+// handed to the components that need it.
+type Config struct {
+	Server   ServerConfig   `yaml:"server"`
+	Database DatabaseConfig `yaml:"database"`
+	Auth     AuthConfig     `yaml:"auth"`
+	Mail     MailConfig     `yaml:"mail"`
+	Log      LogConfig      `yaml:"log"`
+	Tracing  TracingConfig  `yaml:"tracing"`
+	CORS     CORSConfig     `yaml:"cors"`
+}
+
+type ServerConfig struct {
+	// ListenAddress is the host:port the HTTP server binds to.
+	ListenAddress string `yaml:"listen_address"`
+	// PublicURL is the externally reachable base URL used in e-mail links.
+	PublicURL string `yaml:"public_url"`
+	// ShutdownTimeout is how long in-flight requests may take to finish after
+	// the server was asked to stop.
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// DrainDelay is how long the server keeps serving while it reports itself
+	// as not ready, so load balancers notice before the listeners close.
+	DrainDelay time.Duration `yaml:"drain_delay"`
+}
+
+type DatabaseConfig struct {
+	// Driver is one of mysql (also used for MariaDB), postgres or sqlite.
+	Driver          string        `yaml:"driver"`
+	DSN             string        `yaml:"dsn"`
+	MaxOpenConns    int           `yaml:"max_open_conns"`
+	MaxIdleConns    int           `yaml:"max_idle_conns"`
+	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `yaml:"conn_max_idle_time"`
+	// QueryTimeout bounds every single statement.
+	QueryTimeout time.Duration `yaml:"query_timeout"`
+	// AutoMigrate applies pending schema migrations when the server starts.
+	AutoMigrate bool `yaml:"auto_migrate"`
+}
+
+// CORSConfig controls which browser origins may call the API. Origins are
+// matched exactly, so they must be given as scheme://host[:port].
+type CORSConfig struct {
+	AllowedOrigins   []string      `yaml:"allowed_origins"`
+	AllowedMethods   []string      `yaml:"allowed_methods"`
+	AllowedHeaders   []string      `yaml:"allowed_headers"`
+	AllowCredentials bool          `yaml:"allow_credentials"`
+	MaxAge           time.Duration `yaml:"max_age"`
+}
+
+type AuthConfig struct {
+	// JWTKeyFile contains the secret used to sign session tokens.
+	JWTKeyFile string `yaml:"jwt_key_file"`
+}
+
+type MailConfig struct {
+	// Transport is one of tls, starttls, plain, file or memory.
+	Transport          string `yaml:"transport"`
+	Address            string `yaml:"address"`
+	Username           string `yaml:"username"`
+	Password           string `yaml:"password"`
+	Host               string `yaml:"host"`
+	Port               string `yaml:"port"`
+	Dir                string `yaml:"dir"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify"`
+}
+
+type LogConfig struct {
+	// Level is one of debug, info, warn or error.
+	Level string `yaml:"level"`
+	// Format is json or text.
+	Format string `yaml:"format"`
+}
+
+type TracingConfig struct {
+	// Enabled exports traces to the OTLP endpoint. Trace context is
+	// propagated either way.
+	Enabled bool `yaml:"enabled"`
+	// Endpoint is the host:port of the OTLP/HTTP receiver.
+	Endpoint string `yaml:"endpoint"`
+	// Insecure sends traces without TLS, e.g. to a collector sidecar.
+	Insecure bool `yaml:"insecure"`
+	// SampleRatio is the share of new traces that are recorded, between 0 and 1.
+	SampleRatio float64 `yaml:"sample_ratio"`
+	// ServiceName identifies the hub in the tracing backend.
+	ServiceName string `yaml:"service_name"`
+}
+
+// Default returns the configuration used when nothing else is set.
+func Default() *Config {
+	return &Config{
+		Server: ServerConfig{
+			ListenAddress:   ":9000",
+			PublicURL:       "http://localhost:9000",
+			ShutdownTimeout: 30 * time.Second,
+			DrainDelay:      5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:          "mysql",
+			DSN:             "user:password@tcp(mariadb)/aashub?parseTime=true",
+			MaxOpenConns:    25,
+			MaxIdleConns:    25,
+			ConnMaxLifetime: 5 * time.Minute,
+			ConnMaxIdleTime: time.Minute,
+			QueryTimeout:    5 * time.Second,
+			AutoMigrate:     true,
+		},
+		CORS: CORSConfig{
+			// The frontend's development server
+			AllowedOrigins:   []string{"http://localhost:3000"},
+			AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "HEAD", "OPTIONS"},
+			AllowedHeaders:   []string{"Origin", "Content-Length", "Content-Type", "Authorization", "X-Request-ID", "traceparent", "tracestate", "baggage"},
+			AllowCredentials: true,
+			MaxAge:           12 * time.Hour,
+		},
+		Auth: AuthConfig{
+			JWTKeyFile: "privatekey.txt",
+		},
+		Mail: MailConfig{
+			Transport: "tls",
+		},
+		Log: LogConfig{
+			Level:  "info",
+			Format: "json",
+		},
+		Tracing: TracingConfig{
+			Endpoint:    "localhost:4318",
+			SampleRatio: 1,
+			ServiceName: "aashub",
+		},
+	}
+}
+
+// Load builds the configuration from defaults, an optional YAML file, an
+// optional .env file, environment variables and finally the command line
+// flags in args, each overriding the previous source. The arguments left
+// after the flags are returned.
+func Load(args []string) (*Config, []string, error) {
+	cfg := Default()
+
+	flags := flag.NewFlagSet("aashub", flag.ContinueOnError)
+	configFile := flags.String("config", os.Getenv("CONFIG_FILE"), "path to a YAML configuration file")
+	envFile := flags.String("env-file", os.Getenv("ENV_FILE"), "path to a .env file")
+	listenAddress := flags.String("listen", "", "address the HTTP server listens on")
+	driver := flags.String("db-driver", "", "database driver (mysql, postgres or sqlite)")
+	dsn := flags.String("dsn", "", "database data source name")
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *configFile != "" {
+		if err := cfg.loadFile(*configFile); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// A missing .env file is not an error, so that ENV_FILE can point to an
+	// optional file that only exists on some machines
+	if *envFile != "" {
+		if err := godotenv.Load(*envFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, nil, fmt.Errorf("could not load env file %s: %w", *envFile, err)
+		}
+	}
+
+	if err := cfg.loadEnv(); err != nil {
+		return nil, nil, err
+	}
+
+	if *listenAddress != "" {
+		cfg.Server.ListenAddress = *listenAddress
+	}
+	if *driver != "" {
+		cfg.Database.Driver = *driver
+	}
+	if *dsn != "" {
+		cfg.Database.DSN = *dsn
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, nil, err
+	}
+
+	return cfg, flags.Args(), nil
+}
+
+func (c *Config) loadFile(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("could not read config file: %w", err)
+	}
+	defer file.Close()
+
+	decoder := yaml.NewDecoder(file)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil {
+		return fmt.Errorf("could not parse config file %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) loadEnv() error {
+	stringVars := map[string]*string{
+		"LISTEN_ADDRESS":       &c.Server.ListenAddress,
+		"SERVER_ADDRESS":       &c.Server.PublicURL,
+		"DB_DRIVER":            &c.Database.Driver,
+		"DATABASE_DSN":         &c.Database.DSN,
+		"JWT_KEY_FILE":         &c.Auth.JWTKeyFile,
+		"MAIL_TRANSPORT":       &c.Mail.Transport,
+		"MAIL_ADDRESS":         &c.Mail.Address,
+		"MAIL_USERNAME":        &c.Mail.Username,
+		"MAIL_PASSWORD":        &c.Mail.Password,
+		"MAIL_SMTP":            &c.Mail.Host,
+		"SMTP_PORT":            &c.Mail.Port,
+		"MAIL_DIR":             &c.Mail.Dir,
+		"LOG_LEVEL":            &c.Log.Level,
+		"LOG_FORMAT":           &c.Log.Format,
+		"TRACING_ENDPOINT":     &c.Tracing.Endpoint,
+		"TRACING_SERVICE_NAME": &c.Tracing.ServiceName,
+	}
+	for name, target := range stringVars {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = value
+		}
+	}
+
+	intVars := map[string]*int{
+		"DB_MAX_OPEN_CONNS": &c.Database.MaxOpenConns,
+		"DB_MAX_IDLE_CONNS": &c.Database.MaxIdleConns,
+	}
+	for name, target := range intVars {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = parsed
+		}
+	}
+
+	durationVars := map[string]*time.Duration{
+		"DB_CONN_MAX_LIFETIME":  &c.Database.ConnMaxLifetime,
+		"DB_CONN_MAX_IDLE_TIME": &c.Database.ConnMaxIdleTime,
+		"DB_QUERY_TIMEOUT":      &c.Database.QueryTimeout,
+		"CORS_MAX_AGE":          &c.CORS.MaxAge,
+		"SHUTDOWN_TIMEOUT":      &c.Server.ShutdownTimeout,
+		"DRAIN_DELAY":           &c.Server.DrainDelay,
+	}
+	for name, target := range durationVars {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := time.ParseDuration(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = parsed
+		}
+	}
+
+	boolVars := map[string]*bool{
+		"DB_AUTO_MIGRATE":           &c.Database.AutoMigrate,
+		"MAIL_INSECURE_SKIP_VERIFY": &c.Mail.InsecureSkipVerify,
+		"TRACING_ENABLED":           &c.Tracing.Enabled,
+		"TRACING_INSECURE":          &c.Tracing.Insecure,
+		"CORS_ALLOW_CREDENTIALS":    &c.CORS.AllowCredentials,
+	}
+	for name, target := range boolVars {
+		if value, ok := os.LookupEnv(name); ok {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+			*target = parsed
+		}
+	}
+
+	// Lists are comma separated
+	listVars := map[string]*[]string{
+		"CORS_ALLOWED_ORIGINS": &c.CORS.AllowedOrigins,
+		"CORS_ALLOWED_METHODS": &c.CORS.AllowedMethods,
+		"CORS_ALLOWED_HEADERS": &c.CORS.AllowedHeaders,
+	}
+	for name, target := range listVars {
+		if value, ok := os.LookupEnv(name); ok {
+			*target = splitList(value)
+		}
+	}
+
+	if value, ok := os.LookupEnv("TRACING_SAMPLE_RATIO"); ok {
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid TRACING_SAMPLE_RATIO: %w", err)
+		}
+		c.Tracing.SampleRatio = parsed
+	}
+
+	return nil
+}
+
+// Validate reports every invalid setting at once.
+func (c *Config) Validate() error {
+	var errs []error
+
+	if _, _, err := net.SplitHostPort(c.Server.ListenAddress); err != nil {
+		errs = append(errs, fmt.Errorf("server.listen_address: %w", err))
+	}
+	if c.Server.PublicURL == "" {
+		errs = append(errs, errors.New("server.public_url must be set"))
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("server.shutdown_timeout must be positive"))
+	}
+	if c.Server.DrainDelay < 0 {
+		errs = append(errs, errors.New("server.drain_delay must not be negative"))
+	}
+	switch strings.ToLower(c.Database.Driver) {
+	case "mysql", "mariadb", "postgres", "postgresql", "sqlite":
+	default:
+		errs = append(errs, fmt.Errorf("database.driver %q is unknown", c.Database.Driver))
+	}
+	if c.Database.DSN == "" {
+		errs = append(errs, errors.New("database.dsn must be set"))
+	}
+	if c.Database.MaxOpenConns < 0 || c.Database.MaxIdleConns < 0 {
+		errs = append(errs, errors.New("database connection limits must not be negative"))
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		errs = append(errs, errors.New("database.max_idle_conns must not exceed database.max_open_conns"))
+	}
+	if c.Database.QueryTimeout <= 0 {
+		errs = append(errs, errors.New("database.query_timeout must be positive"))
+	}
+	if c.Auth.JWTKeyFile == "" {
+		errs = append(errs, errors.New("auth.jwt_key_file must be set"))
+	}
+
+	switch strings.ToLower(c.Mail.Transport) {
+	case "tls", "starttls", "plain":
+		if c.Mail.Host == "" {
+			errs = append(errs, fmt.Errorf("mail.host must be set for the %q transport", c.Mail.Transport))
+		}
+		if _, err := strconv.ParseUint(c.Mail.Port, 10, 16); err != nil {
+			errs = append(errs, fmt.Errorf("mail.port %q is not a valid port", c.Mail.Port))
+		}
+	case "file":
+		if c.Mail.Dir == "" {
+			errs = append(errs, errors.New("mail.dir must be set for the \"file\" transport"))
+		}
+	case "memory":
+	default:
+		errs = append(errs, fmt.Errorf("mail.transport %q is unknown", c.Mail.Transport))
+	}
+
+	if c.Tracing.Enabled && c.Tracing.Endpoint == "" {
+		errs = append(errs, errors.New("tracing.endpoint must be set when tracing is enabled"))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		errs = append(errs, errors.New("tracing.sample_ratio must be between 0 and 1"))
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				errs = append(errs, errors.New("cors.allowed_origins must not contain * when cors.allow_credentials is set"))
+			}
+			continue
+		}
+		if err := validateOrigin(origin); err != nil {
+			errs = append(errs, fmt.Errorf("cors.allowed_origins: %w", err))
+		}
+	}
+
+	switch strings.ToLower(c.Log.Level) {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log.level %q is unknown", c.Log.Level))
+	}
+	switch strings.ToLower(c.Log.Format) {
+	case "json", "text":
+	default:
+		errs = append(errs, fmt.Errorf("log.format %q is unknown", c.Log.Format))
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateOrigin checks that origin is a bare scheme://host[:port] as sent in
+// the Origin header.
+func validateOrigin(origin string) error {
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return err
+	}
+	if (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" ||
+		parsed.Path != "" || parsed.RawQuery != "" || parsed.Fragment != "" || parsed.User != nil {
+		return fmt.Errorf("%q is not an origin of the form scheme://host[:port]", origin)
+	}
+	return nil
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -1,25 +1,209 @@
 package database
 
 import (
+	"context"
 	"database/sql"
+	"strings"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
 
 	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	_ "modernc.org/sqlite"
 )
 
-func NewDB() (*sql.DB, error) {
-	// Construct the Data Source Name (DSN)
-	dsn := "user:password@tcp(mariadb)/aashub?parseTime=true"
+// DB is a connection pool together with the dialect of its engine. Queries
+// passed to ExecContext, QueryContext and QueryRowContext, also those of a
+// transaction started with BeginTx, use ? placeholders regardless of the
+// engine and are recorded as client spans of the trace in their context.
+type DB struct {
+	*sql.DB
+	Dialect Dialect
+}
+
+// sqlitePragmas are applied to SQLite connections unless the DSN sets its own.
+const sqlitePragmas = "_pragma=busy_timeout(5000)&_pragma=foreign_keys(1)&_pragma=journal_mode(WAL)"
+
+func NewDB(cfg config.DatabaseConfig) (*DB, error) {
+	dialect, err := NewDialect(cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	dsn := cfg.DSN
+	if dialect.Name() == DialectSQLite && !strings.Contains(dsn, "_pragma=") {
+		separator := "?"
+		if strings.Contains(dsn, "?") {
+			separator = "&"
+		}
+		dsn += separator + sqlitePragmas
+	}
 
 	// Open a DB connection
-	db, err := sql.Open("mysql", dsn)
+	db, err := sql.Open(dialect.DriverName(), dsn)
 	if err != nil {
 		return nil, err
 	}
 
+	// Tune the connection pool
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
+
 	// Test the DB connection
-	if err := db.Ping(); err != nil {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.QueryTimeout)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &DB{DB: db, Dialect: dialect}, nil
+}
+
+// instrumentationName identifies the spans created by this package. The
+// tracer is looked up for every span so that it follows the global provider.
+const instrumentationName = "github.com/aas-hub-org/aashub/internal/database"
+
+func (db *DB) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return db.exec(ctx, db.DB, query, args...)
+}
+
+func (db *DB) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return db.query(ctx, db.DB, query, args...)
+}
+
+func (db *DB) QueryRowContext(ctx context.Context, query string, args ...any) *Row {
+	return db.queryRow(ctx, db.DB, query, args...)
+}
+
+// BeginTx starts a transaction whose statements are rebound and traced like
+// those run on db.
+func (db *DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error) {
+	tx, err := db.DB.BeginTx(ctx, opts)
+	if err != nil {
 		return nil, err
 	}
+	return &Tx{Tx: tx, db: db}, nil
+}
+
+// Tx is a transaction of a DB. Its statements use ? placeholders and are
+// recorded as spans like those of the DB.
+type Tx struct {
+	*sql.Tx
+	db *DB
+}
+
+func (tx *Tx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return tx.db.exec(ctx, tx.Tx, query, args...)
+}
+
+func (tx *Tx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return tx.db.query(ctx, tx.Tx, query, args...)
+}
+
+func (tx *Tx) QueryRowContext(ctx context.Context, query string, args ...any) *Row {
+	return tx.db.queryRow(ctx, tx.Tx, query, args...)
+}
+
+// WrapConn returns conn, a connection taken from db with Conn, with its
+// statements rebound and traced like those run on db.
+func (db *DB) WrapConn(conn *sql.Conn) *Conn {
+	return &Conn{Conn: conn, db: db}
+}
+
+// Conn is a single connection of a DB, e.g. the one holding the migration
+// lock. Its statements use ? placeholders and are recorded as spans like
+// those of the DB.
+type Conn struct {
+	*sql.Conn
+	db *DB
+}
+
+func (conn *Conn) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return conn.db.exec(ctx, conn.Conn, query, args...)
+}
+
+func (conn *Conn) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return conn.db.query(ctx, conn.Conn, query, args...)
+}
+
+func (conn *Conn) QueryRowContext(ctx context.Context, query string, args ...any) *Row {
+	return conn.db.queryRow(ctx, conn.Conn, query, args...)
+}
+
+// Row is the result of QueryRowContext. Its span lasts until Scan, as the
+// row is only read there.
+type Row struct {
+	*sql.Row
+	span trace.Span
+}
+
+func (r *Row) Scan(dest ...any) error {
+	defer r.span.End()
+	err := r.Row.Scan(dest...)
+	recordError(r.span, err)
+	return err
+}
+
+// queryer is implemented by *sql.DB, *sql.Tx and *sql.Conn.
+type queryer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+func (db *DB) exec(ctx context.Context, q queryer, query string, args ...any) (sql.Result, error) {
+	query = db.Dialect.Rebind(query)
+	ctx, span := db.startSpan(ctx, query)
+	defer span.End()
+
+	result, err := q.ExecContext(ctx, query, args...)
+	recordError(span, err)
+	return result, err
+}
+
+func (db *DB) query(ctx context.Context, q queryer, query string, args ...any) (*sql.Rows, error) {
+	query = db.Dialect.Rebind(query)
+	ctx, span := db.startSpan(ctx, query)
+	defer span.End()
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	recordError(span, err)
+	return rows, err
+}
+
+func (db *DB) queryRow(ctx context.Context, q queryer, query string, args ...any) *Row {
+	query = db.Dialect.Rebind(query)
+	ctx, span := db.startSpan(ctx, query)
+	return &Row{Row: q.QueryRowContext(ctx, query, args...), span: span}
+}
+
+// startSpan starts a span named after the statement's operation, e.g.
+// SELECT. Only the query text is recorded, never the arguments.
+func (db *DB) startSpan(ctx context.Context, query string) (context.Context, trace.Span) {
+	operation := "SQL"
+	if fields := strings.Fields(query); len(fields) > 0 {
+		operation = strings.ToUpper(fields[0])
+	}
+	return otel.Tracer(instrumentationName).Start(ctx, operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.DBSystemKey.String(db.Dialect.Name()),
+			semconv.DBOperationName(operation),
+			semconv.DBQueryText(query),
+		),
+	)
+}
 
-	return db, nil
+func recordError(span trace.Span, err error) {
+	if err != nil && err != sql.ErrNoRows {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
 }
@@ -0,0 +1,255 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Dialect hides the SQL differences between the supported database engines.
+// Repositories write their queries with ? placeholders and ask the dialect for
+// the statements that differ between engines.
+type Dialect interface {
+	// Name identifies the dialect, e.g. for selecting migration scripts.
+	Name() string
+	// DriverName is the database/sql driver used to open connections.
+	DriverName() string
+	// Rebind converts ? placeholders into the engine's placeholder syntax.
+	Rebind(query string) string
+	// Upsert returns an INSERT that updates the non-key columns if a row with
+	// the same keys exists.
+	Upsert(table string, columns []string, keys []string) string
+	// IsDuplicateKey reports whether err is a unique constraint violation.
+	IsDuplicateKey(err error) bool
+	// Lock acquires the named lock on conn, waiting at most timeout.
+	Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) error
+	// Unlock releases a lock acquired with Lock.
+	Unlock(ctx context.Context, conn *sql.Conn, name string) error
+}
+
+var ErrLockTimeout = errors.New("timed out waiting for database lock")
+
+// Dialect names accepted in the database configuration.
+const (
+	DialectMySQL    = "mysql"
+	DialectSQLite   = "sqlite"
+	DialectPostgres = "postgres"
+)
+
+// NewDialect returns the dialect with the given name.
+func NewDialect(name string) (Dialect, error) {
+	switch strings.ToLower(name) {
+	case DialectMySQL, "mariadb":
+		return MySQL{}, nil
+	case DialectSQLite:
+		return SQLite{}, nil
+	case DialectPostgres, "postgresql":
+		return Postgres{}, nil
+	}
+	return nil, fmt.Errorf("unknown database driver %q", name)
+}
+
+// MySQL is the dialect for MySQL and MariaDB.
+type MySQL struct{}
+
+func (MySQL) Name() string       { return DialectMySQL }
+func (MySQL) DriverName() string { return "mysql" }
+
+func (MySQL) Rebind(query string) string { return query }
+
+func (MySQL) Upsert(table string, columns []string, keys []string) string {
+	var updates []string
+	for _, column := range nonKeyColumns(columns, keys) {
+		updates = append(updates, column+" = VALUES("+column+")")
+	}
+	return insertStatement(table, columns) + " ON DUPLICATE KEY UPDATE " + strings.Join(updates, ", ")
+}
+
+func (MySQL) IsDuplicateKey(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr) && mysqlErr.Number == 1062
+}
+
+func (MySQL) Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) error {
+	var acquired sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", name, int(timeout.Seconds())).Scan(&acquired); err != nil {
+		return err
+	}
+	if !acquired.Valid || acquired.Int64 != 1 {
+		return ErrLockTimeout
+	}
+	return nil
+}
+
+func (MySQL) Unlock(ctx context.Context, conn *sql.Conn, name string) error {
+	_, err := conn.ExecContext(ctx, "SELECT RELEASE_LOCK(?)", name)
+	return err
+}
+
+// SQLite is the dialect for the embedded SQLite backend used for development
+// and tests.
+type SQLite struct{}
+
+// sqliteLockPollInterval is how often Lock retries a locked database, on top
+// of the busy_timeout each attempt waits.
+const sqliteLockPollInterval = 250 * time.Millisecond
+
+func (SQLite) Name() string       { return DialectSQLite }
+func (SQLite) DriverName() string { return "sqlite" }
+
+func (SQLite) Rebind(query string) string { return query }
+
+func (SQLite) Upsert(table string, columns []string, keys []string) string {
+	var updates []string
+	for _, column := range nonKeyColumns(columns, keys) {
+		updates = append(updates, column+" = excluded."+column)
+	}
+	return insertStatement(table, columns) + " ON CONFLICT (" + strings.Join(keys, ", ") + ") DO UPDATE SET " + strings.Join(updates, ", ")
+}
+
+func (SQLite) IsDuplicateKey(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+// Lock opens a write transaction on conn with BEGIN IMMEDIATE. SQLite has no
+// named locks, so this locks the whole database file against writers of all
+// processes, e.g. "aashub migrate" and a running server, until Unlock. The
+// lock is released by SQLite if the process dies.
+func (SQLite) Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := conn.ExecContext(ctx, "BEGIN IMMEDIATE")
+		if err == nil {
+			return nil
+		}
+		if !strings.Contains(err.Error(), "SQLITE_BUSY") && !strings.Contains(err.Error(), "database is locked") {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return ErrLockTimeout
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sqliteLockPollInterval):
+		}
+	}
+}
+
+// Unlock commits the transaction opened by Lock, keeping what was written
+// while holding the lock.
+func (SQLite) Unlock(ctx context.Context, conn *sql.Conn, name string) error {
+	if _, err := conn.ExecContext(ctx, "COMMIT"); err != nil {
+		conn.ExecContext(ctx, "ROLLBACK")
+		return err
+	}
+	return nil
+}
+
+// Postgres is the dialect for PostgreSQL.
+type Postgres struct{}
+
+// postgresLockPollInterval is how often Lock retries a held advisory lock.
+const postgresLockPollInterval = 250 * time.Millisecond
+
+func (Postgres) Name() string       { return DialectPostgres }
+func (Postgres) DriverName() string { return "pgx" }
+
+// Rebind replaces ? placeholders outside of string literals and quoted
+// identifiers with $1, $2, ...
+func (Postgres) Rebind(query string) string {
+	var builder strings.Builder
+	builder.Grow(len(query) + 8)
+
+	argument := 0
+	var quote rune
+	for _, r := range query {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == '?':
+			argument++
+			builder.WriteString("$" + strconv.Itoa(argument))
+			continue
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+func (Postgres) Upsert(table string, columns []string, keys []string) string {
+	return SQLite{}.Upsert(table, columns, keys)
+}
+
+func (Postgres) IsDuplicateKey(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
+func (Postgres) Lock(ctx context.Context, conn *sql.Conn, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		var acquired bool
+		if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", advisoryLockKey(name)).Scan(&acquired); err != nil {
+			return err
+		}
+		if acquired {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return ErrLockTimeout
+		}
+
+		select {
+		case <-time.After(postgresLockPollInterval):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (Postgres) Unlock(ctx context.Context, conn *sql.Conn, name string) error {
+	_, err := conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", advisoryLockKey(name))
+	return err
+}
+
+// advisoryLockKey maps a lock name to the numeric key PostgreSQL expects.
+func advisoryLockKey(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
+
+func insertStatement(table string, columns []string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", ")
+	return "INSERT INTO " + table + " (" + strings.Join(columns, ", ") + ") VALUES (" + placeholders + ")"
+}
+
+func nonKeyColumns(columns []string, keys []string) []string {
+	var result []string
+	for _, column := range columns {
+		isKey := false
+		for _, key := range keys {
+			if column == key {
+				isKey = true
+				break
+			}
+		}
+		if !isKey {
+			result = append(result, column)
+		}
+	}
+	return result
+}
@@ -0,0 +1,143 @@
+package migrations
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aas-hub-org/aashub/internal/database"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// Scripts live in one directory per database dialect, see database.Dialect.
+//
+//go:embed sql/*/*.sql
+var embedded embed.FS
+
+// Migration is one versioned schema change with its up and down script.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	// Step, if set, runs after the up script on the same connection, for
+	// changes of the data that SQL cannot express.
+	Step func(ctx context.Context, conn *database.Conn) error
+}
+
+// steps are the Go steps of the embedded migrations, by version.
+var steps = map[int]func(ctx context.Context, conn *database.Conn) error{
+	9: repositories.ReindexAssetIds,
+}
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// Load returns the migrations embedded in the binary for the named dialect,
+// ordered by version.
+func Load(dialect string) ([]Migration, error) {
+	sub, err := fs.Sub(embedded, path.Join("sql", dialect))
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := Parse(sub)
+	if err != nil {
+		return nil, fmt.Errorf("%s migrations: %w", dialect, err)
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no migrations for dialect %q", dialect)
+	}
+	for i := range migrations {
+		migrations[i].Step = steps[migrations[i].Version]
+	}
+	return migrations, nil
+}
+
+// Parse reads migrations named "<version>_<name>.<up|down>.sql" from the root
+// of fsys. Versions must start at 1 without gaps and every migration needs
+// both scripts.
+func Parse(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration file %s does not match <version>_<name>.<up|down>.sql", entry.Name())
+		}
+
+		version, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("migration file %s: %w", entry.Name(), err)
+		}
+		content, err := fs.ReadFile(fsys, path.Clean(entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+		if strings.TrimSpace(migration.Up) == "" || strings.TrimSpace(migration.Down) == "" {
+			return nil, fmt.Errorf("migration %d needs both an up and a down script", migration.Version)
+		}
+	}
+
+	return migrations, nil
+}
+
+// Statements splits a script into its single statements. Statements end with
+// a semicolon at the end of a line.
+func Statements(script string) []string {
+	var statements []string
+	var current strings.Builder
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if current.Len() == 0 && (trimmed == "" || strings.HasPrefix(trimmed, "--")) {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
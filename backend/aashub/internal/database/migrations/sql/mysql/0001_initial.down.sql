@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS Verifications;
+
+DROP TABLE IF EXISTS Users;
@@ -0,0 +1,4 @@
+ALTER TABLE Users
+    ADD COLUMN created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ADD COLUMN last_login_at TIMESTAMP NULL DEFAULT NULL;
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS Submodels;
+DROP TABLE IF EXISTS Shells;
+DROP TABLE IF EXISTS PackageFiles;
+DROP TABLE IF EXISTS Packages;
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS Packages (
+    id CHAR(36) PRIMARY KEY,
+    owner_id CHAR(36) NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    content LONGBLOB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE
+);
+
+-- Supplementary files and thumbnails extracted from a package
+CREATE TABLE IF NOT EXISTS PackageFiles (
+    id CHAR(36) PRIMARY KEY,
+    package_id CHAR(36) NOT NULL,
+    path VARCHAR(1024) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    thumbnail BOOLEAN NOT NULL DEFAULT FALSE,
+    content LONGBLOB NOT NULL,
+    FOREIGN KEY (package_id) REFERENCES Packages (id) ON DELETE CASCADE
+);
+
+-- AAS identifiers may be up to 2000 characters long, more than most engines
+-- can index, so shells and submodels are keyed by the SHA-256 of their id.
+CREATE TABLE IF NOT EXISTS Shells (
+    id_hash CHAR(64) PRIMARY KEY,
+    id TEXT NOT NULL,
+    id_short VARCHAR(128) NULL,
+    owner_id CHAR(36) NOT NULL,
+    package_id CHAR(36) NULL,
+    document LONGTEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE,
+    FOREIGN KEY (package_id) REFERENCES Packages (id) ON DELETE SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS Submodels (
+    id_hash CHAR(64) PRIMARY KEY,
+    id TEXT NOT NULL,
+    id_short VARCHAR(128) NULL,
+    semantic_id TEXT NULL,
+    owner_id CHAR(36) NOT NULL,
+    package_id CHAR(36) NULL,
+    document LONGTEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE,
+    FOREIGN KEY (package_id) REFERENCES Packages (id) ON DELETE SET NULL
+);
@@ -0,0 +1,26 @@
+-- Descriptors of shells and submodels hosted elsewhere, registered by their
+-- owners, e.g. edge servers.
+CREATE TABLE IF NOT EXISTS ShellDescriptors (
+    id_hash CHAR(64) PRIMARY KEY,
+    id TEXT NOT NULL,
+    id_short VARCHAR(128) NULL,
+    asset_kind VARCHAR(32) NULL,
+    asset_type TEXT NULL,
+    owner_id CHAR(36) NOT NULL,
+    document LONGTEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS SubmodelDescriptors (
+    id_hash CHAR(64) PRIMARY KEY,
+    id TEXT NOT NULL,
+    id_short VARCHAR(128) NULL,
+    semantic_id TEXT NULL,
+    owner_id CHAR(36) NOT NULL,
+    document LONGTEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE
+);
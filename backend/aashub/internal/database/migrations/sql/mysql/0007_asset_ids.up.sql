@@ -0,0 +1,15 @@
+-- Index of the discovery service mapping asset ids to shell ids. Rows are
+-- derived from stored shells and shell descriptors or linked explicitly;
+-- source names the table they come from. asset_hash is the SHA-256 of the
+-- asset id's name and value, the document the asset id itself.
+CREATE TABLE IF NOT EXISTS AssetIds (
+    aas_id_hash CHAR(64) NOT NULL,
+    aas_id TEXT NOT NULL,
+    source VARCHAR(32) NOT NULL,
+    asset_hash CHAR(64) NOT NULL,
+    owner_id CHAR(36) NOT NULL,
+    document TEXT NOT NULL,
+    PRIMARY KEY (aas_id_hash, source, asset_hash),
+    INDEX idx_asset_ids_asset_hash (asset_hash),
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,14 @@
+-- is_case_of and data_specifications hold the hashes of the references of a
+-- concept description, separated by spaces, to filter by them.
+CREATE TABLE IF NOT EXISTS ConceptDescriptions (
+    id_hash CHAR(64) PRIMARY KEY,
+    id TEXT NOT NULL,
+    id_short VARCHAR(128) NULL,
+    is_case_of TEXT NOT NULL,
+    data_specifications TEXT NOT NULL,
+    owner_id CHAR(36) NOT NULL,
+    document TEXT NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (owner_id) REFERENCES Users (id) ON DELETE CASCADE
+);
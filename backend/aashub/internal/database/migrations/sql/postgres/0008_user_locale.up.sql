@@ -0,0 +1,2 @@
+-- The locale the e-mails to a user are written in
+ALTER TABLE Users ADD COLUMN locale VARCHAR(16) NOT NULL DEFAULT 'en';
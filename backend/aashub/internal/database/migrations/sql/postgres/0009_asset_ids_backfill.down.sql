@@ -0,0 +1 @@
+-- The backfilled rows stay, they are valid for the previous version as well.
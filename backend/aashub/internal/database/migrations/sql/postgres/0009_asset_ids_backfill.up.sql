@@ -0,0 +1,4 @@
+-- Shells and shell descriptors stored before the lookup index existed are
+-- indexed by the Go step of this migration, which rebuilds all rows derived
+-- from them.
+DELETE FROM AssetIds WHERE source IN ('Shells', 'ShellDescriptors');
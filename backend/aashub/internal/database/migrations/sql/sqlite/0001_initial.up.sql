@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS Users (
+    id CHAR(36) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS Verifications (
+    email VARCHAR(255) PRIMARY KEY,
+    verification_code VARCHAR(255) NOT NULL,
+    verified BOOLEAN NOT NULL DEFAULT FALSE
+);
@@ -0,0 +1,5 @@
+ALTER TABLE Users DROP COLUMN last_login_at;
+
+ALTER TABLE Users DROP COLUMN updated_at;
+
+ALTER TABLE Users DROP COLUMN created_at;
@@ -0,0 +1,17 @@
+-- SQLite cannot add columns with a non-constant default, so rebuild the table
+CREATE TABLE Users_new (
+    id CHAR(36) PRIMARY KEY,
+    username VARCHAR(255) NOT NULL UNIQUE,
+    email VARCHAR(255) NOT NULL UNIQUE,
+    password_hash VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMP NULL
+);
+
+INSERT INTO Users_new (id, username, email, password_hash)
+SELECT id, username, email, password_hash FROM Users;
+
+DROP TABLE Users;
+
+ALTER TABLE Users_new RENAME TO Users;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS UserRoles (
+    user_id CHAR(36) NOT NULL,
+    role VARCHAR(64) NOT NULL,
+    PRIMARY KEY (user_id, role),
+    FOREIGN KEY (user_id) REFERENCES Users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS SubmodelDescriptors;
+DROP TABLE IF EXISTS ShellDescriptors;
@@ -0,0 +1,98 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+)
+
+type ConceptDescriptionRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
+}
+
+func (repo *ConceptDescriptionRepository) table() documentTable[aas.ConceptDescription] {
+	return documentTable[aas.ConceptDescription]{
+		db:      repo.DB,
+		timeout: repo.QueryTimeout,
+		name:    "ConceptDescriptions",
+		columns: func(cd *aas.ConceptDescription) (string, []string, []any) {
+			var dataSpecifications []aas.Reference
+			for _, spec := range cd.EmbeddedDataSpecifications {
+				dataSpecifications = append(dataSpecifications, spec.DataSpecification)
+			}
+			return cd.Id, []string{"id_short", "is_case_of", "data_specifications"},
+				[]any{cd.IdShort, referenceHashes(cd.IsCaseOf), referenceHashes(dataSpecifications)}
+		},
+	}
+}
+
+// referenceHash identifies a reference by its type and keys. The referred
+// semantic id is not part of the identity.
+func referenceHash(ref aas.Reference) string {
+	data, _ := json.Marshal(aas.Reference{Type: ref.Type, Keys: ref.Keys})
+	return idHash(string(data))
+}
+
+func referenceHashes(refs []aas.Reference) string {
+	hashes := make([]string, len(refs))
+	for i, ref := range refs {
+		hashes[i] = referenceHash(ref)
+	}
+	return strings.Join(hashes, " ")
+}
+
+// ListConceptDescriptions returns up to limit concept descriptions ordered by
+// the hash of their id, starting after cursor, and the cursor of the next
+// page, which is empty on the last page. Concept descriptions match isCaseOf
+// and dataSpecificationRef if one of their isCaseOf references or data
+// specifications equals them. Empty filters match all concept descriptions.
+func (repo *ConceptDescriptionRepository) ListConceptDescriptions(ctx context.Context, idShort string, isCaseOf *aas.Reference, dataSpecificationRef *aas.Reference, cursor string, limit int) ([]aas.ConceptDescription, string, error) {
+	var filters []filter
+	if idShort != "" {
+		filters = append(filters, filter{column: "id_short", value: idShort})
+	}
+	if isCaseOf != nil {
+		filters = append(filters, filter{column: "is_case_of", value: referenceHash(*isCaseOf), contains: true})
+	}
+	if dataSpecificationRef != nil {
+		filters = append(filters, filter{column: "data_specifications", value: referenceHash(*dataSpecificationRef), contains: true})
+	}
+	return repo.table().list(ctx, filters, cursor, limit)
+}
+
+// GetConceptDescription returns the concept description with the given id or
+// ErrIdentifiableNotFound.
+func (repo *ConceptDescriptionRepository) GetConceptDescription(ctx context.Context, id string) (*aas.ConceptDescription, error) {
+	return repo.table().get(ctx, id)
+}
+
+// GetConceptDescriptions returns the stored concept descriptions with the
+// given ids in the order of ids. Ids without a stored concept description are
+// skipped.
+func (repo *ConceptDescriptionRepository) GetConceptDescriptions(ctx context.Context, ids []string) ([]aas.ConceptDescription, error) {
+	return repo.table().getAll(ctx, ids)
+}
+
+// CreateConceptDescription stores a new concept description owned by
+// ownerID, or fails with ErrIdentifiableExists.
+func (repo *ConceptDescriptionRepository) CreateConceptDescription(ctx context.Context, ownerID string, cd *aas.ConceptDescription) error {
+	return repo.table().create(ctx, ownerID, cd)
+}
+
+// UpdateConceptDescription replaces the concept description with the given
+// id by the result of update, like UpdateShell.
+func (repo *ConceptDescriptionRepository) UpdateConceptDescription(ctx context.Context, ownerID string, id string, update func(*aas.ConceptDescription) error) error {
+	return repo.table().update(ctx, ownerID, id, update)
+}
+
+// DeleteConceptDescription removes the concept description with the given
+// id. Only the owner may delete a concept description.
+func (repo *ConceptDescriptionRepository) DeleteConceptDescription(ctx context.Context, ownerID string, id string) error {
+	return repo.table().delete(ctx, ownerID, id)
+}
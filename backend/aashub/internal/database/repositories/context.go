@@ -0,0 +1,19 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultQueryTimeout bounds a single statement when a repository has no
+// QueryTimeout configured.
+const DefaultQueryTimeout = 5 * time.Second
+
+// queryContext derives the context a single statement runs with, so a slow
+// database fails the statement instead of blocking the request.
+func queryContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		timeout = DefaultQueryTimeout
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,285 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+)
+
+// documentTable stores identifiables of type T as JSON documents, keyed by
+// the hash of their id and owned by a user. The Shells and Submodels tables
+// share this layout and differ only in the columns derived from the
+// documents.
+type documentTable[T any] struct {
+	db      *sqldb.DB
+	timeout time.Duration
+	name    string
+	// columns returns the id of a document and the names and values of the
+	// further columns derived from it, e.g. id_short.
+	columns func(*T) (id string, names []string, values []any)
+	// assetIds, if set, returns the asset ids that the lookup index maps to
+	// the id of a document. The index is kept in sync by the same
+	// transactions that write the documents.
+	assetIds func(*T) []aas.SpecificAssetId
+}
+
+// filter is a condition on a derived column of a documentTable.
+type filter struct {
+	column string
+	value  any
+	// contains matches columns holding a list that contains value rather
+	// than being equal to it.
+	contains bool
+}
+
+// list returns up to limit documents ordered by id_hash, starting after
+// cursor, and the cursor of the next page, which is empty on the last page.
+func (t documentTable[T]) list(ctx context.Context, filters []filter, cursor string, limit int) ([]T, string, error) {
+	query := "SELECT id_hash, document FROM " + t.name + " WHERE id_hash > ?"
+	args := []any{cursor}
+	for _, f := range filters {
+		if f.contains {
+			query += " AND " + f.column + " LIKE ?"
+			args = append(args, fmt.Sprintf("%%%v%%", f.value))
+			continue
+		}
+		query += " AND " + f.column + " = ?"
+		args = append(args, f.value)
+	}
+	query += " ORDER BY id_hash LIMIT ?"
+	args = append(args, limit+1)
+
+	queryCtx, cancel := queryContext(ctx, t.timeout)
+	defer cancel()
+	rows, err := t.db.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	documents := []T{}
+	next := ""
+	for rows.Next() {
+		var hash, document string
+		if err := rows.Scan(&hash, &document); err != nil {
+			return nil, "", err
+		}
+		if len(documents) == limit {
+			next = cursor
+			break
+		}
+		var v T
+		if err := json.Unmarshal([]byte(document), &v); err != nil {
+			return nil, "", err
+		}
+		documents = append(documents, v)
+		cursor = hash
+	}
+	return documents, next, rows.Err()
+}
+
+// get returns the document with the given id or ErrIdentifiableNotFound.
+func (t documentTable[T]) get(ctx context.Context, id string) (*T, error) {
+	queryCtx, cancel := queryContext(ctx, t.timeout)
+	defer cancel()
+
+	var v T
+	if err := readDocument(queryCtx, t.db, "SELECT document FROM "+t.name+" WHERE id_hash = ?", id, &v); err != nil {
+		return nil, err
+	}
+	return &v, nil
+}
+
+// maxBatchSize bounds the ids looked up by one query of getAll, keeping the
+// number of placeholders well within the limits of all engines.
+const maxBatchSize = 500
+
+// getAll returns the documents with the given ids in the order of ids,
+// skipping those that are not stored.
+func (t documentTable[T]) getAll(ctx context.Context, ids []string) ([]T, error) {
+	queryCtx, cancel := queryContext(ctx, t.timeout)
+	defer cancel()
+
+	stored := make(map[string]string, len(ids))
+	for start := 0; start < len(ids); start += maxBatchSize {
+		batch := ids[start:min(start+maxBatchSize, len(ids))]
+		args := make([]any, len(batch))
+		for i, id := range batch {
+			args[i] = idHash(id)
+		}
+		query := "SELECT id_hash, document FROM " + t.name + " WHERE id_hash IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(batch)), ", ") + ")"
+		if err := t.readDocuments(queryCtx, query, args, stored); err != nil {
+			return nil, err
+		}
+	}
+
+	documents := []T{}
+	for _, id := range ids {
+		document, ok := stored[idHash(id)]
+		if !ok {
+			continue
+		}
+		// Each document is returned once even if its id is repeated
+		delete(stored, idHash(id))
+		var v T
+		if err := json.Unmarshal([]byte(document), &v); err != nil {
+			return nil, err
+		}
+		documents = append(documents, v)
+	}
+	return documents, nil
+}
+
+// readDocuments adds the documents selected by query to documents, keyed by
+// the hash of their id.
+func (t documentTable[T]) readDocuments(ctx context.Context, query string, args []any, documents map[string]string) error {
+	rows, err := t.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var hash, document string
+		if err := rows.Scan(&hash, &document); err != nil {
+			return err
+		}
+		documents[hash] = document
+	}
+	return rows.Err()
+}
+
+// create stores a new document owned by ownerID, or fails with
+// ErrIdentifiableExists.
+func (t documentTable[T]) create(ctx context.Context, ownerID string, v *T) error {
+	queryCtx, cancel := queryContext(ctx, t.timeout)
+	defer cancel()
+
+	document, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	id, names, values := t.columns(v)
+	now := time.Now().UTC()
+	columns := append([]string{"id_hash", "id", "owner_id", "document", "created_at", "updated_at"}, names...)
+	args := append([]any{idHash(id), id, ownerID, string(document), now, now}, values...)
+	query := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", t.name, strings.Join(columns, ", "), strings.TrimSuffix(strings.Repeat("?, ", len(columns)), ", "))
+
+	tx, err := t.db.BeginTx(queryCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	_, err = tx.ExecContext(queryCtx, query, args...)
+	if t.db.Dialect.IsDuplicateKey(err) {
+		return ErrIdentifiableExists
+	}
+	if err != nil {
+		return err
+	}
+	if t.assetIds != nil {
+		if err := indexAssetIds(queryCtx, tx, t.name, ownerID, id, t.assetIds(v)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// update replaces the document with the given id by the result of modify,
+// which is called with the stored document and must keep its id. Only the
+// owner may update a document; ErrNotOwner is returned for everybody else.
+// Errors of modify are returned as is.
+func (t documentTable[T]) update(ctx context.Context, ownerID string, id string, modify func(*T) error) error {
+	queryCtx, cancel := queryContext(ctx, t.timeout)
+	defer cancel()
+	tx, err := t.db.BeginTx(queryCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var owner, document string
+	err = tx.QueryRowContext(queryCtx, "SELECT owner_id, document FROM "+t.name+" WHERE id_hash = ?", idHash(id)).Scan(&owner, &document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrIdentifiableNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+	if owner != ownerID {
+		return ErrNotOwner
+	}
+
+	var v T
+	if err := json.Unmarshal([]byte(document), &v); err != nil {
+		return err
+	}
+	if err := modify(&v); err != nil {
+		return err
+	}
+	newID, names, values := t.columns(&v)
+	if newID != id {
+		return fmt.Errorf("the id cannot be changed from %q to %q", id, newID)
+	}
+	updated, err := json.Marshal(&v)
+	if err != nil {
+		return err
+	}
+
+	query := "UPDATE " + t.name + " SET document = ?, updated_at = ?"
+	args := []any{string(updated), time.Now().UTC()}
+	for i, name := range names {
+		query += ", " + name + " = ?"
+		args = append(args, values[i])
+	}
+	query += " WHERE id_hash = ?"
+	args = append(args, idHash(id))
+	if _, err := tx.ExecContext(queryCtx, query, args...); err != nil {
+		return err
+	}
+	if t.assetIds != nil {
+		if err := indexAssetIds(queryCtx, tx, t.name, ownerID, id, t.assetIds(&v)); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// delete removes the document with the given id and its asset ids. Only the
+// owner may delete a document.
+func (t documentTable[T]) delete(ctx context.Context, ownerID string, id string) error {
+	queryCtx, cancel := queryContext(ctx, t.timeout)
+	defer cancel()
+	tx, err := t.db.BeginTx(queryCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var owner string
+	err = tx.QueryRowContext(queryCtx, "SELECT owner_id FROM "+t.name+" WHERE id_hash = ?", idHash(id)).Scan(&owner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrIdentifiableNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+	if owner != ownerID {
+		return ErrNotOwner
+	}
+	if _, err := tx.ExecContext(queryCtx, "DELETE FROM "+t.name+" WHERE id_hash = ? AND owner_id = ?", idHash(id), ownerID); err != nil {
+		return err
+	}
+	if t.assetIds != nil {
+		if err := indexAssetIds(queryCtx, tx, t.name, ownerID, id, nil); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
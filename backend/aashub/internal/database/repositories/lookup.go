@@ -0,0 +1,332 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+)
+
+// The lookup index in the AssetIds table maps asset ids to the ids of the
+// shells they identify. Stored shells and shell descriptors contribute their
+// globalAssetId and specificAssetIds, and users can link further asset ids to
+// any shell id. Each row records its source, the table of the document it
+// was derived from, so that every source is replaced on its own.
+
+// GlobalAssetIdName is the name under which a globalAssetId is indexed and
+// looked up, as the Discovery Service specifies.
+const GlobalAssetIdName = "globalAssetId"
+
+// linkSource is the source of the asset ids linked explicitly.
+const linkSource = "AssetLinks"
+
+// assetIdHash is the key of an asset id in the lookup index. Only the name
+// and the value identify an asset; the semantic ids do not.
+func assetIdHash(assetId *aas.SpecificAssetId) string {
+	return idHash(assetId.Name + "\x00" + assetId.Value)
+}
+
+// assetIds returns the asset ids indexed for an asset's globalAssetId and
+// specificAssetIds.
+func assetIds(globalAssetId *string, specificAssetIds []aas.SpecificAssetId) []aas.SpecificAssetId {
+	var ids []aas.SpecificAssetId
+	if globalAssetId != nil && *globalAssetId != "" {
+		ids = append(ids, aas.SpecificAssetId{Name: GlobalAssetIdName, Value: *globalAssetId})
+	}
+	return append(ids, specificAssetIds...)
+}
+
+// shellAssetIds returns the asset ids indexed for a stored shell.
+func shellAssetIds(shell *aas.AssetAdministrationShell) []aas.SpecificAssetId {
+	return assetIds(shell.AssetInformation.GlobalAssetId, shell.AssetInformation.SpecificAssetIds)
+}
+
+// descriptorAssetIds returns the asset ids indexed for a shell descriptor.
+func descriptorAssetIds(descriptor *aas.AssetAdministrationShellDescriptor) []aas.SpecificAssetId {
+	return assetIds(descriptor.GlobalAssetId, descriptor.SpecificAssetIds)
+}
+
+// execer runs statements with ? placeholders, i.e. a *sqldb.Tx or a
+// *sqldb.Conn.
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// indexAssetIds replaces the asset ids that source maps to aasID by ids
+// within tx. Duplicates are indexed once.
+func indexAssetIds(ctx context.Context, tx execer, source string, ownerID string, aasID string, ids []aas.SpecificAssetId) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM AssetIds WHERE aas_id_hash = ? AND source = ?", idHash(aasID), source); err != nil {
+		return err
+	}
+	seen := map[string]bool{}
+	for i := range ids {
+		hash := assetIdHash(&ids[i])
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		document, err := json.Marshal(&ids[i])
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO AssetIds (aas_id_hash, aas_id, source, asset_hash, owner_id, document) VALUES (?, ?, ?, ?, ?, ?)",
+			idHash(aasID), aasID, source, hash, ownerID, string(document)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reindexBatchSize is the number of documents ReindexAssetIds reads at once.
+const reindexBatchSize = 100
+
+// ReindexAssetIds rebuilds the asset ids that stored shells and shell
+// descriptors contribute to the lookup index. The migration that backfills
+// the index for documents stored before it existed runs it on conn.
+func ReindexAssetIds(ctx context.Context, conn *sqldb.Conn) error {
+	if err := reindexAssetIds(ctx, conn, "Shells", shellAssetIds); err != nil {
+		return err
+	}
+	return reindexAssetIds(ctx, conn, "ShellDescriptors", descriptorAssetIds)
+}
+
+// storedDocument is a row of a documentTable read for reindexing.
+type storedDocument struct {
+	hash, id, ownerID, document string
+}
+
+// reindexAssetIds indexes the asset ids of all documents in table. The
+// documents are read in batches, as conn cannot write while it reads.
+func reindexAssetIds[T any](ctx context.Context, conn *sqldb.Conn, table string, ids func(*T) []aas.SpecificAssetId) error {
+	cursor := ""
+	for {
+		batch, err := readDocumentBatch(ctx, conn, table, cursor)
+		if err != nil {
+			return err
+		}
+		for _, stored := range batch {
+			var v T
+			if err := json.Unmarshal([]byte(stored.document), &v); err != nil {
+				return fmt.Errorf("%s %s: %w", table, stored.id, err)
+			}
+			if err := indexAssetIds(ctx, conn, table, stored.ownerID, stored.id, ids(&v)); err != nil {
+				return err
+			}
+			cursor = stored.hash
+		}
+		if len(batch) < reindexBatchSize {
+			return nil
+		}
+	}
+}
+
+func readDocumentBatch(ctx context.Context, conn *sqldb.Conn, table string, cursor string) ([]storedDocument, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT id_hash, id, owner_id, document FROM "+table+" WHERE id_hash > ? ORDER BY id_hash LIMIT ?", cursor, reindexBatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var batch []storedDocument
+	for rows.Next() {
+		var stored storedDocument
+		if err := rows.Scan(&stored.hash, &stored.id, &stored.ownerID, &stored.document); err != nil {
+			return nil, err
+		}
+		batch = append(batch, stored)
+	}
+	return batch, rows.Err()
+}
+
+// AssetLinkRepository implements the lookup of the Discovery Service on the
+// lookup index and manages the asset ids linked explicitly.
+type AssetLinkRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
+}
+
+// LookupShells returns up to limit ids of shells that all of assetIds map to,
+// whatever their source, ordered by the hash of the shell id and starting
+// after cursor, and the cursor of the next page, which is empty on the last
+// page. Without assetIds all indexed shell ids are returned.
+func (repo *AssetLinkRepository) LookupShells(ctx context.Context, assetIds []aas.SpecificAssetId, cursor string, limit int) ([]string, string, error) {
+	query := "SELECT aas_id_hash, MIN(aas_id) FROM AssetIds WHERE aas_id_hash > ?"
+	args := []any{cursor}
+	hashes := map[string]bool{}
+	for i := range assetIds {
+		hashes[assetIdHash(&assetIds[i])] = true
+	}
+	if len(hashes) > 0 {
+		query += " AND asset_hash IN (" + strings.TrimSuffix(strings.Repeat("?, ", len(hashes)), ", ") + ")"
+		for hash := range hashes {
+			args = append(args, hash)
+		}
+	}
+	query += " GROUP BY aas_id_hash"
+	if len(hashes) > 0 {
+		query += " HAVING COUNT(DISTINCT asset_hash) = ?"
+		args = append(args, len(hashes))
+	}
+	query += " ORDER BY aas_id_hash LIMIT ?"
+	args = append(args, limit+1)
+
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	rows, err := repo.DB.QueryContext(queryCtx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	defer rows.Close()
+
+	ids := []string{}
+	next := ""
+	for rows.Next() {
+		var hash, id string
+		if err := rows.Scan(&hash, &id); err != nil {
+			return nil, "", err
+		}
+		if len(ids) == limit {
+			next = cursor
+			break
+		}
+		ids = append(ids, id)
+		cursor = hash
+	}
+	return ids, next, rows.Err()
+}
+
+// GetAssetLinks returns the asset ids that map to the shell with the given id,
+// whatever their source, or ErrIdentifiableNotFound if there are none.
+func (repo *AssetLinkRepository) GetAssetLinks(ctx context.Context, aasID string) ([]aas.SpecificAssetId, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	rows, err := repo.DB.QueryContext(queryCtx, "SELECT asset_hash, document FROM AssetIds WHERE aas_id_hash = ? ORDER BY source, asset_hash", idHash(aasID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []aas.SpecificAssetId{}
+	seen := map[string]bool{}
+	for rows.Next() {
+		var hash, document string
+		if err := rows.Scan(&hash, &document); err != nil {
+			return nil, err
+		}
+		if seen[hash] {
+			continue
+		}
+		seen[hash] = true
+		var id aas.SpecificAssetId
+		if err := json.Unmarshal([]byte(document), &id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrIdentifiableNotFound, aasID)
+	}
+	return ids, nil
+}
+
+// CreateAssetLinks replaces the asset ids linked explicitly to the shell with
+// the given id by assetIds. The links of a stored shell or shell descriptor
+// belong to its owner, those of other shell ids to the user who created them
+// first; ErrNotOwner is returned for everybody else.
+func (repo *AssetLinkRepository) CreateAssetLinks(ctx context.Context, ownerID string, aasID string, assetIds []aas.SpecificAssetId) error {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	tx, err := repo.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	owner, err := repo.linkOwner(queryCtx, tx, aasID)
+	if err != nil {
+		return err
+	}
+	if err := repo.checkLinkOwner(queryCtx, tx, ownerID, aasID, owner); err != nil {
+		return err
+	}
+	if err := indexAssetIds(queryCtx, tx, linkSource, ownerID, aasID, assetIds); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// DeleteAssetLinks removes the asset ids linked explicitly to the shell with
+// the given id, or returns ErrIdentifiableNotFound if there are none. Asset
+// ids of stored shells and descriptors are kept. Only the owner of the links,
+// as for CreateAssetLinks, may delete them.
+func (repo *AssetLinkRepository) DeleteAssetLinks(ctx context.Context, ownerID string, aasID string) error {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	tx, err := repo.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	owner, err := repo.linkOwner(queryCtx, tx, aasID)
+	if err != nil {
+		return err
+	}
+	if owner == "" {
+		return fmt.Errorf("%w: %s", ErrIdentifiableNotFound, aasID)
+	}
+	if err := repo.checkLinkOwner(queryCtx, tx, ownerID, aasID, owner); err != nil {
+		return err
+	}
+	if err := indexAssetIds(queryCtx, tx, linkSource, ownerID, aasID, nil); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// linkOwner returns the owner of the asset ids linked explicitly to aasID, or
+// an empty string if there are none.
+func (repo *AssetLinkRepository) linkOwner(ctx context.Context, tx *sqldb.Tx, aasID string) (string, error) {
+	var owner string
+	err := tx.QueryRowContext(ctx, "SELECT owner_id FROM AssetIds WHERE aas_id_hash = ? AND source = ?", idHash(aasID), linkSource).Scan(&owner)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	return owner, err
+}
+
+// checkLinkOwner returns ErrNotOwner unless ownerID owns the links of aasID,
+// which are currently owned by linkOwner, if anybody. The owner of a stored
+// shell or shell descriptor with that id owns its links, so that nobody else
+// can claim them first.
+func (repo *AssetLinkRepository) checkLinkOwner(ctx context.Context, tx *sqldb.Tx, ownerID string, aasID string, linkOwner string) error {
+	stored := false
+	for _, table := range []string{"Shells", "ShellDescriptors"} {
+		var owner string
+		err := tx.QueryRowContext(ctx, "SELECT owner_id FROM "+table+" WHERE id_hash = ?", idHash(aasID)).Scan(&owner)
+		if errors.Is(err, sql.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if owner != ownerID {
+			return ErrNotOwner
+		}
+		stored = true
+	}
+	if !stored && linkOwner != "" && linkOwner != ownerID {
+		return ErrNotOwner
+	}
+	return nil
+}
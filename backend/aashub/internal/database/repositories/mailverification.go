@@ -1,39 +1,48 @@
 package database
 
 import (
+	"context"
 	b64 "encoding/base64"
-	"os"
+	"log/slog"
 
 	mail "github.com/aas-hub-org/aashub/internal/mail"
 )
 
 type EmailVerificationRepository struct {
 	VerificationRepository *VerificationRepository
+	Mailer                 mail.Mailer
+	// ServerAddress is the public base URL the verification link points to.
+	ServerAddress string
 }
 
-func (e *EmailVerificationRepository) CreateVerification(email string) (string, error) {
-	verificationCode, err := e.VerificationRepository.CreateVerification(email)
+func (e *EmailVerificationRepository) CreateVerification(ctx context.Context, email string, locale string) (string, error) {
+	verificationCode, err := e.VerificationRepository.CreateVerification(ctx, email)
 	if err != nil {
 		return "", err
 	}
 
-	var server = os.Getenv("SERVER_ADDRESS")
-
 	encodedMail := b64.RawURLEncoding.EncodeToString([]byte(email))
 	encodedCode := b64.RawURLEncoding.EncodeToString([]byte(verificationCode))
 
-	link := server + "/verify?email=" + encodedMail + "&code=" + encodedCode
-	println(link)
-	sendMailError := mail.SendEmail(email, "Verification Code", "<a href='"+link+"'>Click here to verify your email</a>")
+	link := e.ServerAddress + "/verify?email=" + encodedMail + "&code=" + encodedCode
+	message, err := mail.Render(mail.TemplateVerification, locale, mail.VerificationData{Email: email, Link: link})
+	if err != nil {
+		return "", err
+	}
+	message.To = email
+
+	sendMailError := e.Mailer.Send(ctx, message)
 	if sendMailError != nil {
+		slog.ErrorContext(ctx, "Could not send verification e-mail", "error", sendMailError)
 		return "", sendMailError
 	}
+	slog.InfoContext(ctx, "Sent verification e-mail", "locale", locale)
 
 	return verificationCode, nil
 }
 
-func (e *EmailVerificationRepository) Verify(email string, verificationCode string) (string, error) {
-	if errtype, err := e.VerificationRepository.Verify(email, verificationCode); err != nil {
+func (e *EmailVerificationRepository) Verify(ctx context.Context, email string, verificationCode string) (string, error) {
+	if errtype, err := e.VerificationRepository.Verify(ctx, email, verificationCode); err != nil {
 		return errtype, err
 	}
 	return "", nil
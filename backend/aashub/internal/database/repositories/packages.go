@@ -0,0 +1,251 @@
+package database
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/aasx"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+	models "github.com/aas-hub-org/aashub/internal/models"
+
+	"github.com/google/uuid"
+)
+
+var ErrPackageNotFound = errors.New("package not found")
+var ErrIdentifiableExists = errors.New("an identifiable with the same id already exists")
+var ErrIdentifiableNotFound = errors.New("identifiable not found")
+var ErrPackageFileNotFound = errors.New("package file not found")
+
+type PackageRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero. An
+	// upload is stored in a single transaction bounded by the same timeout.
+	QueryTimeout time.Duration
+}
+
+// idHash is the key of shells and submodels, whose ids are too long to be
+// indexed directly.
+func idHash(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreatePackage stores an uploaded package together with the shells,
+// submodels and files extracted from it. Nothing is stored if one of the
+// shells or submodels already exists.
+func (repo *PackageRepository) CreatePackage(ctx context.Context, ownerID string, filename string, content []byte, pkg *aasx.Package) (*models.Package, error) {
+	stored := &models.Package{
+		ID:        uuid.New().String(),
+		OwnerID:   ownerID,
+		Filename:  filename,
+		Size:      int64(len(content)),
+		CreatedAt: time.Now().UTC(),
+	}
+
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	tx, err := repo.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	exec := func(query string, args ...any) error {
+		_, err := tx.ExecContext(queryCtx, query, args...)
+		if repo.DB.Dialect.IsDuplicateKey(err) {
+			return ErrIdentifiableExists
+		}
+		return err
+	}
+
+	if err := exec("INSERT INTO Packages (id, owner_id, filename, size, content, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+		stored.ID, ownerID, filename, stored.Size, content, stored.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	files := pkg.Supplementary
+	if pkg.Thumbnail != nil {
+		files = append([]aasx.File{*pkg.Thumbnail}, files...)
+	}
+	for i, file := range files {
+		thumbnail := pkg.Thumbnail != nil && i == 0
+		if err := exec("INSERT INTO PackageFiles (id, package_id, path, content_type, thumbnail, content) VALUES (?, ?, ?, ?, ?, ?)",
+			uuid.New().String(), stored.ID, file.Path, file.ContentType, thumbnail, file.Data); err != nil {
+			return nil, err
+		}
+		stored.Files = append(stored.Files, file.Path)
+	}
+
+	for _, shell := range pkg.Environment.AssetAdministrationShells {
+		document, err := json.Marshal(shell)
+		if err != nil {
+			return nil, err
+		}
+		if err := exec("INSERT INTO Shells (id_hash, id, id_short, owner_id, package_id, document, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+			idHash(shell.Id), shell.Id, shell.IdShort, ownerID, stored.ID, string(document), stored.CreatedAt, stored.CreatedAt); err != nil {
+			return nil, err
+		}
+		if err := indexAssetIds(queryCtx, tx, "Shells", ownerID, shell.Id, shellAssetIds(&shell)); err != nil {
+			return nil, err
+		}
+		stored.Shells = append(stored.Shells, shell.Id)
+	}
+
+	for _, submodel := range pkg.Environment.Submodels {
+		document, err := json.Marshal(submodel)
+		if err != nil {
+			return nil, err
+		}
+		if err := exec("INSERT INTO Submodels (id_hash, id, id_short, semantic_id, owner_id, package_id, document, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+			idHash(submodel.Id), submodel.Id, submodel.IdShort, semanticIdValue(submodel.SemanticId), ownerID, stored.ID, string(document), stored.CreatedAt, stored.CreatedAt); err != nil {
+			return nil, err
+		}
+		stored.Submodels = append(stored.Submodels, submodel.Id)
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "Error storing package", "error", err)
+		return nil, err
+	}
+	return stored, nil
+}
+
+// semanticIdValue returns the value of the first key of a semantic id, which
+// is what lookups by semantic id compare against.
+func semanticIdValue(ref *aas.Reference) *string {
+	if ref == nil || len(ref.Keys) == 0 {
+		return nil
+	}
+	return &ref.Keys[0].Value
+}
+
+// GetPackage returns the package with the given ID and the ids of the shells
+// and submodels still linked to it, or ErrPackageNotFound.
+func (repo *PackageRepository) GetPackage(ctx context.Context, id string) (*models.Package, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+
+	pkg := &models.Package{ID: id}
+	err := repo.DB.QueryRowContext(queryCtx, "SELECT owner_id, filename, size, created_at FROM Packages WHERE id = ?", id).
+		Scan(&pkg.OwnerID, &pkg.Filename, &pkg.Size, &pkg.CreatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPackageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if pkg.Shells, err = repo.strings(queryCtx, "SELECT id FROM Shells WHERE package_id = ? ORDER BY created_at, id_hash", id); err != nil {
+		return nil, err
+	}
+	if pkg.Submodels, err = repo.strings(queryCtx, "SELECT id FROM Submodels WHERE package_id = ? ORDER BY created_at, id_hash", id); err != nil {
+		return nil, err
+	}
+	if pkg.Files, err = repo.strings(queryCtx, "SELECT path FROM PackageFiles WHERE package_id = ? ORDER BY thumbnail DESC, path", id); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// GetPackageContent returns the package with the given ID together with the
+// AASX file as uploaded, or ErrPackageNotFound.
+func (repo *PackageRepository) GetPackageContent(ctx context.Context, id string) (*models.Package, []byte, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+
+	pkg := &models.Package{ID: id}
+	var content []byte
+	err := repo.DB.QueryRowContext(queryCtx, "SELECT owner_id, filename, size, created_at, content FROM Packages WHERE id = ?", id).
+		Scan(&pkg.OwnerID, &pkg.Filename, &pkg.Size, &pkg.CreatedAt, &content)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil, ErrPackageNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return pkg, content, nil
+}
+
+// GetPackageFile returns a supplementary file or the thumbnail of a package,
+// or ErrPackageFileNotFound.
+func (repo *PackageRepository) GetPackageFile(ctx context.Context, packageID string, path string) (*aasx.File, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+
+	file := &aasx.File{Path: path}
+	err := repo.DB.QueryRowContext(queryCtx, "SELECT content_type, content FROM PackageFiles WHERE package_id = ? AND path = ?", packageID, path).
+		Scan(&file.ContentType, &file.Data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrPackageFileNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return file, nil
+}
+
+// GetEnvironment loads the given shells and submodels into an environment,
+// in the order of the ids. A missing id is reported as
+// ErrIdentifiableNotFound.
+func (repo *PackageRepository) GetEnvironment(ctx context.Context, shellIDs []string, submodelIDs []string) (*aas.Environment, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+
+	env := &aas.Environment{}
+	for _, id := range shellIDs {
+		var shell aas.AssetAdministrationShell
+		if err := readDocument(queryCtx, repo.DB, "SELECT document FROM Shells WHERE id_hash = ?", id, &shell); err != nil {
+			return nil, err
+		}
+		env.AssetAdministrationShells = append(env.AssetAdministrationShells, shell)
+	}
+	for _, id := range submodelIDs {
+		var submodel aas.Submodel
+		if err := readDocument(queryCtx, repo.DB, "SELECT document FROM Submodels WHERE id_hash = ?", id, &submodel); err != nil {
+			return nil, err
+		}
+		env.Submodels = append(env.Submodels, submodel)
+	}
+	return env, nil
+}
+
+// readDocument decodes the JSON document of the identifiable with the given
+// id, selected by query.
+func readDocument(ctx context.Context, db *sqldb.DB, query string, id string, v any) error {
+	var document string
+	err := db.QueryRowContext(ctx, query, idHash(id)).Scan(&document)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%w: %s", ErrIdentifiableNotFound, id)
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(document), v)
+}
+
+// strings returns the single string column selected by query.
+func (repo *PackageRepository) strings(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := repo.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []string
+	for rows.Next() {
+		var value string
+		if err := rows.Scan(&value); err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
@@ -0,0 +1,117 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+)
+
+// ShellDescriptorRepository stores the shell descriptors of the AAS
+// Registry, including their submodel descriptors.
+type ShellDescriptorRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
+}
+
+func (repo *ShellDescriptorRepository) table() documentTable[aas.AssetAdministrationShellDescriptor] {
+	return documentTable[aas.AssetAdministrationShellDescriptor]{
+		db:      repo.DB,
+		timeout: repo.QueryTimeout,
+		name:    "ShellDescriptors",
+		columns: func(descriptor *aas.AssetAdministrationShellDescriptor) (string, []string, []any) {
+			return descriptor.Id, []string{"id_short", "asset_kind", "asset_type"}, []any{descriptor.IdShort, descriptor.AssetKind, descriptor.AssetType}
+		},
+		assetIds: descriptorAssetIds,
+	}
+}
+
+// ListShellDescriptors returns up to limit shell descriptors ordered by the
+// hash of their id, starting after cursor, and the cursor of the next page,
+// which is empty on the last page. Empty filters match all descriptors.
+func (repo *ShellDescriptorRepository) ListShellDescriptors(ctx context.Context, assetKind aas.AssetKind, assetType string, cursor string, limit int) ([]aas.AssetAdministrationShellDescriptor, string, error) {
+	var filters []filter
+	if assetKind != "" {
+		filters = append(filters, filter{column: "asset_kind", value: string(assetKind)})
+	}
+	if assetType != "" {
+		filters = append(filters, filter{column: "asset_type", value: assetType})
+	}
+	return repo.table().list(ctx, filters, cursor, limit)
+}
+
+// GetShellDescriptor returns the shell descriptor with the given id or
+// ErrIdentifiableNotFound.
+func (repo *ShellDescriptorRepository) GetShellDescriptor(ctx context.Context, id string) (*aas.AssetAdministrationShellDescriptor, error) {
+	return repo.table().get(ctx, id)
+}
+
+// CreateShellDescriptor registers a new shell descriptor owned by ownerID, or
+// fails with ErrIdentifiableExists.
+func (repo *ShellDescriptorRepository) CreateShellDescriptor(ctx context.Context, ownerID string, descriptor *aas.AssetAdministrationShellDescriptor) error {
+	return repo.table().create(ctx, ownerID, descriptor)
+}
+
+// UpdateShellDescriptor replaces the shell descriptor with the given id by
+// the result of update, like UpdateShell.
+func (repo *ShellDescriptorRepository) UpdateShellDescriptor(ctx context.Context, ownerID string, id string, update func(*aas.AssetAdministrationShellDescriptor) error) error {
+	return repo.table().update(ctx, ownerID, id, update)
+}
+
+// DeleteShellDescriptor removes the shell descriptor with the given id. Only
+// the owner may delete a descriptor.
+func (repo *ShellDescriptorRepository) DeleteShellDescriptor(ctx context.Context, ownerID string, id string) error {
+	return repo.table().delete(ctx, ownerID, id)
+}
+
+// SubmodelDescriptorRepository stores the descriptors of the Submodel
+// Registry, which are registered independently of shells.
+type SubmodelDescriptorRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
+}
+
+func (repo *SubmodelDescriptorRepository) table() documentTable[aas.SubmodelDescriptor] {
+	return documentTable[aas.SubmodelDescriptor]{
+		db:      repo.DB,
+		timeout: repo.QueryTimeout,
+		name:    "SubmodelDescriptors",
+		columns: func(descriptor *aas.SubmodelDescriptor) (string, []string, []any) {
+			return descriptor.Id, []string{"id_short", "semantic_id"}, []any{descriptor.IdShort, semanticIdValue(descriptor.SemanticId)}
+		},
+	}
+}
+
+// ListSubmodelDescriptors returns up to limit submodel descriptors ordered by
+// the hash of their id, starting after cursor, and the cursor of the next
+// page, which is empty on the last page.
+func (repo *SubmodelDescriptorRepository) ListSubmodelDescriptors(ctx context.Context, cursor string, limit int) ([]aas.SubmodelDescriptor, string, error) {
+	return repo.table().list(ctx, nil, cursor, limit)
+}
+
+// GetSubmodelDescriptor returns the submodel descriptor with the given id or
+// ErrIdentifiableNotFound.
+func (repo *SubmodelDescriptorRepository) GetSubmodelDescriptor(ctx context.Context, id string) (*aas.SubmodelDescriptor, error) {
+	return repo.table().get(ctx, id)
+}
+
+// CreateSubmodelDescriptor registers a new submodel descriptor owned by
+// ownerID, or fails with ErrIdentifiableExists.
+func (repo *SubmodelDescriptorRepository) CreateSubmodelDescriptor(ctx context.Context, ownerID string, descriptor *aas.SubmodelDescriptor) error {
+	return repo.table().create(ctx, ownerID, descriptor)
+}
+
+// UpdateSubmodelDescriptor replaces the submodel descriptor with the given id
+// by the result of update, like UpdateShell.
+func (repo *SubmodelDescriptorRepository) UpdateSubmodelDescriptor(ctx context.Context, ownerID string, id string, update func(*aas.SubmodelDescriptor) error) error {
+	return repo.table().update(ctx, ownerID, id, update)
+}
+
+// DeleteSubmodelDescriptor removes the submodel descriptor with the given id.
+// Only the owner may delete a descriptor.
+func (repo *SubmodelDescriptorRepository) DeleteSubmodelDescriptor(ctx context.Context, ownerID string, id string) error {
+	return repo.table().delete(ctx, ownerID, id)
+}
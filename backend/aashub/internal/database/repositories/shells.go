@@ -0,0 +1,66 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+)
+
+var ErrNotOwner = errors.New("the identifiable belongs to another user")
+
+type ShellRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
+}
+
+func (repo *ShellRepository) table() documentTable[aas.AssetAdministrationShell] {
+	return documentTable[aas.AssetAdministrationShell]{
+		db:      repo.DB,
+		timeout: repo.QueryTimeout,
+		name:    "Shells",
+		columns: func(shell *aas.AssetAdministrationShell) (string, []string, []any) {
+			return shell.Id, []string{"id_short"}, []any{shell.IdShort}
+		},
+		assetIds: shellAssetIds,
+	}
+}
+
+// ListShells returns up to limit shells ordered by the hash of their id,
+// starting after cursor, and the cursor of the next page, which is empty on
+// the last page. An empty idShort matches all shells.
+func (repo *ShellRepository) ListShells(ctx context.Context, idShort string, cursor string, limit int) ([]aas.AssetAdministrationShell, string, error) {
+	var filters []filter
+	if idShort != "" {
+		filters = append(filters, filter{column: "id_short", value: idShort})
+	}
+	return repo.table().list(ctx, filters, cursor, limit)
+}
+
+// GetShell returns the shell with the given id or ErrIdentifiableNotFound.
+func (repo *ShellRepository) GetShell(ctx context.Context, id string) (*aas.AssetAdministrationShell, error) {
+	return repo.table().get(ctx, id)
+}
+
+// CreateShell stores a new shell owned by ownerID, or fails with
+// ErrIdentifiableExists.
+func (repo *ShellRepository) CreateShell(ctx context.Context, ownerID string, shell *aas.AssetAdministrationShell) error {
+	return repo.table().create(ctx, ownerID, shell)
+}
+
+// UpdateShell replaces the shell with the given id by the result of update,
+// which is called with the stored shell and must keep its id. Only the owner
+// may update a shell; ErrNotOwner is returned for everybody else. Errors of
+// update are returned as is.
+func (repo *ShellRepository) UpdateShell(ctx context.Context, ownerID string, id string, update func(*aas.AssetAdministrationShell) error) error {
+	return repo.table().update(ctx, ownerID, id, update)
+}
+
+// DeleteShell removes the shell with the given id. Only the owner may delete
+// a shell.
+func (repo *ShellRepository) DeleteShell(ctx context.Context, ownerID string, id string) error {
+	return repo.table().delete(ctx, ownerID, id)
+}
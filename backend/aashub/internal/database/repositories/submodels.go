@@ -0,0 +1,65 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
+)
+
+type SubmodelRepository struct {
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
+}
+
+func (repo *SubmodelRepository) table() documentTable[aas.Submodel] {
+	return documentTable[aas.Submodel]{
+		db:      repo.DB,
+		timeout: repo.QueryTimeout,
+		name:    "Submodels",
+		columns: func(submodel *aas.Submodel) (string, []string, []any) {
+			return submodel.Id, []string{"id_short", "semantic_id"}, []any{submodel.IdShort, semanticIdValue(submodel.SemanticId)}
+		},
+	}
+}
+
+// ListSubmodels returns up to limit submodels ordered by the hash of their
+// id, starting after cursor, and the cursor of the next page, which is empty
+// on the last page. Empty filters match all submodels; semanticId is compared
+// with the value of the first key of the submodels' semantic ids.
+func (repo *SubmodelRepository) ListSubmodels(ctx context.Context, idShort string, semanticId string, cursor string, limit int) ([]aas.Submodel, string, error) {
+	var filters []filter
+	if idShort != "" {
+		filters = append(filters, filter{column: "id_short", value: idShort})
+	}
+	if semanticId != "" {
+		filters = append(filters, filter{column: "semantic_id", value: semanticId})
+	}
+	return repo.table().list(ctx, filters, cursor, limit)
+}
+
+// GetSubmodel returns the submodel with the given id or
+// ErrIdentifiableNotFound.
+func (repo *SubmodelRepository) GetSubmodel(ctx context.Context, id string) (*aas.Submodel, error) {
+	return repo.table().get(ctx, id)
+}
+
+// CreateSubmodel stores a new submodel owned by ownerID, or fails with
+// ErrIdentifiableExists.
+func (repo *SubmodelRepository) CreateSubmodel(ctx context.Context, ownerID string, submodel *aas.Submodel) error {
+	return repo.table().create(ctx, ownerID, submodel)
+}
+
+// UpdateSubmodel replaces the submodel with the given id by the result of
+// update, like UpdateShell.
+func (repo *SubmodelRepository) UpdateSubmodel(ctx context.Context, ownerID string, id string, update func(*aas.Submodel) error) error {
+	return repo.table().update(ctx, ownerID, id, update)
+}
+
+// DeleteSubmodel removes the submodel with the given id. Only the owner may
+// delete a submodel.
+func (repo *SubmodelRepository) DeleteSubmodel(ctx context.Context, ownerID string, id string) error {
+	return repo.table().delete(ctx, ownerID, id)
+}
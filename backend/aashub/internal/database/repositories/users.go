@@ -1,30 +1,37 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"errors"
-	"log"
+	"log/slog"
+	"strings"
+	"time"
 
 	auth "github.com/aas-hub-org/aashub/internal/auth"
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
 	interfaces "github.com/aas-hub-org/aashub/internal/interfaces"
-	utils "github.com/aas-hub-org/aashub/internal/utils"
+	mail "github.com/aas-hub-org/aashub/internal/mail"
+	models "github.com/aas-hub-org/aashub/internal/models"
 
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var ErrUserRepoNotFound = errors.New("identifier or password wrong")
+var ErrUserRepoExists = errors.New("username or email already registered")
+var ErrUserNotFound = errors.New("user not found")
 
-type UserRepository struct {
-	DB                     *sql.DB
-	VerificationRepository interfaces.VerificationRepositoryInterface
-}
+// userColumns lists the Users columns in the order scanUser reads them.
+const userColumns = "id, username, email, password_hash, locale, created_at, updated_at, last_login_at"
 
-type User struct {
-	ID       string
-	Username string
-	Email    string
-	Password string
+type UserRepository struct {
+	DB                     *sqldb.DB
+	VerificationRepository interfaces.VerificationMailerInterface
+	// JWTSecret signs the session tokens issued on login.
+	JWTSecret string
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
 }
 
 func HashPassword(password string) (string, error) {
@@ -35,54 +42,266 @@ func HashPassword(password string) (string, error) {
 	return string(bytes), nil
 }
 
-func (repo *UserRepository) RegisterUser(username string, email string, password string) error {
-	userid := uuid.New().String()
-	hashedpassword, err := HashPassword(password)
+// RegisterUser stores a new user and sends the verification e-mail in the
+// user's locale, which may be a locale tag or an Accept-Language value.
+func (repo *UserRepository) RegisterUser(ctx context.Context, username string, email string, password string, locale string) error {
+	user, err := repo.CreateUser(ctx, username, email, password, locale)
 	if err != nil {
-		log.Fatalf("Error hashing password: %v", err)
 		return err
 	}
-	_, err = repo.DB.Exec("INSERT INTO Users (id, username, email, password_hash) VALUES (?, ?, ?, ?)", userid, username, email, hashedpassword)
+
+	_, err = repo.VerificationRepository.CreateVerification(ctx, email, user.Locale)
 
 	if err != nil {
-		log.Fatalf("Error inserting user: %v", err)
+		slog.ErrorContext(ctx, "Error inserting verification", "error", err)
 		return err
 	}
 
-	_, err = repo.VerificationRepository.CreateVerification(email)
+	return nil
+}
 
+// CreateUser stores a new user without starting the e-mail verification.
+func (repo *UserRepository) CreateUser(ctx context.Context, username string, email string, password string, locale string) (*models.User, error) {
+	hashedpassword, err := HashPassword(password)
 	if err != nil {
-		log.Fatalf("Error inserting verification: %v", err)
-		return err
+		slog.ErrorContext(ctx, "Error hashing password", "error", err)
+		return nil, err
 	}
 
-	return nil
-}
+	now := time.Now().UTC()
+	user := &models.User{
+		ID:           uuid.New().String(),
+		Username:     username,
+		Email:        email,
+		PasswordHash: hashedpassword,
+		Locale:       mail.ResolveLocale(locale),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
 
-func (repo *UserRepository) LoginUser(identifier string, password string) (string, error) {
-	// Changed the error message to 'identifier' to generalize username/email
-	var user User
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	_, err = repo.DB.ExecContext(queryCtx, "INSERT INTO Users (id, username, email, password_hash, locale, created_at, updated_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		user.ID, user.Username, user.Email, user.PasswordHash, user.Locale, user.CreatedAt, user.UpdatedAt)
 
-	// Adjust the SQL query to check both the username and email fields
-	err := repo.DB.QueryRow("SELECT * FROM Users WHERE username = ? OR email = ?", identifier, identifier).Scan(&user.ID, &user.Username, &user.Email, &user.Password)
+	if repo.DB.Dialect.IsDuplicateKey(err) {
+		return nil, ErrUserRepoExists
+	}
 	if err != nil {
+		slog.ErrorContext(ctx, "Error inserting user", "error", err)
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (repo *UserRepository) LoginUser(ctx context.Context, identifier string, password string) (string, error) {
+	// Check both the username and email fields
+	user, err := repo.getUser(ctx, "username = ? OR email = ?", identifier, identifier)
+	if errors.Is(err, ErrUserNotFound) {
 		return "", ErrUserRepoNotFound
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+	if err != nil {
+		slog.ErrorContext(ctx, "Error querying user", "error", err)
+		return "", err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		return "", ErrUserRepoNotFound
 	}
-	secret, fileReadError := utils.ReadFile("/workspace/backend/aashub/privatekey.txt")
+	jwt, err := auth.GenerateJWT(user.ID, repo.JWTSecret)
+	if err != nil {
+		slog.ErrorContext(ctx, "Error generating JWT", "error", err)
+		return "", err
+	}
 
-	if fileReadError != nil {
-		log.Fatalf("Error reading file: %v", fileReadError)
-		return "", fileReadError
+	// The login time is only bookkeeping, so failing to record it does not
+	// reject valid credentials
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	if _, err := repo.DB.ExecContext(queryCtx, "UPDATE Users SET last_login_at = ? WHERE id = ?", time.Now().UTC(), user.ID); err != nil {
+		slog.ErrorContext(ctx, "Error recording login", "user_id", user.ID, "error", err)
 	}
 
-	jwt, err := auth.GenerateJWT(user.ID, secret)
+	return jwt, nil
+}
+
+func (repo *UserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return repo.getUser(ctx, "id = ?", id)
+}
+
+func (repo *UserRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return repo.getUser(ctx, "username = ?", username)
+}
+
+func (repo *UserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return repo.getUser(ctx, "email = ?", email)
+}
+
+// getUser returns the single user matching the WHERE condition, or
+// ErrUserNotFound.
+func (repo *UserRepository) getUser(ctx context.Context, condition string, args ...any) (*models.User, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	user, err := scanUser(repo.DB.QueryRowContext(queryCtx, "SELECT "+userColumns+" FROM Users WHERE "+condition, args...))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+// scanUser maps a row selected with userColumns to a User.
+func scanUser(row interface{ Scan(dest ...any) error }) (*models.User, error) {
+	var user models.User
+	var lastLogin sql.NullTime
+	err := row.Scan(&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.Locale, &user.CreatedAt, &user.UpdatedAt, &lastLogin)
 	if err != nil {
-		log.Fatalf("Error generating JWT: %v", err)
-		return "", err
+		return nil, err
+	}
+	if lastLogin.Valid {
+		user.LastLoginAt = &lastLogin.Time
 	}
+	return &user, nil
+}
 
-	return jwt, nil
+// SetPassword replaces the password of the user with the given ID.
+func (repo *UserRepository) SetPassword(ctx context.Context, id string, password string) error {
+	hashedpassword, err := HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	result, err := repo.DB.ExecContext(queryCtx, "UPDATE Users SET password_hash = ?, updated_at = ? WHERE id = ?", hashedpassword, time.Now().UTC(), id)
+	if err != nil {
+		return err
+	}
+	return expectAffected(result)
+}
+
+// GrantRole assigns a role to the user with the given ID. Granting a role
+// twice is not an error.
+func (repo *UserRepository) GrantRole(ctx context.Context, id string, role string) error {
+	if _, err := repo.GetByID(ctx, id); err != nil {
+		return err
+	}
+
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	_, err := repo.DB.ExecContext(queryCtx, "INSERT INTO UserRoles (user_id, role) VALUES (?, ?)", id, role)
+	if repo.DB.Dialect.IsDuplicateKey(err) {
+		return nil
+	}
+	return err
+}
+
+// Roles returns the roles of the user with the given ID in alphabetical order.
+func (repo *UserRepository) Roles(ctx context.Context, id string) ([]string, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	rows, err := repo.DB.QueryContext(queryCtx, "SELECT role FROM UserRoles WHERE user_id = ? ORDER BY role", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var roles []string
+	for rows.Next() {
+		var role string
+		if err := rows.Scan(&role); err != nil {
+			return nil, err
+		}
+		roles = append(roles, role)
+	}
+	return roles, rows.Err()
+}
+
+// ListUnverified returns all users that have not confirmed their e-mail
+// address yet, oldest first.
+func (repo *UserRepository) ListUnverified(ctx context.Context) ([]models.User, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+	rows, err := repo.DB.QueryContext(queryCtx, "SELECT "+prefixColumns("u", userColumns)+" FROM Users u JOIN Verifications v ON v.email = u.email WHERE v.verified = ? ORDER BY u.created_at", false)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []models.User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, *user)
+	}
+	return users, rows.Err()
+}
+
+// DeleteStaleRegistrations removes users that registered before the given
+// time and never verified their e-mail address, and returns how many were
+// removed.
+func (repo *UserRepository) DeleteStaleRegistrations(ctx context.Context, registeredBefore time.Time) (int64, error) {
+	queryCtx, cancel := queryContext(ctx, repo.QueryTimeout)
+	defer cancel()
+
+	const stale = "SELECT u.email FROM Users u JOIN Verifications v ON v.email = u.email WHERE v.verified = ? AND u.created_at < ?"
+	tx, err := repo.DB.BeginTx(queryCtx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(queryCtx, stale, false, registeredBefore.UTC())
+	if err != nil {
+		return 0, err
+	}
+	var emails []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		emails = append(emails, email)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	for _, email := range emails {
+		if _, err := tx.ExecContext(queryCtx, "DELETE FROM Verifications WHERE email = ?", email); err != nil {
+			return 0, err
+		}
+		if _, err := tx.ExecContext(queryCtx, "DELETE FROM Users WHERE email = ?", email); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+	return int64(len(emails)), nil
+}
+
+// prefixColumns qualifies every column of a comma separated list with alias.
+func prefixColumns(alias string, columns string) string {
+	parts := strings.Split(columns, ", ")
+	for i, column := range parts {
+		parts[i] = alias + "." + column
+	}
+	return strings.Join(parts, ", ")
+}
+
+// expectAffected turns an update that matched no row into ErrUserNotFound.
+func expectAffected(result sql.Result) error {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
 }
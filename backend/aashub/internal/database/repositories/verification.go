@@ -1,16 +1,21 @@
 package database
 
 import (
+	"context"
+	"database/sql"
 	"errors"
-	"log"
+	"log/slog"
 
-	"database/sql"
 	"math/rand"
 	"time"
+
+	sqldb "github.com/aas-hub-org/aashub/internal/database"
 )
 
 type VerificationRepository struct {
-	DB *sql.DB
+	DB *sqldb.DB
+	// QueryTimeout bounds every statement, DefaultQueryTimeout if zero.
+	QueryTimeout time.Duration
 }
 
 func GenerateVerificationCode(length int) string {
@@ -26,16 +31,13 @@ func GenerateVerificationCode(length int) string {
 	return string(result)
 }
 
-func (v *VerificationRepository) CreateVerification(email string) (string, error) {
+func (v *VerificationRepository) CreateVerification(ctx context.Context, email string) (string, error) {
 	verificationCode := GenerateVerificationCode(6)
 
-	_, err := v.DB.Exec(`
-		INSERT INTO Verifications (email, verification_code, verified) 
-		VALUES (?, ?, ?) 
-		ON DUPLICATE KEY UPDATE 
-			verification_code = VALUES(verification_code), 
-			verified = VALUES(verified)`,
-		email, verificationCode, false)
+	queryCtx, cancel := queryContext(ctx, v.QueryTimeout)
+	defer cancel()
+	upsert := v.DB.Dialect.Upsert("Verifications", []string{"email", "verification_code", "verified"}, []string{"email"})
+	_, err := v.DB.ExecContext(queryCtx, upsert, email, verificationCode, false)
 	if err != nil {
 		return "", err
 	}
@@ -43,27 +45,37 @@ func (v *VerificationRepository) CreateVerification(email string) (string, error
 	return verificationCode, nil
 }
 
-func (v *VerificationRepository) Verify(email string, verificationCode string) (string, error) {
-	log.Printf("Verifying email %s with code %s", email, verificationCode)
-
-	result, select_err := v.DB.Query("SELECT * FROM Verifications WHERE email = ? AND verification_code = ? AND verified = ?", email, verificationCode, false)
+func (v *VerificationRepository) Verify(ctx context.Context, email string, verificationCode string) (string, error) {
+	queryCtx, cancel := queryContext(ctx, v.QueryTimeout)
+	defer cancel()
+	var pendingEmail string
+	select_err := v.DB.QueryRowContext(queryCtx, "SELECT email FROM Verifications WHERE email = ? AND verification_code = ? AND verified = ?", email, verificationCode, false).Scan(&pendingEmail)
 
+	if errors.Is(select_err, sql.ErrNoRows) {
+		return "user", errors.New("invalid verification code")
+	}
 	if select_err != nil {
-		log.Fatalf(select_err.Error())
+		slog.ErrorContext(ctx, "Could not look up verification", "error", select_err)
 		return "system", select_err
 	}
 
-	defer result.Close()
-
-	if !result.Next() {
-		return "user", errors.New("invalid verification code")
-	}
-
-	_, err := v.DB.Exec("UPDATE Verifications SET verified = ? WHERE email = ? AND verification_code = ?", true, email, verificationCode)
+	updateCtx, cancelUpdate := queryContext(ctx, v.QueryTimeout)
+	defer cancelUpdate()
+	_, err := v.DB.ExecContext(updateCtx, "UPDATE Verifications SET verified = ? WHERE email = ? AND verification_code = ?", true, email, verificationCode)
 	if err != nil {
-		log.Fatalf(err.Error())
+		slog.ErrorContext(ctx, "Could not mark e-mail as verified", "error", err)
 		return "system", err
 	}
 
 	return "", nil
 }
+
+// MarkVerified confirms the e-mail address without a verification code, e.g.
+// for accounts created by an administrator.
+func (v *VerificationRepository) MarkVerified(ctx context.Context, email string) error {
+	queryCtx, cancel := queryContext(ctx, v.QueryTimeout)
+	defer cancel()
+	upsert := v.DB.Dialect.Upsert("Verifications", []string{"email", "verification_code", "verified"}, []string{"email"})
+	_, err := v.DB.ExecContext(queryCtx, upsert, email, GenerateVerificationCode(6), true)
+	return err
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Check reports whether one dependency of the hub is usable.
+type Check func(ctx context.Context) error
+
+// Status values used in a Report.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+	StatusDraining    = "draining"
+)
+
+// DefaultTimeout bounds every check when the Checker has no Timeout.
+const DefaultTimeout = 2 * time.Second
+
+// Checker runs the readiness checks of the hub. Once Drain has been called it
+// reports the hub as not ready, so load balancers stop sending new requests
+// while the in-flight ones finish.
+type Checker struct {
+	Checks  map[string]Check
+	Timeout time.Duration
+
+	draining atomic.Bool
+}
+
+// Report is the result of a readiness check.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// CheckResult is the outcome of a single check. The reason of a failure is
+// only logged, as the report is served to unauthenticated callers.
+type CheckResult struct {
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+}
+
+// Ready reports whether Status is StatusOK.
+func (r Report) Ready() bool {
+	return r.Status == StatusOK
+}
+
+// Drain marks the hub as shutting down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+// Run executes all checks concurrently and collects their results.
+func (c *Checker) Run(ctx context.Context) Report {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	report := Report{Status: StatusOK, Checks: make(map[string]CheckResult, len(c.Checks))}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range c.Checks {
+		wg.Add(1)
+		go func(name string, check Check) {
+			defer wg.Done()
+			checkCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := check(checkCtx)
+			result := CheckResult{Status: StatusOK, Duration: time.Since(start).Round(time.Millisecond).String()}
+			if err != nil {
+				slog.WarnContext(ctx, "Readiness check failed", "check", name, "error", err)
+				result.Status = StatusUnavailable
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[name] = result
+			if err != nil {
+				report.Status = StatusUnavailable
+			}
+		}(name, check)
+	}
+	wg.Wait()
+
+	if c.draining.Load() {
+		report.Status = StatusDraining
+	}
+	return report
+}
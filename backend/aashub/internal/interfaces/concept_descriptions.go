@@ -0,0 +1,16 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+type ConceptDescriptionRepositoryInterface interface {
+	ListConceptDescriptions(ctx context.Context, idShort string, isCaseOf *aas.Reference, dataSpecificationRef *aas.Reference, cursor string, limit int) ([]aas.ConceptDescription, string, error)
+	GetConceptDescription(ctx context.Context, id string) (*aas.ConceptDescription, error)
+	GetConceptDescriptions(ctx context.Context, ids []string) ([]aas.ConceptDescription, error)
+	CreateConceptDescription(ctx context.Context, ownerID string, cd *aas.ConceptDescription) error
+	UpdateConceptDescription(ctx context.Context, ownerID string, id string, update func(*aas.ConceptDescription) error) error
+	DeleteConceptDescription(ctx context.Context, ownerID string, id string) error
+}
@@ -0,0 +1,14 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+type AssetLinkRepositoryInterface interface {
+	LookupShells(ctx context.Context, assetIds []aas.SpecificAssetId, cursor string, limit int) ([]string, string, error)
+	GetAssetLinks(ctx context.Context, aasID string) ([]aas.SpecificAssetId, error)
+	CreateAssetLinks(ctx context.Context, ownerID string, aasID string, assetIds []aas.SpecificAssetId) error
+	DeleteAssetLinks(ctx context.Context, ownerID string, aasID string) error
+}
@@ -0,0 +1,17 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/aasx"
+	models "github.com/aas-hub-org/aashub/internal/models"
+)
+
+type PackageRepositoryInterface interface {
+	CreatePackage(ctx context.Context, ownerID string, filename string, content []byte, pkg *aasx.Package) (*models.Package, error)
+	GetPackage(ctx context.Context, id string) (*models.Package, error)
+	GetPackageContent(ctx context.Context, id string) (*models.Package, []byte, error)
+	GetPackageFile(ctx context.Context, packageID string, path string) (*aasx.File, error)
+	GetEnvironment(ctx context.Context, shellIDs []string, submodelIDs []string) (*aas.Environment, error)
+}
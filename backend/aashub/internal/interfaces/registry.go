@@ -0,0 +1,23 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+type ShellDescriptorRepositoryInterface interface {
+	ListShellDescriptors(ctx context.Context, assetKind aas.AssetKind, assetType string, cursor string, limit int) ([]aas.AssetAdministrationShellDescriptor, string, error)
+	GetShellDescriptor(ctx context.Context, id string) (*aas.AssetAdministrationShellDescriptor, error)
+	CreateShellDescriptor(ctx context.Context, ownerID string, descriptor *aas.AssetAdministrationShellDescriptor) error
+	UpdateShellDescriptor(ctx context.Context, ownerID string, id string, update func(*aas.AssetAdministrationShellDescriptor) error) error
+	DeleteShellDescriptor(ctx context.Context, ownerID string, id string) error
+}
+
+type SubmodelDescriptorRepositoryInterface interface {
+	ListSubmodelDescriptors(ctx context.Context, cursor string, limit int) ([]aas.SubmodelDescriptor, string, error)
+	GetSubmodelDescriptor(ctx context.Context, id string) (*aas.SubmodelDescriptor, error)
+	CreateSubmodelDescriptor(ctx context.Context, ownerID string, descriptor *aas.SubmodelDescriptor) error
+	UpdateSubmodelDescriptor(ctx context.Context, ownerID string, id string, update func(*aas.SubmodelDescriptor) error) error
+	DeleteSubmodelDescriptor(ctx context.Context, ownerID string, id string) error
+}
@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+type ShellRepositoryInterface interface {
+	ListShells(ctx context.Context, idShort string, cursor string, limit int) ([]aas.AssetAdministrationShell, string, error)
+	GetShell(ctx context.Context, id string) (*aas.AssetAdministrationShell, error)
+	CreateShell(ctx context.Context, ownerID string, shell *aas.AssetAdministrationShell) error
+	UpdateShell(ctx context.Context, ownerID string, id string, update func(*aas.AssetAdministrationShell) error) error
+	DeleteShell(ctx context.Context, ownerID string, id string) error
+}
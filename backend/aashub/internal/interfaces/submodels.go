@@ -0,0 +1,15 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+)
+
+type SubmodelRepositoryInterface interface {
+	ListSubmodels(ctx context.Context, idShort string, semanticId string, cursor string, limit int) ([]aas.Submodel, string, error)
+	GetSubmodel(ctx context.Context, id string) (*aas.Submodel, error)
+	CreateSubmodel(ctx context.Context, ownerID string, submodel *aas.Submodel) error
+	UpdateSubmodel(ctx context.Context, ownerID string, id string, update func(*aas.Submodel) error) error
+	DeleteSubmodel(ctx context.Context, ownerID string, id string) error
+}
@@ -1,6 +1,15 @@
 package interfaces
 
+import (
+	"context"
+
+	models "github.com/aas-hub-org/aashub/internal/models"
+)
+
 type UserRepositoryInterface interface {
-	RegisterUser(username string, email string, password string) error
-	LoginUser(username string, password string) (string, error)
+	RegisterUser(ctx context.Context, username string, email string, password string, locale string) error
+	LoginUser(ctx context.Context, username string, password string) (string, error)
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	GetByUsername(ctx context.Context, username string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
 }
@@ -1,6 +1,14 @@
 package interfaces
 
+import "context"
+
 type VerificationRepositoryInterface interface {
-	CreateVerification(email string) (string, error)
-	Verify(email string, verificationCode string) (string, error)
+	CreateVerification(ctx context.Context, email string) (string, error)
+	Verify(ctx context.Context, email string, verificationCode string) (string, error)
+}
+
+// VerificationMailerInterface creates a verification and sends its link to
+// the user in the given locale.
+type VerificationMailerInterface interface {
+	CreateVerification(ctx context.Context, email string, locale string) (string, error)
 }
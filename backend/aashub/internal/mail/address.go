@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	netmail "net/mail"
+	"strings"
+)
+
+var ErrInvalidAddress = errors.New("invalid e-mail address")
+
+// ParseAddress parses a single RFC 5322 address such as "a@example.com" or
+// "AAS Hub <a@example.com>". Lists, group syntax and line breaks are
+// rejected.
+func ParseAddress(address string) (*netmail.Address, error) {
+	if strings.ContainsAny(address, "\r\n") {
+		return nil, fmt.Errorf("%w: contains line break", ErrInvalidAddress)
+	}
+	parsed, err := netmail.ParseAddress(address)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidAddress, err)
+	}
+	return parsed, nil
+}
+
+// ValidateAddress checks that address is a bare address without display
+// name, as expected for user supplied e-mail addresses.
+func ValidateAddress(address string) error {
+	parsed, err := ParseAddress(address)
+	if err != nil {
+		return err
+	}
+	if parsed.Name != "" || parsed.Address != address {
+		return fmt.Errorf("%w: %q is not a bare address", ErrInvalidAddress, address)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FileMailer writes every e-mail into a maildir below Dir instead of sending
+// it. It is meant for local development.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m *FileMailer) Send(ctx context.Context, message *Message) error {
+	// The caller's message is left untouched so it can be sent again
+	copied := *message
+	message = &copied
+	if message.From == "" {
+		message.From = m.From
+	}
+	data, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+
+	if err := m.createMaildir(); err != nil {
+		return err
+	}
+
+	// Write to tmp first and move into new so readers never see partial files
+	name := strconv.FormatInt(time.Now().UnixNano(), 10) + "." + uuid.New().String() + ".eml"
+	tmpPath := filepath.Join(m.Dir, "tmp", name)
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	if err := os.Rename(tmpPath, filepath.Join(m.Dir, "new", name)); err != nil {
+		return fmt.Errorf("error delivering message: %w", err)
+	}
+
+	return nil
+}
+
+// Ping makes sure the maildir exists and can be written to.
+func (m *FileMailer) Ping(ctx context.Context) error {
+	if err := m.createMaildir(); err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Join(m.Dir, "tmp"), "ping-*")
+	if err != nil {
+		return fmt.Errorf("maildir is not writable: %w", err)
+	}
+	file.Close()
+	return os.Remove(file.Name())
+}
+
+func (m *FileMailer) createMaildir() error {
+	for _, sub := range []string{"tmp", "new", "cur"} {
+		if err := os.MkdirAll(filepath.Join(m.Dir, sub), 0o755); err != nil {
+			return fmt.Errorf("error creating maildir: %w", err)
+		}
+	}
+	return nil
+}
@@ -1,94 +1,77 @@
 package mail
 
 import (
-	"crypto/tls"
-	"log"
-	"net/smtp"
-	"os"
+	"context"
+	"fmt"
+	"strings"
 
-	"github.com/joho/godotenv"
+	"github.com/aas-hub-org/aashub/internal/config"
 )
 
-func SendEmail(to, subject, body string) error {
-	log.Printf("Sending email to %s", to)
-	// Load .env
-	env_err := godotenv.Load("/workspace/backend/aashub/.env")
-	if env_err != nil {
-		log.Fatalf("Error loading .env file")
-	}
-
-	// Get from .env
-	from := os.Getenv("MAIL_ADDRESS")
-	pass := os.Getenv("MAIL_PASSWORD")
-
-	// SMTP server configuration.
-	smtpHost := os.Getenv("MAIL_SMTP")
-	smtpPort := os.Getenv("SMTP_PORT")
-
-	// Message.
-	message := []byte("From: " + from + "\n" +
-		"To: " + to + "\n" +
-		"Subject: " + subject + "\n" +
-		"Content-Type: text/html; charset=\"UTF-8\"\n\n" +
-		body)
-
-	// TLS configuration
-	tlsconfig := &tls.Config{
-		InsecureSkipVerify: true,
-		ServerName:         smtpHost,
-	}
-
-	// Connect to the SMTP Server
-	conn, err := tls.Dial("tcp", smtpHost+":"+smtpPort, tlsconfig)
-	if err != nil {
-		log.Fatalf("Error connecting to SMTP server: %v", err)
-		return err
-	}
+// Mailer delivers e-mails. Implementations are selected at startup so that
+// repositories never depend on a concrete transport. A Mailer fills in the
+// sender if the message has none.
+type Mailer interface {
+	Send(ctx context.Context, message *Message) error
+}
 
-	client, err := smtp.NewClient(conn, smtpHost)
-	if err != nil {
-		log.Fatalf("Error creating SMTP client: %v", err)
-		return err
-	}
+// Pinger is implemented by mailers that can check whether their transport is
+// reachable without sending an e-mail.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
 
-	// Authentication
-	auth := smtp.PlainAuth("", from, pass, smtpHost)
-	if err = client.Auth(auth); err != nil {
-		log.Fatalf("Error authenticating: %v", err)
-		return err
+// Ping checks the transport of mailer. Mailers without a check are always
+// considered reachable.
+func Ping(ctx context.Context, mailer Mailer) error {
+	if pinger, ok := mailer.(Pinger); ok {
+		return pinger.Ping(ctx)
 	}
+	return nil
+}
 
-	// To && From
-	if err = client.Mail(from); err != nil {
-		log.Fatalf("Error setting sender: %v", err)
-		return err
-	}
-	if err = client.Rcpt(to); err != nil {
-		log.Fatalf("Error setting recipient: %v", err)
-		return err
-	}
+// Transport names accepted in the mail configuration.
+const (
+	TransportTLS      = "tls"
+	TransportSTARTTLS = "starttls"
+	TransportPlain    = "plain"
+	TransportFile     = "file"
+	TransportMemory   = "memory"
+)
 
-	// Data
-	w, err := client.Data()
-	if err != nil {
-		log.Fatalf("Error getting SMTP data writer: %v", err)
-		return err
+// NewMailer builds the Mailer selected by the mail configuration.
+func NewMailer(cfg config.MailConfig) (Mailer, error) {
+	switch strings.ToLower(cfg.Transport) {
+	case TransportFile:
+		return &FileMailer{Dir: cfg.Dir, From: cfg.Address}, nil
+	case TransportMemory:
+		return &MemoryMailer{From: cfg.Address}, nil
 	}
 
-	_, err = w.Write(message)
-	if err != nil {
-		log.Fatalf("Error writing message: %v", err)
-		return err
+	security := SecurityTLS
+	switch strings.ToLower(cfg.Transport) {
+	case "", TransportTLS:
+	case TransportSTARTTLS:
+		security = SecuritySTARTTLS
+	case TransportPlain:
+		security = SecurityNone
+	default:
+		return nil, fmt.Errorf("unknown mail transport %q", cfg.Transport)
 	}
 
-	err = w.Close()
-	if err != nil {
-		log.Fatalf("Error closing SMTP data writer: %v", err)
-		return err
+	// Most providers use the sender address as login
+	username := cfg.Username
+	if username == "" {
+		username = cfg.Address
 	}
 
-	client.Quit()
-
-	log.Printf("Email Sent Successfully!")
-	return nil
+	return &SMTPMailer{
+		Host:               cfg.Host,
+		Port:               cfg.Port,
+		Username:           username,
+		Password:           cfg.Password,
+		From:               cfg.Address,
+		Security:           security,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}, nil
 }
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryMailer records e-mails instead of sending them. It is meant for tests
+// and rejects the same malformed messages the other transports do.
+type MemoryMailer struct {
+	From string
+
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (m *MemoryMailer) Send(ctx context.Context, message *Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	recorded := *message
+	if recorded.From == "" {
+		recorded.From = m.From
+	}
+	if _, err := recorded.Bytes(); err != nil {
+		return err
+	}
+	m.messages = append(m.messages, recorded)
+	return nil
+}
+
+// Messages returns a copy of all recorded e-mails in the order they were sent.
+func (m *MemoryMailer) Messages() []Message {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return append([]Message(nil), m.messages...)
+}
+
+// Reset discards all recorded e-mails.
+func (m *MemoryMailer) Reset() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = nil
+}
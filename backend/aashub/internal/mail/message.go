@@ -0,0 +1,154 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	netmail "net/mail"
+	"net/textproto"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a single e-mail as handed to a Mailer. Text and HTML are sent as
+// alternatives of each other; at least one of them must be set.
+type Message struct {
+	From      string
+	To        string
+	Subject   string
+	Text      string
+	HTML      string
+	Date      time.Time
+	MessageID string
+}
+
+// Bytes renders the message as MIME including its headers. A missing Date or
+// Message-ID is filled in. Sender and recipient must be valid RFC 5322
+// addresses; header values can never contain line breaks.
+func (m *Message) Bytes() ([]byte, error) {
+	from, to, err := m.addresses()
+	if err != nil {
+		return nil, err
+	}
+	if m.Text == "" && m.HTML == "" {
+		return nil, fmt.Errorf("message to %s has no body", to.Address)
+	}
+	if m.Date.IsZero() {
+		m.Date = time.Now()
+	}
+	if m.MessageID == "" {
+		m.MessageID = newMessageID(from.Address)
+	}
+
+	var buf bytes.Buffer
+	headers := headerWriter{buf: &buf}
+	headers.write("From", from.String())
+	headers.write("To", to.String())
+	headers.write("Subject", mime.QEncoding.Encode("utf-8", m.Subject))
+	headers.write("Date", m.Date.Format(time.RFC1123Z))
+	headers.write("Message-ID", m.MessageID)
+	headers.write("MIME-Version", "1.0")
+	if headers.err != nil {
+		return nil, headers.err
+	}
+
+	// Single-part message
+	if m.Text == "" || m.HTML == "" {
+		contentType, body := "text/plain; charset=\"UTF-8\"", m.Text
+		if m.HTML != "" {
+			contentType, body = "text/html; charset=\"UTF-8\"", m.HTML
+		}
+		headers.write("Content-Type", contentType)
+		headers.write("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	// Multipart message, plain text first so clients prefer the HTML part
+	writer := multipart.NewWriter(&buf)
+	headers.write("Content-Type", mime.FormatMediaType("multipart/alternative", map[string]string{"boundary": writer.Boundary()}))
+	buf.WriteString("\r\n")
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=\"UTF-8\"", m.Text},
+		{"text/html; charset=\"UTF-8\"", m.HTML},
+	} {
+		w, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := writeQuotedPrintable(w, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Envelope returns the bare sender and recipient addresses for the SMTP
+// envelope.
+func (m *Message) Envelope() (from, to string, err error) {
+	fromAddress, toAddress, err := m.addresses()
+	if err != nil {
+		return "", "", err
+	}
+	return fromAddress.Address, toAddress.Address, nil
+}
+
+func (m *Message) addresses() (*netmail.Address, *netmail.Address, error) {
+	from, err := ParseAddress(m.From)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid sender: %w", err)
+	}
+	to, err := ParseAddress(m.To)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid recipient: %w", err)
+	}
+	return from, to, nil
+}
+
+// headerWriter writes header fields and remembers the first invalid one.
+type headerWriter struct {
+	buf *bytes.Buffer
+	err error
+}
+
+func (h *headerWriter) write(name, value string) {
+	if h.err != nil {
+		return
+	}
+	if strings.ContainsAny(value, "\r\n") {
+		h.err = fmt.Errorf("header %s contains a line break", name)
+		return
+	}
+	h.buf.WriteString(name + ": " + value + "\r\n")
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	qp := quotedprintable.NewWriter(w)
+	if _, err := qp.Write([]byte(body)); err != nil {
+		return err
+	}
+	return qp.Close()
+}
+
+// newMessageID builds a globally unique Message-ID below the sender's domain.
+func newMessageID(from string) string {
+	domain := "aashub.localhost"
+	if at := strings.LastIndex(from, "@"); at >= 0 && at < len(from)-1 {
+		domain = from[at+1:]
+	}
+	return "<" + uuid.New().String() + "@" + domain + ">"
+}
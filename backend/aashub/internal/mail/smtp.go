@@ -0,0 +1,168 @@
+package mail
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+// Security describes how the connection to the SMTP server is protected.
+type Security int
+
+const (
+	// SecurityTLS connects with implicit TLS (SMTPS, usually port 465).
+	SecurityTLS Security = iota
+	// SecuritySTARTTLS upgrades a plain connection (usually port 587).
+	SecuritySTARTTLS
+	// SecurityNone talks plain SMTP, e.g. to a local relay on port 25.
+	SecurityNone
+)
+
+const defaultSMTPTimeout = 30 * time.Second
+
+var ErrSTARTTLSUnsupported = errors.New("smtp server does not support STARTTLS")
+
+// SMTPMailer delivers e-mails through an SMTP server. Server certificates are
+// verified unless InsecureSkipVerify is set.
+type SMTPMailer struct {
+	Host               string
+	Port               string
+	Username           string
+	Password           string
+	From               string
+	Security           Security
+	InsecureSkipVerify bool
+	Timeout            time.Duration
+}
+
+func (m *SMTPMailer) Send(ctx context.Context, message *Message) error {
+	// The caller's message is left untouched so it can be sent again
+	copied := *message
+	message = &copied
+	if message.From == "" {
+		message.From = m.From
+	}
+	// Validate the message before connecting
+	data, err := message.Bytes()
+	if err != nil {
+		return err
+	}
+	from, to, err := message.Envelope()
+	if err != nil {
+		return err
+	}
+
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// Authentication
+	if m.Username != "" {
+		if ok, _ := client.Extension("AUTH"); ok {
+			auth := smtp.PlainAuth("", m.Username, m.Password, m.Host)
+			if err := client.Auth(auth); err != nil {
+				return fmt.Errorf("error authenticating: %w", err)
+			}
+		}
+	}
+
+	// To && From
+	if err := client.Mail(from); err != nil {
+		return fmt.Errorf("error setting sender: %w", err)
+	}
+	if err := client.Rcpt(to); err != nil {
+		return fmt.Errorf("error setting recipient: %w", err)
+	}
+
+	// Data
+	w, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("error getting SMTP data writer: %w", err)
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("error writing message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return fmt.Errorf("error closing SMTP data writer: %w", err)
+	}
+
+	if err := client.Quit(); err != nil {
+		return fmt.Errorf("error closing SMTP session: %w", err)
+	}
+
+	slog.DebugContext(ctx, "Sent e-mail", "to", to, "message_id", message.MessageID)
+	return nil
+}
+
+// Ping connects to the server, negotiates TLS and ends the session again
+// without sending anything.
+func (m *SMTPMailer) Ping(ctx context.Context) error {
+	client, err := m.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.Quit()
+}
+
+// dial connects to the server and negotiates TLS according to m.Security.
+// The whole session must finish within m.Timeout or the deadline of ctx,
+// whichever is earlier.
+func (m *SMTPMailer) dial(ctx context.Context) (*smtp.Client, error) {
+	timeout := m.Timeout
+	if timeout == 0 {
+		timeout = defaultSMTPTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	dialer := &net.Dialer{Deadline: deadline}
+	addr := net.JoinHostPort(m.Host, m.Port)
+
+	tlsConfig := &tls.Config{
+		ServerName:         m.Host,
+		InsecureSkipVerify: m.InsecureSkipVerify,
+	}
+
+	var conn net.Conn
+	var err error
+	if m.Security == SecurityTLS {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: tlsConfig}).DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to SMTP server: %w", err)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	client, err := smtp.NewClient(conn, m.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error creating SMTP client: %w", err)
+	}
+
+	if m.Security == SecuritySTARTTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			client.Close()
+			return nil, ErrSTARTTLSUnsupported
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("error starting TLS: %w", err)
+		}
+	}
+
+	return client, nil
+}
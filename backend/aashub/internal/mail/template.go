@@ -0,0 +1,108 @@
+package mail
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+)
+
+// Template names
+const (
+	TemplateVerification  = "verification"
+	TemplatePasswordReset = "password_reset"
+	TemplateNotification  = "notification"
+)
+
+// DefaultLocale is used whenever a requested locale has no templates.
+const DefaultLocale = "en"
+
+// Locales lists the locales templates are available for.
+var Locales = []string{"en", "de"}
+
+//go:embed templates
+var templateFS embed.FS
+
+// VerificationData is passed to the verification template.
+type VerificationData struct {
+	Email string
+	Link  string
+}
+
+// PasswordResetData is passed to the password reset template.
+type PasswordResetData struct {
+	Username string
+	Link     string
+}
+
+// NotificationData is passed to the notification template.
+type NotificationData struct {
+	Username string
+	Title    string
+	Message  string
+	Link     string
+}
+
+type localizedTemplate struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+// templates maps locale and template name to the parsed templates.
+var templates = mustParseTemplates()
+
+func mustParseTemplates() map[string]map[string]localizedTemplate {
+	parsed := make(map[string]map[string]localizedTemplate)
+	for _, locale := range Locales {
+		parsed[locale] = make(map[string]localizedTemplate)
+		for _, name := range []string{TemplateVerification, TemplatePasswordReset, TemplateNotification} {
+			base := "templates/" + locale + "/" + name
+			parsed[locale][name] = localizedTemplate{
+				text: texttemplate.Must(texttemplate.ParseFS(templateFS, base+".txt")),
+				html: htmltemplate.Must(htmltemplate.ParseFS(templateFS, base+".html")),
+			}
+		}
+	}
+	return parsed
+}
+
+// ResolveLocale picks the best supported locale for a locale tag or an
+// Accept-Language header value, falling back to DefaultLocale.
+func ResolveLocale(value string) string {
+	for _, entry := range strings.Split(value, ",") {
+		tag := strings.TrimSpace(strings.SplitN(entry, ";", 2)[0])
+		tag = strings.ToLower(strings.SplitN(strings.ReplaceAll(tag, "_", "-"), "-", 2)[0])
+		if _, ok := templates[tag]; ok {
+			return tag
+		}
+	}
+	return DefaultLocale
+}
+
+// Render fills the named template in the given locale and returns a message
+// with subject, plain text and HTML body set.
+func Render(name, locale string, data any) (*Message, error) {
+	tmpl, ok := templates[ResolveLocale(locale)][name]
+	if !ok {
+		return nil, fmt.Errorf("unknown mail template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := tmpl.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("error rendering subject of %q: %w", name, err)
+	}
+	if err := tmpl.text.ExecuteTemplate(&text, "text", data); err != nil {
+		return nil, fmt.Errorf("error rendering text of %q: %w", name, err)
+	}
+	if err := tmpl.html.ExecuteTemplate(&html, "html", data); err != nil {
+		return nil, fmt.Errorf("error rendering html of %q: %w", name, err)
+	}
+
+	return &Message{
+		Subject: strings.TrimSpace(subject.String()),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
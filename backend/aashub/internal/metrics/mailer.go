@@ -0,0 +1,24 @@
+package metrics
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/mail"
+)
+
+// Mailer counts the outcome of every e-mail sent through the wrapped Mailer.
+type Mailer struct {
+	Mailer  mail.Mailer
+	Metrics *Metrics
+}
+
+func (m *Mailer) Send(ctx context.Context, message *mail.Message) error {
+	err := m.Mailer.Send(ctx, message)
+	m.Metrics.MailSent(err)
+	return err
+}
+
+// Ping checks the wrapped Mailer's transport.
+func (m *Mailer) Ping(ctx context.Context) error {
+	return mail.Ping(ctx, m.Mailer)
+}
@@ -0,0 +1,170 @@
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Outcomes recorded by the login, registration, verification and mail
+// counters.
+const (
+	ResultSuccess  = "success"
+	ResultFailure  = "failure"
+	ResultInvalid  = "invalid"
+	ResultConflict = "conflict"
+	ResultError    = "error"
+)
+
+// unmatchedRoute labels requests that did not match any route, so that
+// arbitrary paths cannot create new time series.
+const unmatchedRoute = "unmatched"
+
+// Metrics holds the Prometheus collectors of the hub. All methods may be
+// called on a nil *Metrics, which records nothing, so components can be used
+// without metrics in tests.
+type Metrics struct {
+	Registry *prometheus.Registry
+
+	requests      *prometheus.CounterVec
+	duration      *prometheus.HistogramVec
+	logins        *prometheus.CounterVec
+	registrations *prometheus.CounterVec
+	verifications *prometheus.CounterVec
+	mails         *prometheus.CounterVec
+}
+
+// New creates the collectors and registers them, together with the Go runtime
+// and process collectors, in a new registry.
+func New() *Metrics {
+	m := &Metrics{
+		Registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "aashub",
+			Name:      "http_requests_total",
+			Help:      "HTTP requests by method, route and status code.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "aashub",
+			Name:      "http_request_duration_seconds",
+			Help:      "HTTP request latencies by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "aashub",
+			Name:      "logins_total",
+			Help:      "Login attempts by result (success, failure, error).",
+		}, []string{"result"}),
+		registrations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "aashub",
+			Name:      "registrations_total",
+			Help:      "Registrations by result (success, invalid, conflict, error).",
+		}, []string{"result"}),
+		verifications: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "aashub",
+			Name:      "verifications_total",
+			Help:      "E-mail verifications by result (success, invalid, error).",
+		}, []string{"result"}),
+		mails: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "aashub",
+			Name:      "mails_sent_total",
+			Help:      "E-mails handed to the mail transport by result (success, failure).",
+		}, []string{"result"}),
+	}
+
+	// Export every known outcome from the start so rates work before the
+	// first event
+	for _, result := range []string{ResultSuccess, ResultFailure, ResultError} {
+		m.logins.WithLabelValues(result)
+	}
+	for _, result := range []string{ResultSuccess, ResultInvalid, ResultConflict, ResultError} {
+		m.registrations.WithLabelValues(result)
+	}
+	for _, result := range []string{ResultSuccess, ResultInvalid, ResultError} {
+		m.verifications.WithLabelValues(result)
+	}
+	for _, result := range []string{ResultSuccess, ResultFailure} {
+		m.mails.WithLabelValues(result)
+	}
+
+	m.Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requests,
+		m.duration,
+		m.logins,
+		m.registrations,
+		m.verifications,
+		m.mails,
+	)
+	return m
+}
+
+// RegisterDB exports the connection pool statistics of db.
+func (m *Metrics) RegisterDB(db *sql.DB, name string) error {
+	if m == nil {
+		return nil
+	}
+	return m.Registry.Register(collectors.NewDBStatsCollector(db, name))
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.Registry, promhttp.HandlerOpts{Registry: m.Registry})
+}
+
+// Middleware records the count and latency of every request by its route
+// pattern rather than its path, e.g. /api/v1/verify/ instead of the full URL.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		labels := []string{c.Request.Method, route, strconv.Itoa(c.Writer.Status())}
+		m.requests.WithLabelValues(labels...).Inc()
+		m.duration.WithLabelValues(labels...).Observe(time.Since(start).Seconds())
+	}
+}
+
+// Login records the outcome of a login attempt.
+func (m *Metrics) Login(result string) {
+	if m != nil {
+		m.logins.WithLabelValues(result).Inc()
+	}
+}
+
+// Registration records the outcome of a registration.
+func (m *Metrics) Registration(result string) {
+	if m != nil {
+		m.registrations.WithLabelValues(result).Inc()
+	}
+}
+
+// Verification records the outcome of an e-mail verification.
+func (m *Metrics) Verification(result string) {
+	if m != nil {
+		m.verifications.WithLabelValues(result).Inc()
+	}
+}
+
+// MailSent records whether an e-mail was accepted by the mail transport.
+func (m *Metrics) MailSent(err error) {
+	if m == nil {
+		return
+	}
+	if err != nil {
+		m.mails.WithLabelValues(ResultFailure).Inc()
+		return
+	}
+	m.mails.WithLabelValues(ResultSuccess).Inc()
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// Package is an uploaded AASX package as stored in the Packages table.
+type Package struct {
+	ID        string
+	OwnerID   string
+	Filename  string
+	Size      int64
+	CreatedAt time.Time
+	// Shells and Submodels list the ids of the identifiables extracted from
+	// the package.
+	Shells    []string
+	Submodels []string
+	// Files lists the paths of the supplementary files and the thumbnail.
+	Files []string
+}
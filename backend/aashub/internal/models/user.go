@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// User is a registered account as stored in the Users table.
+type User struct {
+	ID           string
+	Username     string
+	Email        string
+	PasswordHash string
+	Locale       string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	// LastLoginAt is nil until the user logs in for the first time.
+	LastLoginAt *time.Time
+}
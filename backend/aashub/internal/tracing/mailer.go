@@ -0,0 +1,35 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/aas-hub-org/aashub/internal/mail"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the spans created by this package.
+const instrumentationName = "github.com/aas-hub-org/aashub/internal/tracing"
+
+// Mailer records a span for every e-mail sent through the wrapped Mailer.
+type Mailer struct {
+	Mailer mail.Mailer
+}
+
+func (m *Mailer) Send(ctx context.Context, message *mail.Message) error {
+	ctx, span := otel.Tracer(instrumentationName).Start(ctx, "mail.send", trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	err := m.Mailer.Send(ctx, message)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, "sending e-mail failed")
+	}
+	return err
+}
+
+// Ping checks the wrapped Mailer's transport.
+func (m *Mailer) Ping(ctx context.Context) error {
+	return mail.Ping(ctx, m.Mailer)
+}
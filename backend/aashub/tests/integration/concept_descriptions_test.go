@@ -0,0 +1,99 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// TestConceptDescriptionRepository covers the filters by isCaseOf and data
+// specification, which match one of several references of a concept
+// description.
+func TestConceptDescriptionRepository(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.ConceptDescriptionRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+
+	global := func(value string) aas.Reference {
+		return aas.Reference{Type: aas.ReferenceTypesExternalReference, Keys: []aas.Key{{Type: aas.KeyTypesGlobalReference, Value: value}}}
+	}
+	iec61360 := global("https://admin-shell.io/DataSpecificationTemplates/DataSpecificationIec61360/3")
+	idShort := "ConceptDescriptionRepoTest"
+	var ids []string
+	for i := 0; i < 3; i++ {
+		cd := &aas.ConceptDescription{
+			Identifiable: aas.Identifiable{Referable: aas.Referable{IdShort: &idShort}, Id: fmt.Sprintf("urn:example:cd:cd-repo-test:%d", i)},
+			IsCaseOf:     []aas.Reference{global("urn:example:eclass:common"), global(fmt.Sprintf("urn:example:eclass:%d", i))},
+		}
+		if i > 0 {
+			cd.EmbeddedDataSpecifications = []aas.EmbeddedDataSpecification{{
+				DataSpecification:        iec61360,
+				DataSpecificationContent: &aas.DataSpecificationIec61360{PreferredName: []aas.LangString{{Language: "en", Text: "Test"}}},
+			}}
+		}
+		if err := repo.CreateConceptDescription(ctx, ownerID, cd); err != nil {
+			t.Fatalf("Failed to create concept description: %v", err)
+		}
+		ids = append(ids, cd.Id)
+		defer database.ExecContext(ctx, "DELETE FROM ConceptDescriptions WHERE id = ?", cd.Id)
+	}
+
+	count := func(isCaseOf, dataSpecification *aas.Reference) int {
+		cds, _, err := repo.ListConceptDescriptions(ctx, idShort, isCaseOf, dataSpecification, "", 10)
+		if err != nil {
+			t.Fatalf("Failed to list concept descriptions: %v", err)
+		}
+		return len(cds)
+	}
+	common, second := global("urn:example:eclass:common"), global("urn:example:eclass:1")
+	if n := count(&common, nil); n != 3 {
+		t.Errorf("Expected 3 cases of the common reference, got %d", n)
+	}
+	if n := count(&second, nil); n != 1 {
+		t.Errorf("Expected 1 case of the second reference, got %d", n)
+	}
+	if n := count(nil, &iec61360); n != 2 {
+		t.Errorf("Expected 2 concept descriptions with IEC 61360 content, got %d", n)
+	}
+	if n := count(&second, &iec61360); n != 1 {
+		t.Errorf("Expected both filters to apply, got %d", n)
+	}
+
+	err := repo.UpdateConceptDescription(ctx, ownerID, ids[1], func(cd *aas.ConceptDescription) error {
+		cd.IsCaseOf = nil
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update concept description: %v", err)
+	}
+	if n := count(&second, nil); n != 0 {
+		t.Errorf("Expected the update to remove the case, got %d", n)
+	}
+
+	// Several concept descriptions are read at once, in the order asked for
+	cds, err := repo.GetConceptDescriptions(ctx, []string{ids[2], "urn:example:cd:unknown", ids[0], ids[2]})
+	if err != nil {
+		t.Fatalf("Failed to get concept descriptions: %v", err)
+	}
+	if len(cds) != 2 || cds[0].Id != ids[2] || cds[1].Id != ids[0] {
+		t.Errorf("Expected %s and %s, got %v", ids[2], ids[0], cds)
+	}
+
+	if err := repo.DeleteConceptDescription(ctx, "someone-else", ids[0]); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := repo.DeleteConceptDescription(ctx, ownerID, ids[0]); err != nil {
+		t.Errorf("Failed to delete concept description: %v", err)
+	}
+	if _, err := repo.GetConceptDescription(ctx, ids[0]); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+}
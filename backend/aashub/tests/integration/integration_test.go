@@ -0,0 +1,83 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	db "github.com/aas-hub-org/aashub/internal/database"
+	"github.com/aas-hub-org/aashub/internal/database/migrations"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/aas-hub-org/aashub/internal/mail"
+)
+
+// testUserPasswordHash is the bcrypt hash of "test", matching mysql/init.sql.
+const testUserPasswordHash = "$2a$12$mGYv8a1151X6gMXRnhldoeptpSWreQqZGM94NgGxNsYHbrm0HQbuK"
+
+// setupDatabase connects to the test database, applies the migrations and
+// seeds the test user.
+func setupDatabase(t *testing.T) *db.DB {
+	t.Helper()
+
+	database := openDatabase(t)
+
+	migrator, err := migrations.NewMigrator(database)
+	if err != nil {
+		t.Fatalf("Could not load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Could not migrate the database: %v", err)
+	}
+
+	var count int
+	if err := database.QueryRowContext(context.Background(), "SELECT COUNT(*) FROM Users WHERE username = ?", "test").Scan(&count); err != nil {
+		t.Fatalf("Could not look up the test user: %v", err)
+	}
+	if count == 0 {
+		_, err := database.ExecContext(context.Background(), "INSERT INTO Users (id, username, email, password_hash) VALUES (?, ?, ?, ?)",
+			"23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9", "test", "test@test.de", testUserPasswordHash)
+		if err != nil {
+			t.Fatalf("Could not seed the test user: %v", err)
+		}
+	}
+
+	return database
+}
+
+// openDatabase connects to the database selected by DB_DRIVER and
+// DATABASE_DSN without migrating it. Without DB_DRIVER every test gets a
+// fresh SQLite file, so no database server is needed.
+func openDatabase(t *testing.T) *db.DB {
+	t.Helper()
+
+	cfg := config.Default().Database
+	if driver := os.Getenv("DB_DRIVER"); driver != "" {
+		cfg.Driver = driver
+		if dsn := os.Getenv("DATABASE_DSN"); dsn != "" {
+			cfg.DSN = dsn
+		}
+	} else {
+		cfg.Driver = "sqlite"
+		cfg.DSN = filepath.Join(t.TempDir(), "aashub.db")
+	}
+
+	database, err := db.NewDB(cfg)
+	if err != nil {
+		t.Fatalf("Could not connect to the database: %v", err)
+	}
+	return database
+}
+
+// verificationMailer sends the verification e-mails of repo to memory.
+func verificationMailer(repo *repositories.VerificationRepository) *repositories.EmailVerificationRepository {
+	return &repositories.EmailVerificationRepository{
+		VerificationRepository: repo,
+		Mailer:                 &mail.MemoryMailer{From: "noreply@example.com"},
+		ServerAddress:          "http://localhost:9000",
+	}
+}
@@ -0,0 +1,253 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/database/migrations"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// TestAssetLinkRepository covers the lookup index, which is fed by stored
+// shells, shell descriptors and explicit links alike.
+func TestAssetLinkRepository(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.AssetLinkRepository{DB: database}
+	shells := &repositories.ShellRepository{DB: database}
+	descriptors := &repositories.ShellDescriptorRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+	const shellID = "urn:example:aas:lookup-test:shell"
+	const descriptorID = "urn:example:aas:lookup-test:descriptor"
+	const linkedID = "urn:example:aas:lookup-test:linked"
+	defer database.ExecContext(ctx, "DELETE FROM Shells WHERE id = ?", shellID)
+	defer database.ExecContext(ctx, "DELETE FROM ShellDescriptors WHERE id = ?", descriptorID)
+	defer database.ExecContext(ctx, "DELETE FROM AssetIds WHERE aas_id IN (?, ?, ?)", shellID, descriptorID, linkedID)
+
+	manufacturer := aas.SpecificAssetId{Name: "manufacturer", Value: "ACME lookup-test"}
+	serial := func(value string) aas.SpecificAssetId { return aas.SpecificAssetId{Name: "serialNumber", Value: value} }
+	globalAssetId := "urn:example:asset:lookup-test"
+	shell := &aas.AssetAdministrationShell{
+		Identifiable: aas.Identifiable{Id: shellID},
+		AssetInformation: aas.AssetInformation{
+			AssetKind:        aas.AssetKindInstance,
+			GlobalAssetId:    &globalAssetId,
+			SpecificAssetIds: []aas.SpecificAssetId{manufacturer, serial("1")},
+		},
+	}
+	if err := shells.CreateShell(ctx, ownerID, shell); err != nil {
+		t.Fatalf("Failed to create shell: %v", err)
+	}
+	descriptor := &aas.AssetAdministrationShellDescriptor{Id: descriptorID, SpecificAssetIds: []aas.SpecificAssetId{manufacturer, serial("2")}}
+	if err := descriptors.CreateShellDescriptor(ctx, ownerID, descriptor); err != nil {
+		t.Fatalf("Failed to create shell descriptor: %v", err)
+	}
+	if err := repo.CreateAssetLinks(ctx, ownerID, linkedID, []aas.SpecificAssetId{manufacturer, serial("3")}); err != nil {
+		t.Fatalf("Failed to link asset ids: %v", err)
+	}
+
+	lookup := func(assetIds ...aas.SpecificAssetId) []string {
+		t.Helper()
+		ids, next, err := repo.LookupShells(ctx, assetIds, "", 10)
+		if err != nil || next != "" {
+			t.Fatalf("Failed to look up shells: %v (cursor %q)", err, next)
+		}
+		return ids
+	}
+	if ids := lookup(manufacturer); len(ids) != 3 {
+		t.Errorf("Expected the shell, the descriptor and the link for the manufacturer, got %v", ids)
+	}
+	if ids := lookup(manufacturer, serial("2")); !reflect.DeepEqual(ids, []string{descriptorID}) {
+		t.Errorf("Expected only the descriptor, got %v", ids)
+	}
+	if ids := lookup(aas.SpecificAssetId{Name: repositories.GlobalAssetIdName, Value: globalAssetId}); !reflect.DeepEqual(ids, []string{shellID}) {
+		t.Errorf("Expected the shell by its globalAssetId, got %v", ids)
+	}
+	ids, next, err := repo.LookupShells(ctx, []aas.SpecificAssetId{manufacturer}, "", 2)
+	if err != nil || len(ids) != 2 || next == "" {
+		t.Fatalf("Expected a first page of two shell ids, got %v, cursor %q (%v)", ids, next, err)
+	}
+	if ids, _, err = repo.LookupShells(ctx, []aas.SpecificAssetId{manufacturer}, next, 2); err != nil || len(ids) != 1 {
+		t.Errorf("Expected a last page of one shell id, got %v (%v)", ids, err)
+	}
+
+	// Updates and deletions of the documents keep the index in sync.
+	err = shells.UpdateShell(ctx, ownerID, shellID, func(shell *aas.AssetAdministrationShell) error {
+		shell.AssetInformation.SpecificAssetIds = []aas.SpecificAssetId{serial("4")}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update shell: %v", err)
+	}
+	if ids := lookup(serial("1")); len(ids) != 0 {
+		t.Errorf("Expected the replaced serial number to be gone, got %v", ids)
+	}
+	if ids := lookup(serial("4")); !reflect.DeepEqual(ids, []string{shellID}) {
+		t.Errorf("Expected the new serial number, got %v", ids)
+	}
+	if err := descriptors.DeleteShellDescriptor(ctx, ownerID, descriptorID); err != nil {
+		t.Fatalf("Failed to delete shell descriptor: %v", err)
+	}
+	if ids := lookup(serial("2")); len(ids) != 0 {
+		t.Errorf("Expected the deleted descriptor's asset ids to be gone, got %v", ids)
+	}
+
+	// Explicit links add to the asset ids of a stored shell.
+	if err := repo.CreateAssetLinks(ctx, ownerID, shellID, []aas.SpecificAssetId{serial("5")}); err != nil {
+		t.Fatalf("Failed to link asset ids: %v", err)
+	}
+	links, err := repo.GetAssetLinks(ctx, shellID)
+	if err != nil || len(links) != 3 {
+		t.Errorf("Expected the globalAssetId, the serial number and the link, got %v (%v)", links, err)
+	}
+	if err := repo.CreateAssetLinks(ctx, "someone-else", shellID, nil); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := repo.DeleteAssetLinks(ctx, ownerID, shellID); err != nil {
+		t.Errorf("Failed to unlink asset ids: %v", err)
+	}
+	if err := repo.DeleteAssetLinks(ctx, ownerID, shellID); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+	if links, err := repo.GetAssetLinks(ctx, shellID); err != nil || len(links) != 2 {
+		t.Errorf("Expected the stored shell's asset ids to be kept, got %v (%v)", links, err)
+	}
+	if _, err := repo.GetAssetLinks(ctx, "urn:example:aas:lookup-test:unknown"); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+}
+
+// TestAssetLinkRepository_StoredShellOwner expects only the owner of a stored
+// shell to link asset ids to its id, even if somebody else linked it first.
+func TestAssetLinkRepository_StoredShellOwner(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.AssetLinkRepository{DB: database}
+	shells := &repositories.ShellRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+	const otherID = "6f1c2f7e-4a8b-4d2e-9a51-3c0b7e9d1a42"
+	const shellID = "urn:example:aas:lookup-owner-test:shell"
+	const squattedID = "urn:example:aas:lookup-owner-test:squatted"
+	defer database.ExecContext(ctx, "DELETE FROM Users WHERE id = ?", otherID)
+	defer database.ExecContext(ctx, "DELETE FROM Shells WHERE id IN (?, ?)", shellID, squattedID)
+	defer database.ExecContext(ctx, "DELETE FROM AssetIds WHERE aas_id IN (?, ?)", shellID, squattedID)
+	if _, err := database.ExecContext(ctx, "INSERT INTO Users (id, username, email, password_hash) VALUES (?, ?, ?, ?)",
+		otherID, "other", "other@test.de", testUserPasswordHash); err != nil {
+		t.Fatalf("Could not seed the other user: %v", err)
+	}
+
+	serial := aas.SpecificAssetId{Name: "serialNumber", Value: "lookup-owner-test"}
+	newShell := func(id string) *aas.AssetAdministrationShell {
+		return &aas.AssetAdministrationShell{
+			Identifiable:     aas.Identifiable{Id: id},
+			AssetInformation: aas.AssetInformation{AssetKind: aas.AssetKindInstance},
+		}
+	}
+	if err := shells.CreateShell(ctx, ownerID, newShell(shellID)); err != nil {
+		t.Fatalf("Failed to create shell: %v", err)
+	}
+	if err := repo.CreateAssetLinks(ctx, otherID, shellID, []aas.SpecificAssetId{serial}); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner for another user's shell, got %v", err)
+	}
+	if err := repo.CreateAssetLinks(ctx, ownerID, shellID, []aas.SpecificAssetId{serial}); err != nil {
+		t.Errorf("Failed to link asset ids to the own shell: %v", err)
+	}
+
+	// Links created before the shell was stored pass to the shell's owner.
+	if err := repo.CreateAssetLinks(ctx, otherID, squattedID, []aas.SpecificAssetId{serial}); err != nil {
+		t.Fatalf("Failed to link asset ids: %v", err)
+	}
+	if err := shells.CreateShell(ctx, ownerID, newShell(squattedID)); err != nil {
+		t.Fatalf("Failed to create shell: %v", err)
+	}
+	if err := repo.CreateAssetLinks(ctx, otherID, squattedID, nil); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner for another user's shell, got %v", err)
+	}
+	if err := repo.DeleteAssetLinks(ctx, otherID, squattedID); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner for another user's shell, got %v", err)
+	}
+	if err := repo.DeleteAssetLinks(ctx, ownerID, squattedID); err != nil {
+		t.Errorf("Failed to unlink asset ids of the own shell: %v", err)
+	}
+}
+
+// TestAssetIdsBackfill stores a shell and a shell descriptor before the
+// lookup index exists and expects the migrations to index them.
+func TestAssetIdsBackfill(t *testing.T) {
+	database := openDatabase(t)
+	ctx := context.Background()
+	migrator, err := migrations.NewMigrator(database)
+	if err != nil {
+		t.Fatalf("Could not load migrations: %v", err)
+	}
+
+	// Start from the schema before the lookup index, migration 7
+	if _, err := migrator.Down(ctx, len(migrator.Migrations)); err != nil {
+		t.Fatalf("Reverting the migrations failed: %v", err)
+	}
+	beforeIndex := &migrations.Migrator{DB: database, Migrations: migrator.Migrations[:6]}
+	if _, err := beforeIndex.Up(ctx); err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+	const shellID = "urn:example:aas:backfill-test:shell"
+	const descriptorID = "urn:example:aas:backfill-test:descriptor"
+	defer database.ExecContext(ctx, "DELETE FROM Shells WHERE id = ?", shellID)
+	defer database.ExecContext(ctx, "DELETE FROM ShellDescriptors WHERE id = ?", descriptorID)
+	defer database.ExecContext(ctx, "DELETE FROM AssetIds WHERE aas_id IN (?, ?)", shellID, descriptorID)
+	if _, err := database.ExecContext(ctx, "INSERT INTO Users (id, username, email, password_hash) VALUES (?, ?, ?, ?)",
+		ownerID, "test", "test@test.de", testUserPasswordHash); err != nil {
+		t.Fatalf("Could not seed the test user: %v", err)
+	}
+
+	serial := aas.SpecificAssetId{Name: "serialNumber", Value: "backfill-test"}
+	globalAssetId := "urn:example:asset:backfill-test"
+	store := func(table string, id string, v any) {
+		t.Helper()
+		document, err := json.Marshal(v)
+		if err != nil {
+			t.Fatalf("Could not encode %s: %v", id, err)
+		}
+		hash := sha256.Sum256([]byte(id))
+		if _, err := database.ExecContext(ctx, "INSERT INTO "+table+" (id_hash, id, owner_id, document) VALUES (?, ?, ?, ?)",
+			hex.EncodeToString(hash[:]), id, ownerID, string(document)); err != nil {
+			t.Fatalf("Could not store %s: %v", id, err)
+		}
+	}
+	store("Shells", shellID, &aas.AssetAdministrationShell{
+		Identifiable:     aas.Identifiable{Id: shellID},
+		AssetInformation: aas.AssetInformation{AssetKind: aas.AssetKindInstance, GlobalAssetId: &globalAssetId},
+	})
+	store("ShellDescriptors", descriptorID, &aas.AssetAdministrationShellDescriptor{Id: descriptorID, SpecificAssetIds: []aas.SpecificAssetId{serial}})
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	repo := &repositories.AssetLinkRepository{DB: database}
+	for _, tc := range []struct {
+		assetId aas.SpecificAssetId
+		want    string
+	}{
+		{assetId: aas.SpecificAssetId{Name: repositories.GlobalAssetIdName, Value: globalAssetId}, want: shellID},
+		{assetId: serial, want: descriptorID},
+	} {
+		ids, _, err := repo.LookupShells(ctx, []aas.SpecificAssetId{tc.assetId}, "", 10)
+		if err != nil {
+			t.Fatalf("Failed to look up shells: %v", err)
+		}
+		if !reflect.DeepEqual(ids, []string{tc.want}) {
+			t.Errorf("Expected %s for %s, got %v", tc.want, tc.assetId.Name, ids)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/database/migrations"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// baselineSchema is the schema of mysql/init.sql from before the migrations
+// existed, including its seed user.
+var baselineSchema = []string{
+	`CREATE TABLE IF NOT EXISTS Users (
+		id CHAR(36) PRIMARY KEY,
+		username VARCHAR(255) NOT NULL UNIQUE,
+		email VARCHAR(255) NOT NULL UNIQUE,
+		password_hash VARCHAR(255) NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS Verifications (
+		email VARCHAR(255) PRIMARY KEY,
+		verification_code VARCHAR(255) NOT NULL,
+		verified BOOLEAN NOT NULL DEFAULT FALSE
+	)`,
+	`INSERT INTO Users (id, username, email, password_hash)
+		VALUES ('23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9', 'test', 'test@test.de', '` + testUserPasswordHash + `')`,
+}
+
+func TestMigrations(t *testing.T) {
+	database := setupDatabase(t)
+
+	migrator, err := migrations.NewMigrator(database)
+	if err != nil {
+		t.Fatalf("Could not load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// Running up twice must be a no-op the second time
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+	if applied != 0 {
+		t.Errorf("Expected no pending migrations, applied %d", applied)
+	}
+
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		t.Fatalf("Could not read migration status: %v", err)
+	}
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			t.Errorf("Migration %d is not applied", status.Migration.Version)
+		}
+	}
+}
+
+func TestMigrations_FromBaselineSchema(t *testing.T) {
+	database := openDatabase(t)
+
+	migrator, err := migrations.NewMigrator(database)
+	if err != nil {
+		t.Fatalf("Could not load migrations: %v", err)
+	}
+
+	ctx := context.Background()
+
+	// A shared database may already be migrated, so start from scratch
+	if _, err := migrator.Down(ctx, len(migrator.Migrations)); err != nil {
+		t.Fatalf("Reverting the migrations failed: %v", err)
+	}
+	for _, statement := range baselineSchema {
+		if _, err := database.ExecContext(ctx, statement); err != nil {
+			t.Fatalf("Could not create the baseline schema: %v", err)
+		}
+	}
+
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("Migration failed: %v", err)
+	}
+
+	userRepo := &repositories.UserRepository{DB: database}
+	user, err := userRepo.GetByEmail(ctx, "test@test.de")
+	if err != nil {
+		t.Fatalf("Could not read the baseline user: %v", err)
+	}
+	if user.Username != "test" || user.Locale != "en" {
+		t.Errorf("Expected user test with locale en, got %s with locale %q", user.Username, user.Locale)
+	}
+}
@@ -0,0 +1,98 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/aasx"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// TestPackageRepository stores a package and checks that its shells and
+// submodels cannot be uploaded a second time.
+func TestPackageRepository(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.PackageRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+
+	idShort := "Pump"
+	pkg := &aasx.Package{
+		Environment: aas.Environment{
+			AssetAdministrationShells: []aas.AssetAdministrationShell{{
+				Identifiable:     aas.Identifiable{Id: "urn:example:aas:repo-test"},
+				AssetInformation: aas.AssetInformation{AssetKind: aas.AssetKindInstance},
+			}},
+			Submodels: []aas.Submodel{{
+				Identifiable: aas.Identifiable{Referable: aas.Referable{IdShort: &idShort}, Id: "urn:example:sm:repo-test"},
+				SemanticId:   &aas.Reference{Type: aas.ReferenceTypesExternalReference, Keys: []aas.Key{{Type: aas.KeyTypesGlobalReference, Value: "urn:example:semantic"}}},
+			}},
+		},
+		Supplementary: []aasx.File{{Path: "/aasx/files/manual.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}},
+		Thumbnail:     &aasx.File{Path: "/thumbnail.png", ContentType: "image/png", Data: []byte("\x89PNG")},
+	}
+
+	stored, err := repo.CreatePackage(ctx, ownerID, "repo-test.aasx", []byte("content"), pkg)
+	if err != nil {
+		t.Fatalf("Failed to store package: %v", err)
+	}
+	defer database.ExecContext(ctx, "DELETE FROM Packages WHERE id = ?", stored.ID)
+	defer database.ExecContext(ctx, "DELETE FROM Shells WHERE package_id = ?", stored.ID)
+	defer database.ExecContext(ctx, "DELETE FROM Submodels WHERE package_id = ?", stored.ID)
+
+	loaded, err := repo.GetPackage(ctx, stored.ID)
+	if err != nil {
+		t.Fatalf("Failed to load package: %v", err)
+	}
+	if loaded.OwnerID != ownerID || loaded.Filename != "repo-test.aasx" || loaded.Size != 7 {
+		t.Errorf("Unexpected package %+v", loaded)
+	}
+	if len(loaded.Shells) != 1 || loaded.Shells[0] != "urn:example:aas:repo-test" {
+		t.Errorf("Unexpected shells %v", loaded.Shells)
+	}
+	if len(loaded.Submodels) != 1 || loaded.Submodels[0] != "urn:example:sm:repo-test" {
+		t.Errorf("Unexpected submodels %v", loaded.Submodels)
+	}
+	if len(loaded.Files) != 2 || loaded.Files[0] != "/thumbnail.png" {
+		t.Errorf("Expected the thumbnail to be listed first, got %v", loaded.Files)
+	}
+
+	if _, content, err := repo.GetPackageContent(ctx, stored.ID); err != nil || string(content) != "content" {
+		t.Errorf("Expected the uploaded content, got %q (%v)", content, err)
+	}
+	if file, err := repo.GetPackageFile(ctx, stored.ID, "/aasx/files/manual.pdf"); err != nil || file.ContentType != "application/pdf" || string(file.Data) != "%PDF-1.4" {
+		t.Errorf("Unexpected file %+v (%v)", file, err)
+	}
+	if _, err := repo.GetPackageFile(ctx, stored.ID, "/missing.pdf"); err != repositories.ErrPackageFileNotFound {
+		t.Errorf("Expected ErrPackageFileNotFound, got %v", err)
+	}
+
+	env, err := repo.GetEnvironment(ctx, []string{"urn:example:aas:repo-test"}, []string{"urn:example:sm:repo-test"})
+	if err != nil {
+		t.Fatalf("Failed to load environment: %v", err)
+	}
+	if !reflect.DeepEqual(*env, pkg.Environment) {
+		t.Errorf("Expected the stored environment %+v, got %+v", pkg.Environment, *env)
+	}
+	if _, err := repo.GetEnvironment(ctx, nil, []string{"urn:example:sm:unknown"}); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+
+	if _, err := repo.CreatePackage(ctx, ownerID, "again.aasx", []byte("content"), pkg); err != repositories.ErrIdentifiableExists {
+		t.Errorf("Expected ErrIdentifiableExists, got %v", err)
+	}
+	var count int
+	if err := database.QueryRowContext(ctx, "SELECT COUNT(*) FROM Packages WHERE filename = ?", "again.aasx").Scan(&count); err != nil || count != 0 {
+		t.Errorf("Expected the rejected package not to be stored, got %d (%v)", count, err)
+	}
+
+	if _, err := repo.GetPackage(ctx, "00000000-0000-0000-0000-000000000000"); err != repositories.ErrPackageNotFound {
+		t.Errorf("Expected ErrPackageNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,101 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// TestShellDescriptorRepository covers the registry's storage, including the
+// asset filters and the columns kept up to date by updates.
+func TestShellDescriptorRepository(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.ShellDescriptorRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+	const pumpType = "urn:example:type:registry-test:pump"
+
+	instance := aas.AssetKindInstance
+	var ids []string
+	for i := 0; i < 3; i++ {
+		assetType := pumpType
+		if i == 2 {
+			assetType = "urn:example:type:registry-test:valve"
+		}
+		descriptor := &aas.AssetAdministrationShellDescriptor{
+			Id:        fmt.Sprintf("urn:example:aas:registry-test:%d", i),
+			AssetKind: &instance,
+			AssetType: &assetType,
+			Endpoints: []aas.Endpoint{{Interface: "AAS-3.0", ProtocolInformation: aas.ProtocolInformation{Href: "https://edge.example.com"}}},
+		}
+		if err := repo.CreateShellDescriptor(ctx, ownerID, descriptor); err != nil {
+			t.Fatalf("Failed to create shell descriptor: %v", err)
+		}
+		ids = append(ids, descriptor.Id)
+		defer database.ExecContext(ctx, "DELETE FROM ShellDescriptors WHERE id = ?", descriptor.Id)
+	}
+	if err := repo.CreateShellDescriptor(ctx, ownerID, &aas.AssetAdministrationShellDescriptor{Id: ids[0]}); err != repositories.ErrIdentifiableExists {
+		t.Errorf("Expected ErrIdentifiableExists, got %v", err)
+	}
+
+	descriptors, next, err := repo.ListShellDescriptors(ctx, aas.AssetKindInstance, pumpType, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to list shell descriptors: %v", err)
+	}
+	if len(descriptors) != 2 || next != "" {
+		t.Errorf("Expected the two pumps on one page, got %d descriptors and cursor %q", len(descriptors), next)
+	}
+	if descriptors, _, err = repo.ListShellDescriptors(ctx, aas.AssetKindType, pumpType, "", 10); err != nil || len(descriptors) != 0 {
+		t.Errorf("Expected no pump types, got %d (%v)", len(descriptors), err)
+	}
+
+	// Updates keep the asset_type column in sync with the document.
+	err = repo.UpdateShellDescriptor(ctx, ownerID, ids[2], func(descriptor *aas.AssetAdministrationShellDescriptor) error {
+		*descriptor.AssetType = pumpType
+		descriptor.SubmodelDescriptors = []aas.SubmodelDescriptor{{Id: "urn:example:sm:registry-test"}}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update shell descriptor: %v", err)
+	}
+	if descriptors, _, err = repo.ListShellDescriptors(ctx, "", pumpType, "", 10); err != nil || len(descriptors) != 3 {
+		t.Errorf("Expected three pumps after the update, got %d (%v)", len(descriptors), err)
+	}
+	descriptor, err := repo.GetShellDescriptor(ctx, ids[2])
+	if err != nil || len(descriptor.SubmodelDescriptors) != 1 {
+		t.Errorf("Expected the added submodel descriptor, got %+v (%v)", descriptor, err)
+	}
+
+	if err := repo.UpdateShellDescriptor(ctx, "someone-else", ids[0], func(*aas.AssetAdministrationShellDescriptor) error { return nil }); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := repo.DeleteShellDescriptor(ctx, ownerID, ids[0]); err != nil {
+		t.Errorf("Failed to delete shell descriptor: %v", err)
+	}
+	if _, err := repo.GetShellDescriptor(ctx, ids[0]); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+
+	submodels := &repositories.SubmodelDescriptorRepository{DB: database}
+	submodel := &aas.SubmodelDescriptor{
+		Id:        "urn:example:sm:registry-test:nameplate",
+		Endpoints: []aas.Endpoint{{Interface: "SUBMODEL-3.0", ProtocolInformation: aas.ProtocolInformation{Href: "https://edge.example.com"}}},
+	}
+	if err := submodels.CreateSubmodelDescriptor(ctx, ownerID, submodel); err != nil {
+		t.Fatalf("Failed to create submodel descriptor: %v", err)
+	}
+	defer database.ExecContext(ctx, "DELETE FROM SubmodelDescriptors WHERE id = ?", submodel.Id)
+	if stored, err := submodels.GetSubmodelDescriptor(ctx, submodel.Id); err != nil || stored.Endpoints[0].ProtocolInformation.Href != "https://edge.example.com" {
+		t.Errorf("Expected the stored submodel descriptor, got %+v (%v)", stored, err)
+	}
+	if err := submodels.DeleteSubmodelDescriptor(ctx, "someone-else", submodel.Id); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+}
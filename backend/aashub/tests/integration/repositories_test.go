@@ -0,0 +1,155 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	utils "github.com/aas-hub-org/aashub/internal/utils"
+)
+
+// TestRepositories exercises the repositories' SQL directly so that every
+// supported engine can be checked by running the suite with a different
+// DB_DRIVER.
+func TestRepositories(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+
+	jwtSecret, err := utils.ReadFile("../../privatekey.txt")
+	if err != nil {
+		t.Fatalf("Could not read the JWT key: %v", err)
+	}
+
+	verifyRepo := &repositories.VerificationRepository{DB: database}
+	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: verificationMailer(verifyRepo), JWTSecret: jwtSecret}
+
+	defer database.ExecContext(ctx, "DELETE FROM Users WHERE username = ?", "repouser")
+	defer database.ExecContext(ctx, "DELETE FROM Verifications WHERE email = ?", "repo@example.com")
+
+	if err := userRepo.RegisterUser(ctx, "repouser", "repo@example.com", "password123", "de"); err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+
+	// Registering the same user twice is reported as duplicate
+	if err := userRepo.RegisterUser(ctx, "repouser", "repo@example.com", "password123", "de"); err != repositories.ErrUserRepoExists {
+		t.Errorf("Expected ErrUserRepoExists, got %v", err)
+	}
+
+	user, err := userRepo.GetByUsername(ctx, "repouser")
+	if err != nil {
+		t.Fatalf("Failed to look up user by username: %v", err)
+	}
+	if user.Email != "repo@example.com" || user.Locale != "de" || user.CreatedAt.IsZero() || user.LastLoginAt != nil {
+		t.Errorf("Unexpected user %+v", user)
+	}
+	if byID, err := userRepo.GetByID(ctx, user.ID); err != nil || byID.Username != "repouser" {
+		t.Errorf("Failed to look up user by id: %v", err)
+	}
+	if _, err := userRepo.GetByEmail(ctx, "nobody@example.com"); err != repositories.ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+
+	if _, err := userRepo.LoginUser(ctx, "repo@example.com", "password123"); err != nil {
+		t.Errorf("Failed to log in: %v", err)
+	}
+	if user, err := userRepo.GetByEmail(ctx, "repo@example.com"); err != nil || user.LastLoginAt == nil {
+		t.Errorf("Expected the login to be recorded, got %+v (%v)", user, err)
+	}
+	if _, err := userRepo.LoginUser(ctx, "repouser", "wrong"); err != repositories.ErrUserRepoNotFound {
+		t.Errorf("Expected ErrUserRepoNotFound, got %v", err)
+	}
+
+	// A new verification replaces the previous code
+	code, err := verifyRepo.CreateVerification(ctx, "repo@example.com")
+	if err != nil {
+		t.Fatalf("Failed to create verification: %v", err)
+	}
+	if errType, err := verifyRepo.Verify(ctx, "repo@example.com", code); err != nil {
+		t.Errorf("Failed to verify (%s): %v", errType, err)
+	}
+	if errType, _ := verifyRepo.Verify(ctx, "repo@example.com", code); errType != "user" {
+		t.Errorf("Expected a second verification to fail with a user error, got %q", errType)
+	}
+}
+
+// TestAdminRepositories covers the repository methods behind "aashub admin".
+func TestAdminRepositories(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+
+	verifyRepo := &repositories.VerificationRepository{DB: database}
+	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: verificationMailer(verifyRepo)}
+
+	defer database.ExecContext(ctx, "DELETE FROM Users WHERE username IN (?, ?)", "adminuser", "staleuser")
+	defer database.ExecContext(ctx, "DELETE FROM Verifications WHERE email IN (?, ?)", "admin@example.com", "stale@example.com")
+
+	admin, err := userRepo.CreateUser(ctx, "adminuser", "admin@example.com", "password123", "en")
+	if err != nil {
+		t.Fatalf("Failed to create user: %v", err)
+	}
+	if err := verifyRepo.MarkVerified(ctx, admin.Email); err != nil {
+		t.Fatalf("Failed to mark user as verified: %v", err)
+	}
+
+	// Granting a role twice keeps a single grant
+	for i := 0; i < 2; i++ {
+		if err := userRepo.GrantRole(ctx, admin.ID, "admin"); err != nil {
+			t.Fatalf("Failed to grant role: %v", err)
+		}
+	}
+	if roles, err := userRepo.Roles(ctx, admin.ID); err != nil || len(roles) != 1 || roles[0] != "admin" {
+		t.Errorf("Expected the admin role, got %v (%v)", roles, err)
+	}
+	if err := userRepo.GrantRole(ctx, "00000000-0000-0000-0000-000000000000", "admin"); err != repositories.ErrUserNotFound {
+		t.Errorf("Expected ErrUserNotFound, got %v", err)
+	}
+
+	if err := userRepo.SetPassword(ctx, admin.ID, "changed"); err != nil {
+		t.Fatalf("Failed to set password: %v", err)
+	}
+	if _, err := userRepo.LoginUser(ctx, "adminuser", "changed"); err != nil {
+		t.Errorf("Failed to log in with the new password: %v", err)
+	}
+
+	if err := userRepo.RegisterUser(ctx, "staleuser", "stale@example.com", "password123", "en"); err != nil {
+		t.Fatalf("Failed to register user: %v", err)
+	}
+	unverified, err := userRepo.ListUnverified(ctx)
+	if err != nil {
+		t.Fatalf("Failed to list unverified users: %v", err)
+	}
+	var listed []string
+	for _, user := range unverified {
+		listed = append(listed, user.Username)
+	}
+	if !contains(listed, "staleuser") || contains(listed, "adminuser") {
+		t.Errorf("Unexpected unverified users %v", listed)
+	}
+
+	// Only registrations older than the cut-off are purged
+	if deleted, err := userRepo.DeleteStaleRegistrations(ctx, time.Now().Add(-time.Hour)); err != nil || deleted != 0 {
+		t.Errorf("Expected no stale registrations, got %d (%v)", deleted, err)
+	}
+	if deleted, err := userRepo.DeleteStaleRegistrations(ctx, time.Now().Add(time.Minute)); err != nil || deleted < 1 {
+		t.Errorf("Expected the stale registration to be purged, got %d (%v)", deleted, err)
+	}
+	if _, err := userRepo.GetByUsername(ctx, "staleuser"); err != repositories.ErrUserNotFound {
+		t.Errorf("Expected the stale user to be deleted, got %v", err)
+	}
+	if _, err := userRepo.GetByUsername(ctx, "adminuser"); err != nil {
+		t.Errorf("Expected the verified user to be kept, got %v", err)
+	}
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// TestShellRepository covers the shell endpoints' storage, including paging
+// and the ownership checks.
+func TestShellRepository(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.ShellRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+
+	idShort := "ShellRepoTest"
+	var ids []string
+	for i := 0; i < 3; i++ {
+		shell := &aas.AssetAdministrationShell{
+			Identifiable:     aas.Identifiable{Referable: aas.Referable{IdShort: &idShort}, Id: fmt.Sprintf("urn:example:aas:shell-repo-test:%d", i)},
+			AssetInformation: aas.AssetInformation{AssetKind: aas.AssetKindInstance},
+		}
+		if err := repo.CreateShell(ctx, ownerID, shell); err != nil {
+			t.Fatalf("Failed to create shell: %v", err)
+		}
+		ids = append(ids, shell.Id)
+		defer database.ExecContext(ctx, "DELETE FROM Shells WHERE id = ?", shell.Id)
+	}
+	if err := repo.CreateShell(ctx, ownerID, &aas.AssetAdministrationShell{Identifiable: aas.Identifiable{Id: ids[0]}}); err != repositories.ErrIdentifiableExists {
+		t.Errorf("Expected ErrIdentifiableExists, got %v", err)
+	}
+
+	// Two pages of two and one shells
+	seen := map[string]bool{}
+	cursor := ""
+	for page := 0; page < 2; page++ {
+		shells, next, err := repo.ListShells(ctx, idShort, cursor, 2)
+		if err != nil {
+			t.Fatalf("Failed to list shells: %v", err)
+		}
+		for _, shell := range shells {
+			seen[shell.Id] = true
+		}
+		if (page == 0) != (next != "") {
+			t.Errorf("Unexpected cursor %q on page %d", next, page)
+		}
+		cursor = next
+	}
+	if len(seen) != 3 {
+		t.Errorf("Expected all shells to be listed once, got %v", seen)
+	}
+
+	err := repo.UpdateShell(ctx, ownerID, ids[0], func(shell *aas.AssetAdministrationShell) error {
+		shell.AssetInformation.AssetKind = aas.AssetKindType
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update shell: %v", err)
+	}
+	if shell, err := repo.GetShell(ctx, ids[0]); err != nil || shell.AssetInformation.AssetKind != aas.AssetKindType {
+		t.Errorf("Expected the updated shell, got %+v (%v)", shell, err)
+	}
+
+	failed := errors.New("rejected")
+	if err := repo.UpdateShell(ctx, ownerID, ids[0], func(*aas.AssetAdministrationShell) error { return failed }); err != failed {
+		t.Errorf("Expected the error of the update, got %v", err)
+	}
+	if err := repo.UpdateShell(ctx, "someone-else", ids[0], func(*aas.AssetAdministrationShell) error { return nil }); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := repo.DeleteShell(ctx, "someone-else", ids[0]); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+
+	if err := repo.DeleteShell(ctx, ownerID, ids[0]); err != nil {
+		t.Errorf("Failed to delete shell: %v", err)
+	}
+	if _, err := repo.GetShell(ctx, ids[0]); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+	if err := repo.DeleteShell(ctx, ownerID, ids[0]); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+package integration_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+)
+
+// TestSubmodelRepository covers the submodel endpoints' storage, including
+// the semanticId filter and the columns kept up to date by updates.
+func TestSubmodelRepository(t *testing.T) {
+	database := setupDatabase(t)
+	ctx := context.Background()
+	repo := &repositories.SubmodelRepository{DB: database}
+	const ownerID = "23e3b6f5-6785-42c6-a7f5-d8cecf04a6b9"
+	const nameplate = "urn:example:sm:submodel-repo-test:nameplate"
+
+	idShort := "SubmodelRepoTest"
+	var ids []string
+	for i := 0; i < 3; i++ {
+		semanticId := nameplate
+		if i == 2 {
+			semanticId = "urn:example:sm:submodel-repo-test:documentation"
+		}
+		submodel := &aas.Submodel{
+			Identifiable: aas.Identifiable{Referable: aas.Referable{IdShort: &idShort}, Id: fmt.Sprintf("urn:example:sm:submodel-repo-test:%d", i)},
+			SemanticId:   &aas.Reference{Type: aas.ReferenceTypesExternalReference, Keys: []aas.Key{{Type: aas.KeyTypesGlobalReference, Value: semanticId}}},
+		}
+		if err := repo.CreateSubmodel(ctx, ownerID, submodel); err != nil {
+			t.Fatalf("Failed to create submodel: %v", err)
+		}
+		ids = append(ids, submodel.Id)
+		defer database.ExecContext(ctx, "DELETE FROM Submodels WHERE id = ?", submodel.Id)
+	}
+	if err := repo.CreateSubmodel(ctx, ownerID, &aas.Submodel{Identifiable: aas.Identifiable{Id: ids[0]}}); err != repositories.ErrIdentifiableExists {
+		t.Errorf("Expected ErrIdentifiableExists, got %v", err)
+	}
+
+	submodels, next, err := repo.ListSubmodels(ctx, idShort, nameplate, "", 10)
+	if err != nil {
+		t.Fatalf("Failed to list submodels: %v", err)
+	}
+	if len(submodels) != 2 || next != "" {
+		t.Errorf("Expected the two nameplates on one page, got %d submodels and cursor %q", len(submodels), next)
+	}
+	submodels, next, err = repo.ListSubmodels(ctx, idShort, "", "", 2)
+	if err != nil || len(submodels) != 2 || next == "" {
+		t.Fatalf("Expected a first page of two submodels, got %d, cursor %q (%v)", len(submodels), next, err)
+	}
+	if submodels, _, err = repo.ListSubmodels(ctx, idShort, "", next, 2); err != nil || len(submodels) != 1 {
+		t.Errorf("Expected a last page of one submodel, got %d (%v)", len(submodels), err)
+	}
+
+	// Updates keep the semantic_id column in sync with the document.
+	err = repo.UpdateSubmodel(ctx, ownerID, ids[2], func(submodel *aas.Submodel) error {
+		submodel.SemanticId.Keys[0].Value = nameplate
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Failed to update submodel: %v", err)
+	}
+	if submodels, _, err = repo.ListSubmodels(ctx, idShort, nameplate, "", 10); err != nil || len(submodels) != 3 {
+		t.Errorf("Expected three nameplates after the update, got %d (%v)", len(submodels), err)
+	}
+
+	err = repo.UpdateSubmodel(ctx, ownerID, ids[0], func(submodel *aas.Submodel) error {
+		submodel.Id = "urn:example:sm:submodel-repo-test:renamed"
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected changing the id to fail")
+	}
+	if err := repo.UpdateSubmodel(ctx, "someone-else", ids[0], func(*aas.Submodel) error { return nil }); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+	if err := repo.DeleteSubmodel(ctx, "someone-else", ids[0]); err != repositories.ErrNotOwner {
+		t.Errorf("Expected ErrNotOwner, got %v", err)
+	}
+
+	if err := repo.DeleteSubmodel(ctx, ownerID, ids[0]); err != nil {
+		t.Errorf("Failed to delete submodel: %v", err)
+	}
+	if _, err := repo.GetSubmodel(ctx, ids[0]); !errors.Is(err, repositories.ErrIdentifiableNotFound) {
+		t.Errorf("Expected ErrIdentifiableNotFound, got %v", err)
+	}
+}
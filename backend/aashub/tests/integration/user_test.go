@@ -5,7 +5,7 @@ package integration_test
 
 import (
 	"bytes"
-	"database/sql"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -22,6 +22,7 @@ import (
 	api "github.com/aas-hub-org/aashub/api/handler"
 	db "github.com/aas-hub-org/aashub/internal/database"
 	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	utils "github.com/aas-hub-org/aashub/internal/utils"
 )
 
 type testCase struct {
@@ -31,29 +32,26 @@ type testCase struct {
 	expectedBody   string
 }
 
-func teardown(database *sql.DB) {
+func teardown(database *db.DB) {
 	// SQL statement to delete the test user, using ? as the placeholder
 	query := "DELETE FROM Users WHERE username = ?"
 
 	// Execute the query for the test username
-	if _, err := database.Exec(query, "testuser"); err != nil {
+	if _, err := database.ExecContext(context.Background(), query, "testuser"); err != nil {
 		log.Fatalf("Failed to clean up test user: %v", err)
 	}
 }
 
 func TestRegisterUser(t *testing.T) {
 	// Initialize the database connection
-	database, err := db.NewDB()
-	if err != nil {
-		log.Fatalf("Could not connect to the database: %v", err)
-	}
+	database := setupDatabase(t)
 
 	// Ensure teardown is called no matter what happens in the test
 	defer teardown(database)
 
 	// Instantiate the repository
 	verifyRepo := &repositories.VerificationRepository{DB: database}
-	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: verifyRepo}
+	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: verificationMailer(verifyRepo)}
 
 	// Instantiate the handler struct with the repository
 	userHandler := &api.UserHandler{Repo: userRepo}
@@ -135,13 +133,13 @@ func TestRegisterUser(t *testing.T) {
 	}
 }
 
-func verifyUser(t *testing.T, tc testCase, ts *httptest.Server, database *sql.DB) {
+func verifyUser(t *testing.T, tc testCase, ts *httptest.Server, database *db.DB) {
 	// Assuming successful registration, proceed to verification
 	if tc.expectedStatus == http.StatusCreated {
 		// get the verification code from the database
 		var verificationCode string
 		query := "SELECT verification_code FROM Verifications WHERE email = ?"
-		if err := database.QueryRow(query, tc.user.Email).Scan(&verificationCode); err != nil {
+		if err := database.QueryRowContext(context.Background(), query, tc.user.Email).Scan(&verificationCode); err != nil {
 			t.Fatalf("Failed to get verification code: %v", err)
 		}
 
@@ -187,14 +185,17 @@ func verifyUser(t *testing.T, tc testCase, ts *httptest.Server, database *sql.DB
 
 func TestLoginUser(t *testing.T) {
 	// Initialize the database connection
-	database, err := db.NewDB()
+	database := setupDatabase(t)
+
+	// Read the JWT signing key
+	jwtSecret, err := utils.ReadFile("../../privatekey.txt")
 	if err != nil {
-		t.Fatalf("Could not connect to the database: %v", err)
+		t.Fatalf("Could not read the JWT key: %v", err)
 	}
 
 	// Instantiate the repository
 	verifyRepo := &repositories.VerificationRepository{DB: database}
-	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: verifyRepo}
+	userRepo := &repositories.UserRepository{DB: database, VerificationRepository: verificationMailer(verifyRepo), JWTSecret: jwtSecret}
 
 	// Instantiate the handler struct with the repository
 	userHandler := &api.UserHandler{Repo: userRepo}
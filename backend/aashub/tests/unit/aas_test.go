@@ -0,0 +1,255 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEnvironmentRoundTrip decodes the sample environments and checks that
+// encoding them again yields the same JSON, so that no attribute is lost.
+func TestEnvironmentRoundTrip(t *testing.T) {
+	files, err := filepath.Glob("testdata/aas/*.json")
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	for _, file := range files {
+		t.Run(filepath.Base(file), func(t *testing.T) {
+			data, err := os.ReadFile(file)
+			assert.NoError(t, err)
+
+			var env aas.Environment
+			assert.NoError(t, json.Unmarshal(data, &env))
+
+			encoded, err := json.Marshal(env)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(data), string(encoded))
+		})
+	}
+}
+
+func TestEnvironmentConcreteTypes(t *testing.T) {
+	data, err := os.ReadFile("testdata/aas/full.json")
+	assert.NoError(t, err)
+
+	var env aas.Environment
+	assert.NoError(t, json.Unmarshal(data, &env))
+	assert.Len(t, env.AssetAdministrationShells, 1)
+	assert.Equal(t, aas.AssetKindInstance, env.AssetAdministrationShells[0].AssetInformation.AssetKind)
+	assert.Len(t, env.Submodels, 2)
+
+	elements := env.Submodels[0].SubmodelElements
+	property, ok := elements[0].(*aas.Property)
+	assert.True(t, ok)
+	assert.Equal(t, "ManufacturerName", *property.IdShort)
+	assert.Equal(t, "Example Pumps Ltd.", *property.Value)
+	assert.Nil(t, elements[1].(*aas.Property).Value)
+
+	blob := elements[4].(*aas.Blob)
+	assert.Equal(t, []byte("hello world"), blob.Value)
+
+	collection := elements[7].(*aas.SubmodelElementCollection)
+	list := collection.Value[1].(*aas.SubmodelElementList)
+	assert.Equal(t, aas.AasSubmodelElementsProperty, list.TypeValueListElement)
+	assert.False(t, *list.OrderRelevant)
+	assert.Equal(t, "Property", list.Value[0].ModelType())
+	assert.Nil(t, list.Value[0].Base().IdShort)
+
+	operation := env.Submodels[1].SubmodelElements[6].(*aas.Operation)
+	assert.Equal(t, "SubmodelElementCollection", operation.InoutputVariables[0].Value.ModelType())
+}
+
+func TestSubmodelElementModelType(t *testing.T) {
+	value := "42"
+	encoded, err := json.Marshal(aas.SubmodelElements{&aas.Property{ValueType: aas.DataTypeDefXsdInt, Value: &value}, &aas.Capability{}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{"modelType":"Property","valueType":"xs:int","value":"42"},{"modelType":"Capability"}]`, string(encoded))
+}
+
+func TestSubmodelElementUnknownModelType(t *testing.T) {
+	tests := map[string]string{
+		"unknown": `{"submodelElements":[{"modelType":"Pump","idShort":"x"}]}`,
+		"missing": `{"submodelElements":[{"idShort":"x"}]}`,
+		"nested":  `{"submodelElements":[{"modelType":"SubmodelElementCollection","value":[{"modelType":"Submodel"}]}]}`,
+	}
+
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			var submodel aas.Submodel
+			err := json.Unmarshal([]byte(data), &submodel)
+			assert.True(t, errors.Is(err, aas.ErrUnknownModelType), err)
+		})
+	}
+}
+
+// TestDecodeXML checks that the XML samples decode to the same environment
+// as their JSON counterparts.
+func TestDecodeXML(t *testing.T) {
+	for _, name := range []string{"full", "minimal"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/aas/" + name + ".json")
+			assert.NoError(t, err)
+			var expected aas.Environment
+			assert.NoError(t, json.Unmarshal(data, &expected))
+
+			file, err := os.Open("testdata/aas/" + name + ".xml")
+			assert.NoError(t, err)
+			defer file.Close()
+
+			env, err := aas.DecodeXML(file)
+			assert.NoError(t, err)
+			assert.Equal(t, &expected, env)
+		})
+	}
+}
+
+func TestDecodeXML_Invalid(t *testing.T) {
+	tests := map[string]struct {
+		document string
+		err      error
+	}{
+		"v2 namespace": {
+			document: `<aasenv xmlns="http://www.admin-shell.io/aas/2/0"></aasenv>`,
+			err:      aas.ErrUnsupportedNamespace,
+		},
+		"unknown element": {
+			document: `<environment xmlns="https://admin-shell.io/aas/3/0"><submodels><submodel><id>urn:sm</id><submodelElements><pump/></submodelElements></submodel></submodels></environment>`,
+			err:      aas.ErrUnknownModelType,
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := aas.DecodeXML(strings.NewReader(tc.document))
+			assert.True(t, errors.Is(err, tc.err), err)
+		})
+	}
+
+	_, err := aas.DecodeXML(strings.NewReader(`<shell xmlns="https://admin-shell.io/aas/3/0"/>`))
+	assert.Error(t, err)
+}
+
+// TestEncodeXML checks that encoding the JSON samples yields the XML
+// samples, which follow the element order of the schema.
+func TestEncodeXML(t *testing.T) {
+	for _, name := range []string{"full", "minimal"} {
+		t.Run(name, func(t *testing.T) {
+			data, err := os.ReadFile("testdata/aas/" + name + ".json")
+			assert.NoError(t, err)
+			var env aas.Environment
+			assert.NoError(t, json.Unmarshal(data, &env))
+			expected, err := os.ReadFile("testdata/aas/" + name + ".xml")
+			assert.NoError(t, err)
+
+			var buf strings.Builder
+			assert.NoError(t, aas.EncodeXML(&buf, &env))
+			assert.Equal(t, strings.TrimSpace(string(expected)), buf.String())
+
+			decoded, err := aas.DecodeXML(strings.NewReader(buf.String()))
+			assert.NoError(t, err)
+			assert.Equal(t, &env, decoded)
+		})
+	}
+}
+
+func TestEncodeXML_EmptyValues(t *testing.T) {
+	empty := ""
+	idShort := "Name"
+	submodel := aas.Submodel{
+		Identifiable: aas.Identifiable{Id: "urn:sm"},
+		SubmodelElements: aas.SubmodelElements{
+			&aas.Property{SubmodelElementBase: aas.SubmodelElementBase{Referable: aas.Referable{IdShort: &idShort}}, ValueType: aas.DataTypeDefXsdString, Value: &empty},
+			&aas.MultiLanguageProperty{},
+		},
+	}
+
+	var buf strings.Builder
+	assert.NoError(t, aas.EncodeXMLElement(&buf, "submodel", &submodel))
+	assert.NotContains(t, buf.String(), "<keys>")
+	assert.Contains(t, buf.String(), "<valueType>xs:string</valueType>\n      <value></value>")
+	assert.Contains(t, buf.String(), "<multiLanguageProperty></multiLanguageProperty>")
+
+	var decoded aas.Submodel
+	assert.NoError(t, aas.DecodeXMLElement(strings.NewReader(buf.String()), "submodel", &decoded))
+	assert.Equal(t, submodel, decoded)
+}
+
+func TestSubmodelElementByIdShortPath(t *testing.T) {
+	var submodel aas.Submodel
+	assert.NoError(t, json.Unmarshal([]byte(nameplateJSON), &submodel))
+
+	for path, modelType := range map[string]string{
+		"ManufacturerName":  "Property",
+		"Address.Logo":      "Blob",
+		"Markings[0]":       "SubmodelElementCollection",
+		"Markings[0].Image": "File",
+	} {
+		element, err := submodel.Element(path)
+		if assert.NoError(t, err, path) {
+			assert.Equal(t, modelType, element.ModelType(), path)
+		}
+	}
+
+	for _, path := range []string{"Unknown", "Address.Unknown", "Markings[1]", "Address[0]", "Markings.Image", "ManufacturerName.Value"} {
+		_, err := submodel.Element(path)
+		assert.ErrorIs(t, err, aas.ErrElementNotFound, path)
+	}
+	for _, path := range []string{"", ".Address", "Address.", "Address..City", "[0]", "Markings[-1]", "Markings[+0]", "Markings[x]", "Markings[0", "1st"} {
+		_, err := submodel.Element(path)
+		assert.ErrorIs(t, err, aas.ErrInvalidIdShortPath, path)
+	}
+
+	assert.Equal(t, []string{
+		"ManufacturerName", "YearOfConstruction", "Certified", "ProductDesignation", "Pressure",
+		"Address", "Address.City", "Address.Logo", "Markings", "Markings[0]", "Markings[0].Image",
+	}, aas.IdShortPaths("", submodel.SubmodelElements, false, true))
+}
+
+func TestValueOnly(t *testing.T) {
+	var submodel aas.Submodel
+	assert.NoError(t, json.Unmarshal([]byte(nameplateJSON), &submodel))
+
+	encoded, err := json.Marshal(aas.ElementsValueOnly(submodel.SubmodelElements))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"ManufacturerName": "ACME",
+		"YearOfConstruction": 2024,
+		"Certified": true,
+		"ProductDesignation": [{"en": "Pump"}],
+		"Pressure": {"min": 0.5, "max": 10},
+		"Address": {"City": "Berlin", "Logo": {"contentType": "image/png", "value": "iVBORw=="}},
+		"Markings": [{"Image": {"contentType": "image/png", "value": "/aasx/ce.png"}}]
+	}`, string(encoded))
+
+	// Values that are no valid JSON numbers stay strings.
+	value := "+1"
+	v, ok := aas.ValueOnly(&aas.Property{ValueType: aas.DataTypeDefXsdInt, Value: &value})
+	assert.True(t, ok)
+	assert.Equal(t, "+1", v)
+
+	_, ok = aas.ValueOnly(&aas.Operation{})
+	assert.False(t, ok)
+}
+
+func TestMetadata(t *testing.T) {
+	var submodel aas.Submodel
+	assert.NoError(t, json.Unmarshal([]byte(nameplateJSON), &submodel))
+
+	metadata, err := aas.Metadata(&submodel)
+	assert.NoError(t, err)
+	assert.Equal(t, "Nameplate", metadata["idShort"])
+	assert.NotContains(t, metadata, "submodelElements")
+
+	metadata, err = aas.Metadata(submodel.SubmodelElements[4])
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"modelType": "Range", "idShort": "Pressure", "valueType": "xs:double"}, metadata)
+}
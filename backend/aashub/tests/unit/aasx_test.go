@@ -0,0 +1,189 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/aasx"
+	"github.com/stretchr/testify/assert"
+)
+
+const testContentTypes = `<?xml version="1.0" encoding="utf-8"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+  <Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml" />
+  <Default Extension="json" ContentType="application/json" />
+  <Default Extension="xml" ContentType="text/xml" />
+  <Default Extension="png" ContentType="image/png" />
+  <Override PartName="/aasx/aasx-origin" ContentType="text/plain" />
+  <Override PartName="/aasx/files/manual.pdf" ContentType="application/pdf" />
+</Types>`
+
+// testPackageParts is a package with an XML spec part, a thumbnail and a
+// supplementary file, whose relationships use both absolute and relative
+// targets.
+func testPackageParts(t *testing.T) map[string]string {
+	spec, err := os.ReadFile("testdata/aas/full.xml")
+	assert.NoError(t, err)
+	return map[string]string{
+		"[Content_Types].xml": testContentTypes,
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Type="http://admin-shell.io/aasx/relationships/aasx-origin" Target="/aasx/aasx-origin" Id="r1" />
+  <Relationship Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/thumbnail" Target="thumbnail.png" Id="r2" />
+</Relationships>`,
+		"aasx/aasx-origin": "Intentionally empty.",
+		"aasx/_rels/aasx-origin.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Type="http://admin-shell.io/aasx/relationships/aas-spec" Target="xml/content.xml" Id="r3" />
+</Relationships>`,
+		"aasx/xml/content.xml": string(spec),
+		"aasx/xml/_rels/content.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Type="http://admin-shell.io/aasx/relationships/aas-suppl" Target="../files/manual.pdf" Id="r4" />
+  <Relationship Type="http://admin-shell.io/aasx/relationships/aas-suppl" Target="https://example.com/datasheet.pdf" TargetMode="External" Id="r5" />
+</Relationships>`,
+		"aasx/files/manual.pdf": "%PDF-1.4",
+		"thumbnail.png":         "\x89PNG",
+	}
+}
+
+func writeZip(t *testing.T, parts map[string]string) []byte {
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, archive.Close())
+	return buf.Bytes()
+}
+
+func readPackage(t *testing.T, parts map[string]string) (*aasx.Package, error) {
+	data := writeZip(t, parts)
+	return aasx.Read(bytes.NewReader(data), int64(len(data)))
+}
+
+func TestReadPackage(t *testing.T) {
+	pkg, err := readPackage(t, testPackageParts(t))
+	assert.NoError(t, err)
+
+	assert.Len(t, pkg.Environment.AssetAdministrationShells, 1)
+	assert.Len(t, pkg.Environment.Submodels, 2)
+	assert.Len(t, pkg.Environment.ConceptDescriptions, 1)
+	assert.Len(t, pkg.Specs, 1)
+	assert.Equal(t, "/aasx/xml/content.xml", pkg.Specs[0].Path)
+	assert.Equal(t, "text/xml", pkg.Specs[0].ContentType)
+
+	assert.Equal(t, []aasx.File{{Path: "/aasx/files/manual.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}}, pkg.Supplementary)
+	assert.Equal(t, &aasx.File{Path: "/thumbnail.png", ContentType: "image/png", Data: []byte("\x89PNG")}, pkg.Thumbnail)
+}
+
+func TestReadPackage_JSONSpecAndLegacyRelationships(t *testing.T) {
+	spec, err := os.ReadFile("testdata/aas/minimal.json")
+	assert.NoError(t, err)
+	parts := map[string]string{
+		"[Content_Types].xml": testContentTypes,
+		"_rels/.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Type="http://www.admin-shell.io/aasx/relationships/aasx-origin" Target="/aasx/aasx-origin" Id="r1" />
+</Relationships>`,
+		"aasx/aasx-origin": "",
+		"aasx/_rels/aasx-origin.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+  <Relationship Type="http://www.admin-shell.io/aasx/relationships/aas-spec" Target="/aasx/minimal.json" Id="r2" />
+</Relationships>`,
+		"aasx/minimal.json": string(spec),
+	}
+
+	pkg, err := readPackage(t, parts)
+	assert.NoError(t, err)
+	assert.Equal(t, "urn:example:aas:minimal", pkg.Environment.AssetAdministrationShells[0].Id)
+	assert.Nil(t, pkg.Thumbnail)
+	assert.Empty(t, pkg.Supplementary)
+}
+
+func TestReadPackage_Invalid(t *testing.T) {
+	tests := map[string]func(parts map[string]string){
+		"missing content types": func(parts map[string]string) { delete(parts, "[Content_Types].xml") },
+		"missing origin":        func(parts map[string]string) { delete(parts, "_rels/.rels") },
+		"missing spec part":     func(parts map[string]string) { delete(parts, "aasx/xml/content.xml") },
+		"missing supplementary": func(parts map[string]string) { delete(parts, "aasx/files/manual.pdf") },
+		"broken spec part":      func(parts map[string]string) { parts["aasx/xml/content.xml"] = "<environment" },
+		"v2 spec part": func(parts map[string]string) {
+			parts["aasx/xml/content.xml"] = `<aasenv xmlns="http://www.admin-shell.io/aas/2/0"/>`
+		},
+	}
+
+	for name, modify := range tests {
+		t.Run(name, func(t *testing.T) {
+			parts := testPackageParts(t)
+			modify(parts)
+			_, err := readPackage(t, parts)
+			assert.True(t, errors.Is(err, aasx.ErrInvalidPackage), err)
+		})
+	}
+
+	_, err := aasx.Read(bytes.NewReader([]byte("not a zip")), 9)
+	assert.True(t, errors.Is(err, aasx.ErrInvalidPackage), err)
+}
+
+func TestReadPackage_TooLarge(t *testing.T) {
+	limit := aasx.MaxUncompressedSize
+	aasx.MaxUncompressedSize = 1024
+	defer func() { aasx.MaxUncompressedSize = limit }()
+
+	_, err := readPackage(t, testPackageParts(t))
+	assert.True(t, errors.Is(err, aasx.ErrTooLarge), err)
+}
+
+func TestWritePackage_RoundTrip(t *testing.T) {
+	original, err := readPackage(t, testPackageParts(t))
+	assert.NoError(t, err)
+
+	var buf bytes.Buffer
+	assert.NoError(t, aasx.Write(&buf, original))
+	pkg, err := aasx.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+
+	assert.Equal(t, original.Environment, pkg.Environment)
+	assert.Equal(t, original.Supplementary, pkg.Supplementary)
+	assert.Equal(t, original.Thumbnail, pkg.Thumbnail)
+	assert.Len(t, pkg.Specs, 1)
+	assert.Equal(t, "/aasx/data.json", pkg.Specs[0].Path)
+	assert.Equal(t, "application/json", pkg.Specs[0].ContentType)
+}
+
+func TestWritePackage_ContentTypes(t *testing.T) {
+	var buf bytes.Buffer
+	pkg := &aasx.Package{
+		Supplementary: []aasx.File{{Path: "/aasx/files/data.bin"}, {Path: "/aasx/files/data.bin"}},
+		Thumbnail:     &aasx.File{Path: "/aasx/files/data.bin", ContentType: "image/png"},
+	}
+	assert.NoError(t, aasx.Write(&buf, pkg))
+
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	names := map[string]bool{}
+	for _, file := range archive.File {
+		assert.False(t, names[file.Name], "duplicate part %s", file.Name)
+		names[file.Name] = true
+	}
+	assert.True(t, names["[Content_Types].xml"])
+	assert.True(t, names["_rels/.rels"])
+	assert.True(t, names["aasx/_rels/data.json.rels"])
+
+	read, err := aasx.Read(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	assert.NoError(t, err)
+	assert.Equal(t, "image/png", read.Thumbnail.ContentType)
+	assert.Len(t, read.Supplementary, 1)
+}
+
+func TestWritePackage_InvalidPartName(t *testing.T) {
+	for _, name := range []string{"relative.pdf", "/aasx/../x.pdf", "/aasx/_rels/data.json.rels", "/aasx/data.json", "/[Content_Types].xml"} {
+		pkg := &aasx.Package{Supplementary: []aasx.File{{Path: name}}}
+		assert.Error(t, aasx.Write(&bytes.Buffer{}, pkg), name)
+	}
+}
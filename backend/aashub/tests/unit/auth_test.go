@@ -0,0 +1,70 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireUser(t *testing.T) {
+	const secret = "testSecret"
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/me", auth.RequireUser(secret), func(c *gin.Context) {
+		id, _ := auth.UserID(c.Request.Context())
+		c.String(http.StatusOK, id)
+	})
+
+	valid, err := auth.GenerateJWT("user-1", secret)
+	assert.NoError(t, err)
+	foreign, err := auth.GenerateJWT("user-1", "otherSecret")
+	assert.NoError(t, err)
+	expired, err := jwt.NewWithClaims(jwt.SigningMethodHS256, auth.CustomClaims{
+		Payload:          "user-1",
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute))},
+	}).SignedString([]byte(secret))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		header string
+		cookie string
+		status int
+	}{
+		{name: "bearer token", header: "Bearer " + valid, status: http.StatusOK},
+		{name: "lower case scheme", header: "bearer " + valid, status: http.StatusOK},
+		{name: "session cookie", cookie: valid, status: http.StatusOK},
+		{name: "no token", status: http.StatusUnauthorized},
+		{name: "basic auth", header: "Basic dXNlcjpwdw==", status: http.StatusUnauthorized},
+		{name: "foreign signature", header: "Bearer " + foreign, status: http.StatusUnauthorized},
+		{name: "expired token", cookie: expired, status: http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/me", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			if tt.cookie != "" {
+				req.AddCookie(&http.Cookie{Name: auth.TokenCookie, Value: tt.cookie})
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.status, rr.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, "user-1", rr.Body.String())
+			} else {
+				assert.NotEmpty(t, rr.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockConceptDescriptionRepository keeps concept descriptions in memory,
+// ordered by id.
+type MockConceptDescriptionRepository struct {
+	cds    map[string]aas.ConceptDescription
+	owners map[string]string
+	// batches counts the calls of GetConceptDescriptions.
+	batches int
+}
+
+func NewMockConceptDescriptionRepository() *MockConceptDescriptionRepository {
+	return &MockConceptDescriptionRepository{cds: map[string]aas.ConceptDescription{}, owners: map[string]string{}}
+}
+
+// containsReference reports whether refs contain ref, compared by type and
+// keys.
+func containsReference(refs []aas.Reference, ref *aas.Reference) bool {
+	for _, r := range refs {
+		if r.Type == ref.Type && reflect.DeepEqual(r.Keys, ref.Keys) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MockConceptDescriptionRepository) ListConceptDescriptions(ctx context.Context, idShort string, isCaseOf *aas.Reference, dataSpecificationRef *aas.Reference, cursor string, limit int) ([]aas.ConceptDescription, string, error) {
+	var ids []string
+	for id, cd := range m.cds {
+		var dataSpecifications []aas.Reference
+		for _, spec := range cd.EmbeddedDataSpecifications {
+			dataSpecifications = append(dataSpecifications, spec.DataSpecification)
+		}
+		if id > cursor && (idShort == "" || cd.IdShort != nil && *cd.IdShort == idShort) &&
+			(isCaseOf == nil || containsReference(cd.IsCaseOf, isCaseOf)) &&
+			(dataSpecificationRef == nil || containsReference(dataSpecifications, dataSpecificationRef)) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	cds := []aas.ConceptDescription{}
+	for i, id := range ids {
+		if i == limit {
+			return cds, ids[i-1], nil
+		}
+		cds = append(cds, m.cds[id])
+	}
+	return cds, "", nil
+}
+
+func (m *MockConceptDescriptionRepository) GetConceptDescription(ctx context.Context, id string) (*aas.ConceptDescription, error) {
+	cd, ok := m.cds[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+	}
+	return &cd, nil
+}
+
+func (m *MockConceptDescriptionRepository) GetConceptDescriptions(ctx context.Context, ids []string) ([]aas.ConceptDescription, error) {
+	m.batches++
+	cds := []aas.ConceptDescription{}
+	for _, id := range ids {
+		if cd, ok := m.cds[id]; ok {
+			cds = append(cds, cd)
+		}
+	}
+	return cds, nil
+}
+
+func (m *MockConceptDescriptionRepository) CreateConceptDescription(ctx context.Context, ownerID string, cd *aas.ConceptDescription) error {
+	if _, ok := m.cds[cd.Id]; ok {
+		return repositories.ErrIdentifiableExists
+	}
+	m.cds[cd.Id] = *cd
+	m.owners[cd.Id] = ownerID
+	return nil
+}
+
+func (m *MockConceptDescriptionRepository) UpdateConceptDescription(ctx context.Context, ownerID string, id string, update func(*aas.ConceptDescription) error) error {
+	cd, err := m.GetConceptDescription(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	if err := update(cd); err != nil {
+		return err
+	}
+	m.cds[id] = *cd
+	return nil
+}
+
+func (m *MockConceptDescriptionRepository) DeleteConceptDescription(ctx context.Context, ownerID string, id string) error {
+	if _, err := m.GetConceptDescription(ctx, id); err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	delete(m.cds, id)
+	return nil
+}
+
+func newConceptDescriptionRouter(repo *MockConceptDescriptionRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := &api.ConceptDescriptionHandler{Repo: repo}
+	r := gin.New()
+	cdg := r.Group("/api/v1/concept-descriptions", withTestUser)
+	cdg.GET("", gin.WrapF(handler.GetConceptDescriptions))
+	cdg.POST("", gin.WrapF(handler.PostConceptDescription))
+	cdg.GET("/:cdIdentifier", api.WithPathValues(handler.GetConceptDescription))
+	cdg.PUT("/:cdIdentifier", api.WithPathValues(handler.PutConceptDescription))
+	cdg.DELETE("/:cdIdentifier", api.WithPathValues(handler.DeleteConceptDescription))
+	return r
+}
+
+const iec61360Ref = `{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"https://admin-shell.io/DataSpecificationTemplates/DataSpecificationIec61360/3"}]}`
+
+// conceptDescriptionJSON is a concept description with IEC 61360 content
+// that is a case of the ECLASS property eclassID.
+func conceptDescriptionJSON(id, idShort, eclassID string) string {
+	return fmt.Sprintf(`{
+		"modelType": "ConceptDescription",
+		"id": %q,
+		"idShort": %q,
+		"isCaseOf": [{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":%q}]}],
+		"embeddedDataSpecifications": [{
+			"dataSpecification": %s,
+			"dataSpecificationContent": {"modelType": "DataSpecificationIec61360", "preferredName": [{"language": "en", "text": %q}]}
+		}]
+	}`, id, idShort, eclassID, iec61360Ref, idShort)
+}
+
+func TestConceptDescriptionAPI(t *testing.T) {
+	router := newConceptDescriptionRouter(NewMockConceptDescriptionRepository())
+	year := "/api/v1/concept-descriptions/" + encodeID("urn:cd:year")
+
+	// A request whose response cannot be written stores nothing
+	assert.Equal(t, http.StatusNotAcceptable, serve(router, http.MethodPost, "/api/v1/concept-descriptions", conceptDescriptionJSON("urn:cd:year", "YearOfConstruction", "0173-1#02-AAP906#001"), testUserHeader, "alice", "Accept", "text/html").Code)
+	rr := serve(router, http.MethodPost, "/api/v1/concept-descriptions", conceptDescriptionJSON("urn:cd:year", "YearOfConstruction", "0173-1#02-AAP906#001"), testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, year, rr.Header().Get("Location"))
+	assert.Equal(t, http.StatusCreated, serve(router, http.MethodPost, "/api/v1/concept-descriptions", conceptDescriptionJSON("urn:cd:name", "ManufacturerName", "0173-1#02-AAO677#002"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, "/api/v1/concept-descriptions", conceptDescriptionJSON("urn:cd:year", "Year", "x"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, "/api/v1/concept-descriptions", conceptDescriptionJSON("urn:cd:x", "X", "x")).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, "/api/v1/concept-descriptions", `{"modelType":"ConceptDescription","id":"urn:cd:x","idShort":"1st"}`, testUserHeader, "alice").Code)
+
+	rr = serve(router, http.MethodGet, year, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, conceptDescriptionJSON("urn:cd:year", "YearOfConstruction", "0173-1#02-AAP906#001"), rr.Body.String())
+	rr = serve(router, http.MethodGet, year, "", "Accept", "application/xml")
+	assert.Contains(t, rr.Body.String(), `<conceptDescription xmlns="https://admin-shell.io/aas/3/0">`)
+
+	// Filters
+	list := func(query string) []string {
+		var page api.GetConceptDescriptionsResult
+		rr := serve(router, http.MethodGet, "/api/v1/concept-descriptions"+query, "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		var ids []string
+		for _, cd := range page.Result {
+			ids = append(ids, cd.Id)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"urn:cd:name", "urn:cd:year"}, list(""))
+	assert.Equal(t, []string{"urn:cd:name"}, list("?idShort=ManufacturerName"))
+	assert.Equal(t, []string{"urn:cd:year"}, list("?isCaseOf="+encodeID(`{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"0173-1#02-AAP906#001"}]}`)))
+	assert.Equal(t, []string{"urn:cd:name", "urn:cd:year"}, list("?dataSpecificationRef="+encodeID(iec61360Ref)))
+	assert.Empty(t, list("?isCaseOf="+encodeID(`{"type":"ModelReference","keys":[{"type":"GlobalReference","value":"0173-1#02-AAP906#001"}]}`)))
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/concept-descriptions?isCaseOf="+encodeID("urn:not-json"), "").Code)
+
+	// Replacing and deleting
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, year, conceptDescriptionJSON("urn:cd:year", "Year", "0173-1#02-AAP906#001"), testUserHeader, "alice").Code)
+	assert.Equal(t, []string{"urn:cd:year"}, list("?idShort=Year"))
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, year, conceptDescriptionJSON("urn:cd:name", "Year", "x"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPut, year, conceptDescriptionJSON("urn:cd:year", "Year", "x"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, year, "", testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, year, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, year, "").Code)
+}
+
+func TestResolveSemanticIds(t *testing.T) {
+	submodels := NewMockSubmodelRepository()
+	cds := NewMockConceptDescriptionRepository()
+	router := newSubmodelRouter(&api.SubmodelHandler{Repo: submodels, ConceptDescriptions: cds})
+
+	var submodel aas.Submodel
+	assert.NoError(t, json.Unmarshal([]byte(nameplateJSON), &submodel))
+	year, _ := submodel.Element("YearOfConstruction")
+	year.Base().SemanticId = &aas.Reference{Type: aas.ReferenceTypesExternalReference, Keys: []aas.Key{{Type: aas.KeyTypesGlobalReference, Value: "0173-1#02-AAP906#001"}}}
+	city, _ := submodel.Element("Address.City")
+	city.Base().SemanticId = &aas.Reference{Type: aas.ReferenceTypesModelReference, Keys: []aas.Key{{Type: aas.KeyTypesConceptDescription, Value: "urn:cd:city"}}}
+	assert.NoError(t, submodels.CreateSubmodel(context.Background(), "alice", &submodel))
+	for _, id := range []string{"0173-1#02-AAP906#001", "urn:cd:city"} {
+		assert.NoError(t, cds.CreateConceptDescription(context.Background(), "alice", &aas.ConceptDescription{Identifiable: aas.Identifiable{Id: id}}))
+	}
+	nameplate := "/api/v1/submodels/" + encodeID("urn:sm:nameplate")
+
+	var resolved struct {
+		Result              json.RawMessage          `json:"result"`
+		ConceptDescriptions []aas.ConceptDescription `json:"conceptDescriptions"`
+	}
+	rr := serve(router, http.MethodGet, nameplate+"?resolveSemanticIds=true", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resolved))
+	assert.Contains(t, string(resolved.Result), `"id":"urn:sm:nameplate"`)
+	// The semantic id of the submodel itself has no stored concept description.
+	assert.Len(t, resolved.ConceptDescriptions, 2)
+	assert.Equal(t, 1, cds.batches, "all semantic ids are resolved at once")
+
+	rr = serve(router, http.MethodGet, nameplate+"/submodel-elements/Address?resolveSemanticIds=true", "")
+	resolved.ConceptDescriptions = nil
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resolved))
+	assert.Len(t, resolved.ConceptDescriptions, 1)
+	assert.Equal(t, "urn:cd:city", resolved.ConceptDescriptions[0].Id)
+
+	rr = serve(router, http.MethodGet, nameplate+"/submodel-elements/Address", "")
+	assert.Contains(t, rr.Body.String(), `"modelType":"SubmodelElementCollection"`)
+	assert.NotContains(t, rr.Body.String(), "conceptDescriptions")
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, nameplate+"?resolveSemanticIds=maybe", "").Code)
+}
@@ -0,0 +1,148 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadConfig_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	configFile := filepath.Join(dir, "aashub.yaml")
+	err := os.WriteFile(configFile, []byte(`
+server:
+  listen_address: ":8080"
+  public_url: "https://hub.example.com"
+database:
+  dsn: "file-dsn"
+mail:
+  transport: memory
+  address: noreply@example.com
+`), 0o644)
+	assert.NoError(t, err)
+
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("DATABASE_DSN", "env-dsn")
+
+	cfg, args, err := config.Load([]string{"-config", configFile, "-listen", ":7000", "status"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"status"}, args)
+	assert.Equal(t, ":7000", cfg.Server.ListenAddress)
+	assert.Equal(t, "https://hub.example.com", cfg.Server.PublicURL)
+	assert.Equal(t, "env-dsn", cfg.Database.DSN)
+	assert.Equal(t, "privatekey.txt", cfg.Auth.JWTKeyFile)
+	assert.Equal(t, "noreply@example.com", cfg.Mail.Address)
+}
+
+func TestLoadConfig_UnknownField(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "aashub.yaml")
+	err := os.WriteFile(configFile, []byte("server:\n  listen_adress: \":8080\"\n"), 0o644)
+	assert.NoError(t, err)
+
+	_, _, err = config.Load([]string{"-config", configFile})
+	assert.Error(t, err)
+}
+
+func TestLoadConfig_MissingEnvFile(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("MAIL_TRANSPORT", "memory")
+
+	_, _, err := config.Load([]string{"-env-file", filepath.Join(t.TempDir(), ".env")})
+	assert.NoError(t, err)
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := config.Default()
+	cfg.Server.ListenAddress = "9000"
+	cfg.Mail.Transport = "starttls"
+	cfg.Mail.Port = "submission"
+
+	err := cfg.Validate()
+	assert.ErrorContains(t, err, "server.listen_address")
+	assert.ErrorContains(t, err, "mail.host must be set")
+	assert.ErrorContains(t, err, `mail.port "submission" is not a valid port`)
+
+	cfg = config.Default()
+	cfg.Mail.Transport = "memory"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Server.ShutdownTimeout = 0
+	assert.ErrorContains(t, cfg.Validate(), "server.shutdown_timeout must be positive")
+
+	cfg = config.Default()
+	cfg.Mail.Transport = "memory"
+	cfg.Server.DrainDelay = -time.Second
+	assert.ErrorContains(t, cfg.Validate(), "server.drain_delay must not be negative")
+
+	cfg = config.Default()
+	cfg.Mail.Transport = "memory"
+	cfg.Log.Level = "verbose"
+	assert.ErrorContains(t, cfg.Validate(), `log.level "verbose" is unknown`)
+
+	cfg = config.Default()
+	cfg.Mail.Transport = "memory"
+	cfg.Tracing.Enabled = true
+	cfg.Tracing.Endpoint = ""
+	cfg.Tracing.SampleRatio = 1.5
+	err = cfg.Validate()
+	assert.ErrorContains(t, err, "tracing.endpoint must be set")
+	assert.ErrorContains(t, err, "tracing.sample_ratio must be between 0 and 1")
+
+	// Credentials must never be shared with every origin
+	cfg = config.Default()
+	cfg.Mail.Transport = "memory"
+	cfg.CORS.AllowedOrigins = []string{"*", "https://hub.example.com/"}
+	err = cfg.Validate()
+	assert.ErrorContains(t, err, "must not contain * when cors.allow_credentials is set")
+	assert.ErrorContains(t, err, `"https://hub.example.com/" is not an origin`)
+
+	cfg.CORS.AllowCredentials = false
+	cfg.CORS.AllowedOrigins = []string{"*"}
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadConfig_DatabasePool(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "aashub.yaml")
+	err := os.WriteFile(configFile, []byte(`
+database:
+  max_open_conns: 10
+  conn_max_lifetime: 2m
+mail:
+  transport: memory
+`), 0o644)
+	assert.NoError(t, err)
+
+	t.Setenv("DB_MAX_IDLE_CONNS", "4")
+	t.Setenv("DB_QUERY_TIMEOUT", "750ms")
+
+	cfg, _, err := config.Load([]string{"-config", configFile})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, cfg.Database.MaxOpenConns)
+	assert.Equal(t, 4, cfg.Database.MaxIdleConns)
+	assert.Equal(t, 2*time.Minute, cfg.Database.ConnMaxLifetime)
+	assert.Equal(t, 750*time.Millisecond, cfg.Database.QueryTimeout)
+
+	t.Setenv("DB_QUERY_TIMEOUT", "soon")
+	_, _, err = config.Load([]string{"-config", configFile})
+	assert.ErrorContains(t, err, "DB_QUERY_TIMEOUT")
+}
+
+func TestLoadConfig_CORSFromEnv(t *testing.T) {
+	t.Setenv("CONFIG_FILE", "")
+	t.Setenv("MAIL_TRANSPORT", "memory")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://hub.example.com, https://admin.example.com")
+	t.Setenv("CORS_ALLOWED_METHODS", "GET,POST")
+
+	cfg, _, err := config.Load(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"https://hub.example.com", "https://admin.example.com"}, cfg.CORS.AllowedOrigins)
+	assert.Equal(t, []string{"GET", "POST"}, cfg.CORS.AllowedMethods)
+	assert.True(t, cfg.CORS.AllowCredentials)
+}
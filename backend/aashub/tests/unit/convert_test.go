@@ -0,0 +1,83 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvert(t *testing.T) {
+	jsonSample, err := os.ReadFile("testdata/aas/full.json")
+	assert.NoError(t, err)
+	xmlSample, err := os.ReadFile("testdata/aas/full.xml")
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		body        []byte
+		contentType string
+		accept      string
+		status      int
+		expected    string
+	}{
+		{name: "JSON to XML", body: jsonSample, contentType: "application/json", status: http.StatusOK, expected: "application/xml"},
+		{name: "XML to JSON", body: xmlSample, contentType: "application/xml", status: http.StatusOK, expected: "application/json"},
+		{name: "text/xml to JSON", body: xmlSample, contentType: "text/xml; charset=utf-8", accept: "*/*", status: http.StatusOK, expected: "application/json"},
+		{name: "JSON requested", body: jsonSample, contentType: "application/json", accept: "application/json", status: http.StatusOK, expected: "application/json"},
+		{name: "XML preferred", body: xmlSample, contentType: "application/xml", accept: "application/json;q=0.5, application/xml", status: http.StatusOK, expected: "application/xml"},
+		{name: "JSON preferred", body: jsonSample, contentType: "application/json", accept: "application/xml;q=0.2, application/*;q=0.8", status: http.StatusOK, expected: "application/json"},
+		{name: "not acceptable", body: jsonSample, contentType: "application/json", accept: "text/html", status: http.StatusNotAcceptable},
+		{name: "invalid XML", body: []byte("<environment"), contentType: "application/xml", status: http.StatusBadRequest},
+		{name: "XML sent as JSON", body: xmlSample, contentType: "application/json", status: http.StatusBadRequest},
+	}
+
+	handler := &api.ConversionHandler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/convert", bytes.NewReader(tt.body))
+			req.Header.Set("Content-Type", tt.contentType)
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			rr := httptest.NewRecorder()
+			handler.Convert(rr, req)
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.expected, rr.Header().Get("Content-Type"))
+			assert.Equal(t, "Accept", rr.Header().Get("Vary"))
+			if tt.expected == "application/xml" {
+				assert.Equal(t, strings.TrimSpace(string(xmlSample)), rr.Body.String())
+			} else {
+				assert.JSONEq(t, string(jsonSample), rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestValidationHandler_XML(t *testing.T) {
+	handler := &api.ValidationHandler{}
+
+	body := `<environment xmlns="https://admin-shell.io/aas/3/0"><submodels><submodel><idShort>1st</idShort><id>urn:sm</id></submodel></submodels></environment>`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/validate", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/xml")
+	rr := httptest.NewRecorder()
+	handler.Validate(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var result api.ValidationResult
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	assert.True(t, hasViolation(result.Violations, "$.submodels[0].idShort", "AASd-002"))
+}
@@ -0,0 +1,61 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/auth"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(auth.CSRF([]string{"https://hub.example.com", "http://localhost:3000"}))
+	router.POST("/packages", func(c *gin.Context) { c.Status(http.StatusCreated) })
+	router.GET("/packages", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	tests := []struct {
+		name    string
+		method  string
+		headers map[string]string
+		cookie  bool
+		status  int
+	}{
+		{name: "safe method", method: http.MethodGet, headers: map[string]string{"Origin": "https://evil.example.com"}, cookie: true, status: http.StatusOK},
+		{name: "trusted origin", method: http.MethodPost, headers: map[string]string{"Origin": "http://localhost:3000"}, cookie: true, status: http.StatusCreated},
+		{name: "foreign origin", method: http.MethodPost, headers: map[string]string{"Origin": "https://evil.example.com"}, cookie: true, status: http.StatusForbidden},
+		{name: "foreign origin without cookie", method: http.MethodPost, headers: map[string]string{"Origin": "https://evil.example.com"}, status: http.StatusForbidden},
+		{name: "opaque origin", method: http.MethodPost, headers: map[string]string{"Origin": "null"}, cookie: true, status: http.StatusForbidden},
+		{name: "cookie with trusted referer", method: http.MethodPost, headers: map[string]string{"Referer": "https://hub.example.com/upload?step=2"}, cookie: true, status: http.StatusCreated},
+		{name: "cookie with foreign referer", method: http.MethodPost, headers: map[string]string{"Referer": "https://evil.example.com/"}, cookie: true, status: http.StatusForbidden},
+		{name: "cookie without origin", method: http.MethodPost, cookie: true, status: http.StatusForbidden},
+		{name: "bearer token without origin", method: http.MethodPost, headers: map[string]string{"Authorization": "Bearer abc"}, cookie: true, status: http.StatusCreated},
+		{name: "api client without cookie", method: http.MethodPost, status: http.StatusCreated},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/packages", nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			if tt.cookie {
+				req.AddCookie(&http.Cookie{Name: auth.TokenCookie, Value: "jwt"})
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			assert.Equal(t, tt.status, rr.Code)
+		})
+	}
+}
+
+func TestOrigin(t *testing.T) {
+	assert.Equal(t, "https://hub.example.com", auth.Origin("https://hub.example.com/api/v1"))
+	assert.Equal(t, "http://localhost:9000", auth.Origin("http://localhost:9000"))
+	assert.Equal(t, "", auth.Origin("/relative/path"))
+}
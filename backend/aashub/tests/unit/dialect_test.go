@@ -0,0 +1,112 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/aas-hub-org/aashub/internal/database"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDialectUpsert(t *testing.T) {
+	columns := []string{"email", "verification_code", "verified"}
+
+	assert.Equal(t,
+		"INSERT INTO Verifications (email, verification_code, verified) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE verification_code = VALUES(verification_code), verified = VALUES(verified)",
+		database.MySQL{}.Upsert("Verifications", columns, []string{"email"}))
+	assert.Equal(t,
+		"INSERT INTO Verifications (email, verification_code, verified) VALUES (?, ?, ?) ON CONFLICT (email) DO UPDATE SET verification_code = excluded.verification_code, verified = excluded.verified",
+		database.SQLite{}.Upsert("Verifications", columns, []string{"email"}))
+}
+
+func TestDialectIsDuplicateKey(t *testing.T) {
+	assert.True(t, database.MySQL{}.IsDuplicateKey(&mysql.MySQLError{Number: 1062}))
+	assert.False(t, database.MySQL{}.IsDuplicateKey(errors.New("boom")))
+	assert.False(t, database.MySQL{}.IsDuplicateKey(nil))
+	assert.True(t, database.Postgres{}.IsDuplicateKey(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, database.Postgres{}.IsDuplicateKey(&pgconn.PgError{Code: "23503"}))
+}
+
+func TestNewDB_SQLite(t *testing.T) {
+	cfg := config.Default().Database
+	cfg.Driver = "sqlite"
+	cfg.DSN = filepath.Join(t.TempDir(), "aashub.db")
+
+	db, err := database.NewDB(cfg)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT NOT NULL)")
+	assert.NoError(t, err)
+
+	upsert := db.Dialect.Upsert("items", []string{"id", "name"}, []string{"id"})
+	_, err = db.ExecContext(ctx, upsert, 1, "first")
+	assert.NoError(t, err)
+	_, err = db.ExecContext(ctx, upsert, 1, "second")
+	assert.NoError(t, err)
+
+	var name string
+	assert.NoError(t, db.QueryRowContext(ctx, "SELECT name FROM items WHERE id = ?", 1).Scan(&name))
+	assert.Equal(t, "second", name)
+
+	_, err = db.ExecContext(ctx, "INSERT INTO items (id, name) VALUES (?, ?)", 1, "third")
+	assert.True(t, db.Dialect.IsDuplicateKey(err))
+}
+
+// TestSQLiteLock opens the same file through two pools, as "aashub migrate"
+// and a running server would, and checks that only one holds the lock.
+func TestSQLiteLock(t *testing.T) {
+	cfg := config.Default().Database
+	cfg.Driver = "sqlite"
+	cfg.DSN = filepath.Join(t.TempDir(), "aashub.db") + "?_pragma=busy_timeout(10)"
+
+	ctx := context.Background()
+	var conns []*sql.Conn
+	for i := 0; i < 2; i++ {
+		db, err := database.NewDB(cfg)
+		assert.NoError(t, err)
+		defer db.Close()
+		conn, err := db.Conn(ctx)
+		assert.NoError(t, err)
+		defer conn.Close()
+		conns = append(conns, conn)
+	}
+
+	dialect := database.SQLite{}
+	assert.NoError(t, dialect.Lock(ctx, conns[0], "migrations", time.Second))
+	assert.ErrorIs(t, dialect.Lock(ctx, conns[1], "migrations", 100*time.Millisecond), database.ErrLockTimeout)
+	assert.NoError(t, dialect.Unlock(ctx, conns[0], "migrations"))
+	assert.NoError(t, dialect.Lock(ctx, conns[1], "migrations", time.Second))
+	assert.NoError(t, dialect.Unlock(ctx, conns[1], "migrations"))
+}
+
+func TestPostgresRebind(t *testing.T) {
+	tests := []struct {
+		query    string
+		expected string
+	}{
+		{"SELECT * FROM Users WHERE username = ? OR email = ?", "SELECT * FROM Users WHERE username = $1 OR email = $2"},
+		{"SELECT '?' FROM t WHERE a = ?", "SELECT '?' FROM t WHERE a = $1"},
+		{`SELECT "col?" FROM t WHERE a = ? AND b = ?`, `SELECT "col?" FROM t WHERE a = $1 AND b = $2`},
+		{"SELECT 1", "SELECT 1"},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.expected, database.Postgres{}.Rebind(tc.query))
+	}
+
+	assert.Equal(t,
+		"INSERT INTO Verifications (email, verification_code, verified) VALUES ($1, $2, $3) ON CONFLICT (email) DO UPDATE SET verification_code = excluded.verification_code, verified = excluded.verified",
+		database.Postgres{}.Rebind(database.Postgres{}.Upsert("Verifications", []string{"email", "verification_code", "verified"}, []string{"email"})))
+}
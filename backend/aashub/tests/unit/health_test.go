@@ -0,0 +1,103 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/health"
+	"github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecker_Run(t *testing.T) {
+	checker := &health.Checker{
+		Checks: map[string]health.Check{
+			"database": func(ctx context.Context) error { return nil },
+			"mail":     func(ctx context.Context) error { return errors.New("connection refused") },
+		},
+	}
+
+	report := checker.Run(context.Background())
+	assert.False(t, report.Ready())
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+	assert.Equal(t, health.StatusUnavailable, report.Checks["mail"].Status)
+
+	// The failure reason is logged, never served
+	body, err := json.Marshal(report)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(body), "connection refused")
+}
+
+func TestChecker_Timeout(t *testing.T) {
+	checker := &health.Checker{
+		Timeout: 10 * time.Millisecond,
+		Checks: map[string]health.Check{
+			"database": func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+		},
+	}
+
+	report := checker.Run(context.Background())
+	assert.Equal(t, health.StatusUnavailable, report.Status)
+	assert.Equal(t, health.StatusUnavailable, report.Checks["database"].Status)
+}
+
+func TestHealthHandler_Readyz(t *testing.T) {
+	checker := &health.Checker{
+		Checks: map[string]health.Check{
+			"database": func(ctx context.Context) error { return nil },
+		},
+	}
+	handler := &api.HealthHandler{Checker: checker}
+
+	rr := httptest.NewRecorder()
+	handler.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var report health.Report
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(t, health.StatusOK, report.Checks["database"].Status)
+
+	// A draining server is not ready although all checks pass
+	checker.Drain()
+	rr = httptest.NewRecorder()
+	handler.Readyz(rr, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &report))
+	assert.Equal(t, health.StatusDraining, report.Status)
+
+	rr = httptest.NewRecorder()
+	handler.Livez(rr, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+}
+
+func TestMailerPing(t *testing.T) {
+	ctx := context.Background()
+
+	assert.NoError(t, mail.Ping(ctx, &mail.MemoryMailer{}))
+	assert.NoError(t, mail.Ping(ctx, &mail.FileMailer{Dir: filepath.Join(t.TempDir(), "mail")}))
+
+	// Grab a free port and close it again so nothing is listening there
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	listener.Close()
+
+	smtpMailer := &mail.SMTPMailer{Host: "127.0.0.1", Port: port, Security: mail.SecurityNone, Timeout: time.Second}
+	assert.ErrorContains(t, mail.Ping(ctx, smtpMailer), "error connecting to SMTP server")
+}
@@ -0,0 +1,104 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/aas-hub-org/aashub/internal/logging"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	t.Helper()
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var record map[string]interface{}
+		assert.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestLogger_RedactsSecrets(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, config.LogConfig{Level: "info", Format: "json"})
+
+	logger.Info("Login", "user", "alice", "password", "hunter2", slog.Group("verification", "Code", "123456"), "token", "eyJhbGciOi")
+
+	output := buf.String()
+	assert.NotContains(t, output, "hunter2")
+	assert.NotContains(t, output, "123456")
+	assert.NotContains(t, output, "eyJhbGciOi")
+
+	record := decodeLogLines(t, &buf)[0]
+	assert.Equal(t, "alice", record["user"])
+	assert.Equal(t, logging.Redacted, record["password"])
+	assert.Equal(t, logging.Redacted, record["verification"].(map[string]interface{})["Code"])
+}
+
+func TestLogger_AddsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := logging.New(&buf, config.LogConfig{Level: "debug", Format: "json"}).With("component", "test")
+
+	logger.DebugContext(logging.WithRequestID(context.Background(), "req-1"), "Handled")
+	logger.InfoContext(context.Background(), "No request")
+
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	spanCtx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+	logger.InfoContext(spanCtx, "Traced")
+
+	records := decodeLogLines(t, &buf)
+	assert.Equal(t, "req-1", records[0]["request_id"])
+	assert.Equal(t, "test", records[0]["component"])
+	assert.NotContains(t, records[1], "request_id")
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", records[2]["trace_id"])
+	assert.Equal(t, "00f067aa0ba902b7", records[2]["span_id"])
+}
+
+func TestRequestIDMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+	logger := logging.New(&buf, config.LogConfig{Level: "info", Format: "json"})
+
+	var seen string
+	router := gin.New()
+	router.Use(logging.RequestIDMiddleware(), logging.AccessLog(logger))
+	router.GET("/verify/", func(c *gin.Context) {
+		seen = logging.RequestID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+
+	// A valid incoming ID is kept
+	req := httptest.NewRequest(http.MethodGet, "/verify/?email=YQ&code=c2VjcmV0", nil)
+	req.Header.Set(logging.RequestIDHeader, "abc-123")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Equal(t, "abc-123", rr.Header().Get(logging.RequestIDHeader))
+	assert.Equal(t, "abc-123", seen)
+
+	record := decodeLogLines(t, &buf)[0]
+	assert.Equal(t, "abc-123", record["request_id"])
+	assert.Equal(t, "/verify/", record["path"])
+	assert.NotContains(t, buf.String(), "c2VjcmV0")
+
+	// Anything else is replaced by a generated ID
+	req = httptest.NewRequest(http.MethodGet, "/verify/", nil)
+	req.Header.Set(logging.RequestIDHeader, "bad id\nwith a line break")
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	assert.Len(t, rr.Header().Get(logging.RequestIDHeader), 36)
+	assert.Equal(t, rr.Header().Get(logging.RequestIDHeader), seen)
+}
@@ -0,0 +1,162 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockAssetLinkRepository keeps the asset ids linked to shell ids in memory,
+// ordered by shell id. The owners of stored shells are kept in shells.
+type MockAssetLinkRepository struct {
+	links  map[string][]aas.SpecificAssetId
+	owners map[string]string
+	shells map[string]string
+}
+
+func NewMockAssetLinkRepository() *MockAssetLinkRepository {
+	return &MockAssetLinkRepository{links: map[string][]aas.SpecificAssetId{}, owners: map[string]string{}, shells: map[string]string{}}
+}
+
+func (m *MockAssetLinkRepository) LookupShells(ctx context.Context, assetIds []aas.SpecificAssetId, cursor string, limit int) ([]string, string, error) {
+	var ids []string
+	for id, links := range m.links {
+		matches := id > cursor
+		for _, assetId := range assetIds {
+			found := false
+			for _, link := range links {
+				found = found || link.Name == assetId.Name && link.Value == assetId.Value
+			}
+			matches = matches && found
+		}
+		if matches {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	if len(ids) > limit {
+		return ids[:limit], ids[limit-1], nil
+	}
+	return append([]string{}, ids...), "", nil
+}
+
+func (m *MockAssetLinkRepository) GetAssetLinks(ctx context.Context, aasID string) ([]aas.SpecificAssetId, error) {
+	links, ok := m.links[aasID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, aasID)
+	}
+	return links, nil
+}
+
+func (m *MockAssetLinkRepository) CreateAssetLinks(ctx context.Context, ownerID string, aasID string, assetIds []aas.SpecificAssetId) error {
+	if !m.ownsLinks(ownerID, aasID) {
+		return repositories.ErrNotOwner
+	}
+	m.links[aasID] = assetIds
+	m.owners[aasID] = ownerID
+	return nil
+}
+
+func (m *MockAssetLinkRepository) DeleteAssetLinks(ctx context.Context, ownerID string, aasID string) error {
+	if _, err := m.GetAssetLinks(ctx, aasID); err != nil {
+		return err
+	}
+	if !m.ownsLinks(ownerID, aasID) {
+		return repositories.ErrNotOwner
+	}
+	delete(m.links, aasID)
+	delete(m.owners, aasID)
+	return nil
+}
+
+func (m *MockAssetLinkRepository) ownsLinks(ownerID string, aasID string) bool {
+	if owner, ok := m.shells[aasID]; ok {
+		return owner == ownerID
+	}
+	owner, ok := m.owners[aasID]
+	return !ok || owner == ownerID
+}
+
+func newLookupRouter(repo *MockAssetLinkRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := &api.LookupHandler{Repo: repo}
+	r := gin.New()
+	lg := r.Group("/api/v1/lookup/shells", withTestUser)
+	lg.GET("", gin.WrapF(handler.GetShellIdsByAssetLink))
+	lg.GET("/:aasIdentifier", api.WithPathValues(handler.GetAssetLinks))
+	lg.POST("/:aasIdentifier", api.WithPathValues(handler.PostAssetLinks))
+	lg.DELETE("/:aasIdentifier", api.WithPathValues(handler.DeleteAssetLinks))
+	return r
+}
+
+func TestLookupAPI(t *testing.T) {
+	router := newLookupRouter(NewMockAssetLinkRepository())
+	pump := "/api/v1/lookup/shells/" + encodeID("urn:aas:pump")
+	valve := "/api/v1/lookup/shells/" + encodeID("urn:aas:valve")
+
+	links := `[{"name":"serialNumber","value":"4711"},{"name":"manufacturer","value":"ACME"}]`
+	rr := serve(router, http.MethodPost, pump, links, testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.JSONEq(t, links, rr.Body.String())
+	assert.Equal(t, http.StatusCreated, serve(router, http.MethodPost, valve, `[{"name":"serialNumber","value":"0815"},{"name":"manufacturer","value":"ACME"}]`, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, pump, links).Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPost, pump, links, testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, pump, `[{"name":"serialNumber","value":""}]`, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, pump, `{"name":"serialNumber"}`, testUserHeader, "alice").Code)
+
+	rr = serve(router, http.MethodGet, pump, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, links, rr.Body.String())
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/api/v1/lookup/shells/"+encodeID("urn:aas:unknown"), "").Code)
+
+	lookup := func(query string) []string {
+		var page api.GetShellIdsResult
+		rr := serve(router, http.MethodGet, "/api/v1/lookup/shells"+query, "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		return page.Result
+	}
+	serial := "assetIds=" + encodeID(`{"name":"serialNumber","value":"4711"}`)
+	acme := "assetIds=" + encodeID(`{"name":"manufacturer","value":"ACME"}`)
+	assert.Equal(t, []string{"urn:aas:pump"}, lookup("?"+serial))
+	assert.Equal(t, []string{"urn:aas:pump", "urn:aas:valve"}, lookup("?"+acme))
+	assert.Equal(t, []string{"urn:aas:pump"}, lookup("?"+serial+"&"+acme))
+	assert.Empty(t, lookup("?assetIds="+encodeID(`{"name":"serialNumber","value":"9999"}`)))
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/lookup/shells?assetIds="+encodeID(`{"name":"serialNumber"}`), "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/lookup/shells?assetIds=%25", "").Code)
+
+	var page api.GetShellIdsResult
+	rr = serve(router, http.MethodGet, "/api/v1/lookup/shells?limit=1&"+acme, "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Equal(t, []string{"urn:aas:pump"}, page.Result)
+	assert.NotEmpty(t, page.PagingMetadata.Cursor)
+
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, pump, "", testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, pump, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodDelete, pump, "", testUserHeader, "alice").Code)
+	assert.Empty(t, lookup("?"+serial))
+}
+
+func TestLookupAPI_StoredShellOwner(t *testing.T) {
+	repo := NewMockAssetLinkRepository()
+	repo.shells["urn:aas:pump"] = "alice"
+	router := newLookupRouter(repo)
+	pump := "/api/v1/lookup/shells/" + encodeID("urn:aas:pump")
+	links := `[{"name":"serialNumber","value":"4711"}]`
+
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPost, pump, links, testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, pump, "").Code)
+	assert.Equal(t, http.StatusCreated, serve(router, http.MethodPost, pump, links, testUserHeader, "alice").Code)
+}
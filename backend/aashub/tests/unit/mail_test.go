@@ -0,0 +1,226 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	stdmail "net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryMailer_RecordsMessages(t *testing.T) {
+	mailer := &mail.MemoryMailer{From: "noreply@example.com"}
+
+	err := mailer.Send(context.Background(), &mail.Message{To: "test@example.com", Subject: "Verification Code", HTML: "<p>Hello</p>"})
+	assert.NoError(t, err)
+
+	messages := mailer.Messages()
+	assert.Len(t, messages, 1)
+	assert.Equal(t, "noreply@example.com", messages[0].From)
+	assert.Equal(t, "test@example.com", messages[0].To)
+	assert.Equal(t, "Verification Code", messages[0].Subject)
+
+	mailer.Reset()
+	assert.Empty(t, mailer.Messages())
+
+	err = mailer.Send(context.Background(), &mail.Message{To: "test@example.com\r\nBcc: victim@example.com", Subject: "Verification Code", HTML: "<p>Hello</p>"})
+	assert.ErrorIs(t, err, mail.ErrInvalidAddress)
+	assert.Empty(t, mailer.Messages())
+}
+
+func TestFileMailer_WritesMaildir(t *testing.T) {
+	dir := t.TempDir()
+	mailer := &mail.FileMailer{Dir: dir, From: "noreply@example.com"}
+
+	message := &mail.Message{To: "test@example.com", Subject: "Verification Code", HTML: "<p>Hello</p>"}
+	err := mailer.Send(context.Background(), message)
+	assert.NoError(t, err)
+	assert.Empty(t, message.From, "the sender must not be written back into the message")
+
+	entries, err := os.ReadDir(filepath.Join(dir, "new"))
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	content, err := os.ReadFile(filepath.Join(dir, "new", entries[0].Name()))
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(content), "To: <test@example.com>\r\n"))
+	assert.True(t, strings.Contains(string(content), "Content-Type: text/html"))
+	assert.True(t, strings.HasSuffix(string(content), "<p>Hello</p>"))
+}
+
+func TestNewMailer(t *testing.T) {
+	tests := []struct {
+		transport string
+		expected  interface{}
+		security  mail.Security
+	}{
+		{transport: "tls", expected: &mail.SMTPMailer{}, security: mail.SecurityTLS},
+		{transport: "starttls", expected: &mail.SMTPMailer{}, security: mail.SecuritySTARTTLS},
+		{transport: "plain", expected: &mail.SMTPMailer{}, security: mail.SecurityNone},
+		{transport: "file", expected: &mail.FileMailer{}},
+		{transport: "memory", expected: &mail.MemoryMailer{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.transport, func(t *testing.T) {
+			mailer, err := mail.NewMailer(config.MailConfig{Transport: tc.transport, Address: "noreply@example.com"})
+			assert.NoError(t, err)
+			assert.IsType(t, tc.expected, mailer)
+			if smtpMailer, ok := mailer.(*mail.SMTPMailer); ok {
+				assert.Equal(t, tc.security, smtpMailer.Security)
+				assert.Equal(t, "noreply@example.com", smtpMailer.Username)
+				assert.False(t, smtpMailer.InsecureSkipVerify)
+			}
+		})
+	}
+
+	t.Run("unknown", func(t *testing.T) {
+		_, err := mail.NewMailer(config.MailConfig{Transport: "carrier-pigeon"})
+		assert.Error(t, err)
+	})
+}
+
+func TestRender_Localized(t *testing.T) {
+	data := mail.VerificationData{Email: "test@example.com", Link: "https://hub.example.com/verify?email=a&code=b"}
+
+	english, err := mail.Render(mail.TemplateVerification, "en-US,en;q=0.9", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Verify your AAS Hub account", english.Subject)
+	assert.Contains(t, english.Text, data.Link)
+	assert.Contains(t, english.HTML, `href="https://hub.example.com/verify?email=a&amp;code=b"`)
+
+	german, err := mail.Render(mail.TemplateVerification, "de-DE", data)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bestätigen Sie Ihr AAS-Hub-Konto", german.Subject)
+
+	fallback, err := mail.Render(mail.TemplateVerification, "fr", data)
+	assert.NoError(t, err)
+	assert.Equal(t, english.Subject, fallback.Subject)
+
+	for _, name := range []string{mail.TemplatePasswordReset, mail.TemplateNotification} {
+		for _, locale := range mail.Locales {
+			_, err := mail.Render(name, locale, mail.NotificationData{Username: "test", Title: "t", Message: "m", Link: "l"})
+			assert.NoError(t, err, "%s/%s", locale, name)
+		}
+	}
+}
+
+func TestMessage_Multipart(t *testing.T) {
+	message := &mail.Message{
+		From:    "AAS Hub <noreply@example.com>",
+		To:      "test@example.com",
+		Subject: "Bestätigung",
+		Text:    "Hallo",
+		HTML:    "<p>Hallo</p>",
+	}
+	data, err := message.Bytes()
+	assert.NoError(t, err)
+
+	parsed, err := stdmail.ReadMessage(strings.NewReader(string(data)))
+	assert.NoError(t, err)
+
+	subject, err := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Bestätigung", subject)
+	assert.NotEmpty(t, parsed.Header.Get("Date"))
+	assert.True(t, strings.HasSuffix(parsed.Header.Get("Message-ID"), "@example.com>"))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var contentTypes []string
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		contentTypes = append(contentTypes, part.Header.Get("Content-Type"))
+	}
+	assert.Equal(t, []string{`text/plain; charset="UTF-8"`, `text/html; charset="UTF-8"`}, contentTypes)
+}
+
+func TestMessage_RejectsHeaderInjection(t *testing.T) {
+	tests := []struct {
+		name    string
+		message mail.Message
+	}{
+		{name: "CRLF in recipient", message: mail.Message{From: "noreply@example.com", To: "a@example.com\r\nBcc: b@example.com", HTML: "x"}},
+		{name: "LF in recipient", message: mail.Message{From: "noreply@example.com", To: "a@example.com\nBcc: b@example.com", HTML: "x"}},
+		{name: "recipient list", message: mail.Message{From: "noreply@example.com", To: "a@example.com, b@example.com", HTML: "x"}},
+		{name: "missing domain", message: mail.Message{From: "noreply@example.com", To: "a@", HTML: "x"}},
+		{name: "CRLF in sender", message: mail.Message{From: "noreply@example.com\r\nX-Evil: 1", To: "a@example.com", HTML: "x"}},
+		{name: "CRLF in message id", message: mail.Message{From: "noreply@example.com", To: "a@example.com", MessageID: "<a@b>\r\nX-Evil: 1", HTML: "x"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.message.Bytes()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMessage_EncodesSubjectLineBreaks(t *testing.T) {
+	message := &mail.Message{From: "noreply@example.com", To: "a@example.com", Subject: "Hi\r\nBcc: b@example.com", Text: "x"}
+	data, err := message.Bytes()
+	assert.NoError(t, err)
+
+	parsed, err := stdmail.ReadMessage(strings.NewReader(string(data)))
+	assert.NoError(t, err)
+	assert.Empty(t, parsed.Header.Get("Bcc"))
+}
+
+func TestValidateAddress(t *testing.T) {
+	assert.NoError(t, mail.ValidateAddress("test@example.com"))
+	assert.ErrorIs(t, mail.ValidateAddress("Test <test@example.com>"), mail.ErrInvalidAddress)
+	assert.ErrorIs(t, mail.ValidateAddress("test"), mail.ErrInvalidAddress)
+}
+
+func FuzzMessageBytes(f *testing.F) {
+	f.Add("test@example.com", "Verification Code", "<p>Hello</p>")
+	f.Add("Jürgen <j@example.com>", "Bestätigung", "Hallo")
+	f.Add("a@example.com\r\nBcc: b@example.com", "Hi", "x")
+	f.Add("a@example.com", "Hi\r\nBcc: b@example.com", "x")
+
+	f.Fuzz(func(t *testing.T, to, subject, body string) {
+		message := &mail.Message{From: "noreply@example.com", To: to, Subject: subject, Text: body, HTML: body}
+		data, err := message.Bytes()
+		if err != nil {
+			return
+		}
+
+		// Whatever was accepted must parse back without extra headers
+		parsed, err := stdmail.ReadMessage(strings.NewReader(string(data)))
+		if err != nil {
+			t.Fatalf("rendered message does not parse: %v", err)
+		}
+		for name := range parsed.Header {
+			switch name {
+			case "From", "To", "Subject", "Date", "Message-Id", "Mime-Version", "Content-Type", "Content-Transfer-Encoding":
+			default:
+				t.Fatalf("unexpected header %q", name)
+			}
+		}
+		recipients, err := parsed.Header.AddressList("To")
+		if err != nil || len(recipients) != 1 {
+			t.Fatalf("expected exactly one recipient, got %v (%v)", recipients, err)
+		}
+		if _, expected, _ := message.Envelope(); recipients[0].Address != expected {
+			t.Fatalf("recipient changed from %q to %q", expected, recipients[0].Address)
+		}
+	})
+}
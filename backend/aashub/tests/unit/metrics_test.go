@@ -0,0 +1,95 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/aas-hub-org/aashub/internal/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics_Middleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	m := metrics.New()
+	router := gin.New()
+	router.Use(m.Middleware())
+	router.GET("/items/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+
+	for _, path := range []string{"/items/1", "/items/2", "/unknown"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, rr.Code)
+
+	body := rr.Body.String()
+	assert.Contains(t, body, `aashub_http_requests_total{method="GET",route="/items/:id",status="204"} 2`)
+	assert.Contains(t, body, `aashub_http_requests_total{method="GET",route="unmatched",status="404"} 1`)
+	assert.Contains(t, body, `aashub_http_request_duration_seconds_count{method="GET",route="/items/:id",status="204"} 2`)
+}
+
+func TestMetrics_Handlers(t *testing.T) {
+	m := metrics.New()
+	handler := api.UserHandler{Repo: &MockRepository{}, Metrics: m}
+
+	register := func(user api.APIUser) {
+		body, _ := json.Marshal(user)
+		handler.RegisterUser(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(body)))
+	}
+	register(api.APIUser{Username: "testUser", Email: "test@example.com", Password: "password123"})
+	register(api.APIUser{Username: "testUser", Email: "not an address", Password: "password123"})
+
+	rr := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	body := rr.Body.String()
+	assert.Contains(t, body, `aashub_registrations_total{result="success"} 1`)
+	assert.Contains(t, body, `aashub_registrations_total{result="invalid"} 1`)
+	assert.Contains(t, body, `aashub_registrations_total{result="conflict"} 0`)
+
+	// A nil *Metrics is allowed and records nothing
+	handler = api.UserHandler{Repo: &MockRepository{}}
+	body2, _ := json.Marshal(api.APIUser{Username: "testUser", Email: "test@example.com", Password: "password123"})
+	rr = httptest.NewRecorder()
+	handler.RegisterUser(rr, httptest.NewRequest(http.MethodPost, "/users/register", bytes.NewBuffer(body2)))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+}
+
+type failingMailer struct{}
+
+func (failingMailer) Send(ctx context.Context, message *mail.Message) error {
+	return errors.New("connection refused")
+}
+
+func TestMetrics_Mailer(t *testing.T) {
+	m := metrics.New()
+	ok := &metrics.Mailer{Mailer: &mail.MemoryMailer{From: "noreply@example.com"}, Metrics: m}
+	failing := &metrics.Mailer{Mailer: failingMailer{}, Metrics: m}
+
+	message := &mail.Message{To: "test@example.com", Subject: "Hello", Text: "Hello"}
+	assert.NoError(t, ok.Send(context.Background(), message))
+	assert.Error(t, failing.Send(context.Background(), message))
+	assert.Error(t, failing.Send(context.Background(), message))
+
+	expected := `
+# HELP aashub_mails_sent_total E-mails handed to the mail transport by result (success, failure).
+# TYPE aashub_mails_sent_total counter
+aashub_mails_sent_total{result="failure"} 2
+aashub_mails_sent_total{result="success"} 1
+`
+	assert.NoError(t, testutil.GatherAndCompare(m.Registry, strings.NewReader(expected), "aashub_mails_sent_total"))
+}
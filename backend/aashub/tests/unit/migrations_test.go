@@ -0,0 +1,90 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/aas-hub-org/aashub/internal/database"
+	"github.com/aas-hub-org/aashub/internal/database/migrations"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	var versions [][]int
+	for _, dialect := range []string{database.DialectMySQL, database.DialectSQLite, database.DialectPostgres} {
+		loaded, err := migrations.Load(dialect)
+		assert.NoError(t, err, dialect)
+		assert.NotEmpty(t, loaded, dialect)
+		assert.Equal(t, "initial", loaded[0].Name)
+		assert.Contains(t, loaded[0].Up, "CREATE TABLE IF NOT EXISTS Users")
+
+		var dialectVersions []int
+		for _, migration := range loaded {
+			dialectVersions = append(dialectVersions, migration.Version)
+		}
+		versions = append(versions, dialectVersions)
+	}
+
+	// Every dialect must offer the same schema versions
+	for _, dialectVersions := range versions[1:] {
+		assert.Equal(t, versions[0], dialectVersions)
+	}
+
+	_, err := migrations.Load("oracle")
+	assert.Error(t, err)
+}
+
+func TestParseMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0002_add_roles.up.sql":   {Data: []byte("ALTER TABLE Users ADD role VARCHAR(32);")},
+		"0002_add_roles.down.sql": {Data: []byte("ALTER TABLE Users DROP role;")},
+		"0001_initial.up.sql":     {Data: []byte("CREATE TABLE a (id INT);")},
+		"0001_initial.down.sql":   {Data: []byte("DROP TABLE a;")},
+	}
+
+	parsed, err := migrations.Parse(fsys)
+	assert.NoError(t, err)
+	assert.Len(t, parsed, 2)
+	assert.Equal(t, 1, parsed[0].Version)
+	assert.Equal(t, "add_roles", parsed[1].Name)
+}
+
+func TestParseMigrations_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		fsys fstest.MapFS
+	}{
+		{name: "gap", fsys: fstest.MapFS{
+			"0002_b.up.sql":   {Data: []byte("SELECT 1;")},
+			"0002_b.down.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{name: "missing down", fsys: fstest.MapFS{
+			"0001_a.up.sql": {Data: []byte("SELECT 1;")},
+		}},
+		{name: "bad name", fsys: fstest.MapFS{
+			"initial.sql": {Data: []byte("SELECT 1;")},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := migrations.Parse(tc.fsys)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestStatements(t *testing.T) {
+	script := `
+-- create the tables
+CREATE TABLE a (
+    id INT
+);
+
+DROP TABLE b;
+`
+	assert.Equal(t, []string{"CREATE TABLE a (\n    id INT\n);", "DROP TABLE b;"}, migrations.Statements(script))
+}
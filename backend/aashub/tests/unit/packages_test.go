@@ -0,0 +1,253 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/aasx"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	models "github.com/aas-hub-org/aashub/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockPackageRepository records the last created package, from which the
+// other methods read, and fails with Err if it is set.
+type MockPackageRepository struct {
+	Created *aasx.Package
+	Content []byte
+	Err     error
+}
+
+func (m *MockPackageRepository) CreatePackage(ctx context.Context, ownerID string, filename string, content []byte, pkg *aasx.Package) (*models.Package, error) {
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	m.Created = pkg
+	m.Content = content
+	stored := &models.Package{ID: "pkg-1", OwnerID: ownerID, Filename: filename, Size: int64(len(content)), CreatedAt: time.Now()}
+	for _, shell := range pkg.Environment.AssetAdministrationShells {
+		stored.Shells = append(stored.Shells, shell.Id)
+	}
+	for _, submodel := range pkg.Environment.Submodels {
+		stored.Submodels = append(stored.Submodels, submodel.Id)
+	}
+	return stored, nil
+}
+
+func (m *MockPackageRepository) GetPackage(ctx context.Context, id string) (*models.Package, error) {
+	if id != "pkg-1" {
+		return nil, repositories.ErrPackageNotFound
+	}
+	return &models.Package{ID: id, Filename: "example.aasx"}, nil
+}
+
+func (m *MockPackageRepository) GetPackageContent(ctx context.Context, id string) (*models.Package, []byte, error) {
+	if id != "pkg-1" || m.Content == nil {
+		return nil, nil, repositories.ErrPackageNotFound
+	}
+	return &models.Package{ID: id, Filename: "example.aasx"}, m.Content, nil
+}
+
+func (m *MockPackageRepository) GetPackageFile(ctx context.Context, packageID string, path string) (*aasx.File, error) {
+	if packageID == "pkg-1" && m.Created != nil {
+		files := m.Created.Supplementary
+		if m.Created.Thumbnail != nil {
+			files = append(files, *m.Created.Thumbnail)
+		}
+		for _, file := range files {
+			if file.Path == path {
+				return &file, nil
+			}
+		}
+	}
+	return nil, repositories.ErrPackageFileNotFound
+}
+
+func (m *MockPackageRepository) GetEnvironment(ctx context.Context, shellIDs []string, submodelIDs []string) (*aas.Environment, error) {
+	env := &aas.Environment{}
+	for _, id := range shellIDs {
+		found := false
+		for _, shell := range m.Created.Environment.AssetAdministrationShells {
+			if shell.Id == id {
+				env.AssetAdministrationShells = append(env.AssetAdministrationShells, shell)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+		}
+	}
+	for _, id := range submodelIDs {
+		found := false
+		for _, submodel := range m.Created.Environment.Submodels {
+			if submodel.Id == id {
+				env.Submodels = append(env.Submodels, submodel)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+		}
+	}
+	return env, nil
+}
+
+func uploadRequest(t *testing.T, filename string, content []byte, userID string) *http.Request {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", filename)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, form.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/packages", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	if userID != "" {
+		req = req.WithContext(auth.WithUserID(req.Context(), userID))
+	}
+	return req
+}
+
+func TestUploadPackage(t *testing.T) {
+	valid := writeZip(t, testPackageParts(t))
+	invalidParts := testPackageParts(t)
+	invalidParts["aasx/xml/content.xml"] = `<environment xmlns="https://admin-shell.io/aas/3/0"><submodels><submodel><idShort>1st</idShort><id>urn:sm</id></submodel></submodels></environment>`
+	invalid := writeZip(t, invalidParts)
+
+	tests := []struct {
+		name    string
+		content []byte
+		userID  string
+		repoErr error
+		status  int
+	}{
+		{name: "stored", content: valid, userID: "user-1", status: http.StatusCreated},
+		{name: "not logged in", content: valid, status: http.StatusUnauthorized},
+		{name: "not a package", content: []byte("hello"), userID: "user-1", status: http.StatusBadRequest},
+		{name: "constraint violations", content: invalid, userID: "user-1", status: http.StatusUnprocessableEntity},
+		{name: "existing ids", content: valid, userID: "user-1", repoErr: repositories.ErrIdentifiableExists, status: http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &MockPackageRepository{Err: tt.repoErr}
+			handler := &api.PackageHandler{Repo: repo}
+			rr := httptest.NewRecorder()
+			handler.UploadPackage(rr, uploadRequest(t, "dir/example.aasx", tt.content, tt.userID))
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+
+			switch tt.status {
+			case http.StatusCreated:
+				assert.Equal(t, "/api/v1/packages/pkg-1", rr.Header().Get("Location"))
+				var stored api.APIPackage
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &stored))
+				assert.Equal(t, "example.aasx", stored.Filename)
+				assert.Len(t, stored.Shells, 1)
+				assert.Len(t, stored.Submodels, 2)
+				assert.NotNil(t, repo.Created.Thumbnail)
+			case http.StatusUnprocessableEntity:
+				var result api.ValidationResult
+				assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+				assert.True(t, hasViolation(result.Violations, "$.submodels[0].idShort", "AASd-002"))
+			}
+		})
+	}
+}
+
+func TestGetPackage(t *testing.T) {
+	handler := &api.PackageHandler{Repo: &MockPackageRepository{}}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/packages/pkg-1", nil)
+	req.SetPathValue("id", "pkg-1")
+	rr := httptest.NewRecorder()
+	handler.GetPackage(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"id":"pkg-1","filename":"example.aasx","size":0,"createdAt":"0001-01-01T00:00:00Z","shells":[],"submodels":[],"files":[]}`, rr.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/packages/unknown", nil)
+	req.SetPathValue("id", "unknown")
+	rr = httptest.NewRecorder()
+	handler.GetPackage(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+// uploadedRepository returns a repository holding the package of
+// testPackageParts as pkg-1.
+func uploadedRepository(t *testing.T) *MockPackageRepository {
+	repo := &MockPackageRepository{}
+	rr := httptest.NewRecorder()
+	(&api.PackageHandler{Repo: repo}).UploadPackage(rr, uploadRequest(t, "example.aasx", writeZip(t, testPackageParts(t)), "user-1"))
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	return repo
+}
+
+func TestDownloadPackage(t *testing.T) {
+	repo := uploadedRepository(t)
+	handler := &api.PackageHandler{Repo: repo}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/packages/pkg-1/download", nil)
+	req.SetPathValue("id", "pkg-1")
+	rr := httptest.NewRecorder()
+	handler.DownloadPackage(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, aasx.ContentType, rr.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=example.aasx`, rr.Header().Get("Content-Disposition"))
+	assert.Equal(t, repo.Content, rr.Body.Bytes())
+
+	req = httptest.NewRequest(http.MethodGet, "/api/v1/packages/unknown/download", nil)
+	req.SetPathValue("id", "unknown")
+	rr = httptest.NewRecorder()
+	handler.DownloadPackage(rr, req)
+	assert.Equal(t, http.StatusNotFound, rr.Code)
+}
+
+func TestAssemblePackage(t *testing.T) {
+	repo := uploadedRepository(t)
+	handler := &api.PackageHandler{Repo: repo}
+	shell := repo.Created.Environment.AssetAdministrationShells[0].Id
+	submodel := repo.Created.Environment.Submodels[1].Id
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "assembled", body: fmt.Sprintf(`{"filename":"pump","shells":[%q],"submodels":[%q],"attachments":[{"packageId":"pkg-1","path":"/aasx/files/manual.pdf"}],"thumbnail":{"packageId":"pkg-1","path":"/thumbnail.png"}}`, shell, submodel), status: http.StatusOK},
+		{name: "nothing selected", body: `{"shells":[]}`, status: http.StatusBadRequest},
+		{name: "invalid body", body: `{"shells":`, status: http.StatusBadRequest},
+		{name: "unknown shell", body: `{"shells":["urn:unknown"]}`, status: http.StatusNotFound},
+		{name: "unknown attachment", body: fmt.Sprintf(`{"shells":[%q],"attachments":[{"packageId":"pkg-1","path":"/missing.pdf"}]}`, shell), status: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/packages/assemble", bytes.NewBufferString(tt.body))
+			rr := httptest.NewRecorder()
+			handler.AssemblePackage(rr, req)
+			assert.Equal(t, tt.status, rr.Code, rr.Body.String())
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, `attachment; filename=pump.aasx`, rr.Header().Get("Content-Disposition"))
+			pkg, err := aasx.Read(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+			assert.NoError(t, err)
+			assert.Equal(t, repo.Created.Environment.AssetAdministrationShells, pkg.Environment.AssetAdministrationShells)
+			assert.Equal(t, repo.Created.Environment.Submodels[1:], pkg.Environment.Submodels)
+			assert.Equal(t, repo.Created.Supplementary, pkg.Supplementary)
+			assert.Equal(t, repo.Created.Thumbnail, pkg.Thumbnail)
+		})
+	}
+}
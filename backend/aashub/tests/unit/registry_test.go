@@ -0,0 +1,326 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockShellDescriptorRepository keeps shell descriptors in memory as JSON,
+// ordered by id, so that failed updates leave them unchanged.
+type MockShellDescriptorRepository struct {
+	descriptors map[string][]byte
+	owners      map[string]string
+}
+
+func NewMockShellDescriptorRepository() *MockShellDescriptorRepository {
+	return &MockShellDescriptorRepository{descriptors: map[string][]byte{}, owners: map[string]string{}}
+}
+
+func (m *MockShellDescriptorRepository) ListShellDescriptors(ctx context.Context, assetKind aas.AssetKind, assetType string, cursor string, limit int) ([]aas.AssetAdministrationShellDescriptor, string, error) {
+	var ids []string
+	for id := range m.descriptors {
+		descriptor, _ := m.GetShellDescriptor(ctx, id)
+		if id > cursor && (assetKind == "" || descriptor.AssetKind != nil && *descriptor.AssetKind == assetKind) &&
+			(assetType == "" || descriptor.AssetType != nil && *descriptor.AssetType == assetType) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	descriptors := []aas.AssetAdministrationShellDescriptor{}
+	for i, id := range ids {
+		if i == limit {
+			return descriptors, ids[i-1], nil
+		}
+		descriptor, _ := m.GetShellDescriptor(ctx, id)
+		descriptors = append(descriptors, *descriptor)
+	}
+	return descriptors, "", nil
+}
+
+func (m *MockShellDescriptorRepository) GetShellDescriptor(ctx context.Context, id string) (*aas.AssetAdministrationShellDescriptor, error) {
+	data, ok := m.descriptors[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+	}
+	var descriptor aas.AssetAdministrationShellDescriptor
+	return &descriptor, json.Unmarshal(data, &descriptor)
+}
+
+func (m *MockShellDescriptorRepository) CreateShellDescriptor(ctx context.Context, ownerID string, descriptor *aas.AssetAdministrationShellDescriptor) error {
+	if _, ok := m.descriptors[descriptor.Id]; ok {
+		return repositories.ErrIdentifiableExists
+	}
+	m.descriptors[descriptor.Id], _ = json.Marshal(descriptor)
+	m.owners[descriptor.Id] = ownerID
+	return nil
+}
+
+func (m *MockShellDescriptorRepository) UpdateShellDescriptor(ctx context.Context, ownerID string, id string, update func(*aas.AssetAdministrationShellDescriptor) error) error {
+	descriptor, err := m.GetShellDescriptor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	if err := update(descriptor); err != nil {
+		return err
+	}
+	m.descriptors[id], _ = json.Marshal(descriptor)
+	return nil
+}
+
+func (m *MockShellDescriptorRepository) DeleteShellDescriptor(ctx context.Context, ownerID string, id string) error {
+	if _, err := m.GetShellDescriptor(ctx, id); err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	delete(m.descriptors, id)
+	return nil
+}
+
+// MockSubmodelDescriptorRepository keeps submodel descriptors in memory,
+// ordered by id.
+type MockSubmodelDescriptorRepository struct {
+	descriptors map[string]aas.SubmodelDescriptor
+	owners      map[string]string
+}
+
+func NewMockSubmodelDescriptorRepository() *MockSubmodelDescriptorRepository {
+	return &MockSubmodelDescriptorRepository{descriptors: map[string]aas.SubmodelDescriptor{}, owners: map[string]string{}}
+}
+
+func (m *MockSubmodelDescriptorRepository) ListSubmodelDescriptors(ctx context.Context, cursor string, limit int) ([]aas.SubmodelDescriptor, string, error) {
+	var ids []string
+	for id := range m.descriptors {
+		if id > cursor {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	descriptors := []aas.SubmodelDescriptor{}
+	for i, id := range ids {
+		if i == limit {
+			return descriptors, ids[i-1], nil
+		}
+		descriptors = append(descriptors, m.descriptors[id])
+	}
+	return descriptors, "", nil
+}
+
+func (m *MockSubmodelDescriptorRepository) GetSubmodelDescriptor(ctx context.Context, id string) (*aas.SubmodelDescriptor, error) {
+	descriptor, ok := m.descriptors[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+	}
+	return &descriptor, nil
+}
+
+func (m *MockSubmodelDescriptorRepository) CreateSubmodelDescriptor(ctx context.Context, ownerID string, descriptor *aas.SubmodelDescriptor) error {
+	if _, ok := m.descriptors[descriptor.Id]; ok {
+		return repositories.ErrIdentifiableExists
+	}
+	m.descriptors[descriptor.Id] = *descriptor
+	m.owners[descriptor.Id] = ownerID
+	return nil
+}
+
+func (m *MockSubmodelDescriptorRepository) UpdateSubmodelDescriptor(ctx context.Context, ownerID string, id string, update func(*aas.SubmodelDescriptor) error) error {
+	descriptor, err := m.GetSubmodelDescriptor(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	if err := update(descriptor); err != nil {
+		return err
+	}
+	m.descriptors[id] = *descriptor
+	return nil
+}
+
+func (m *MockSubmodelDescriptorRepository) DeleteSubmodelDescriptor(ctx context.Context, ownerID string, id string) error {
+	if _, err := m.GetSubmodelDescriptor(ctx, id); err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	delete(m.descriptors, id)
+	return nil
+}
+
+func newRegistryRouter(shells *MockShellDescriptorRepository, submodels *MockSubmodelDescriptorRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	shellHandler := &api.ShellDescriptorHandler{Repo: shells}
+	submodelHandler := &api.SubmodelDescriptorHandler{Repo: submodels}
+	r := gin.New()
+	sdg := r.Group("/api/v1/shell-descriptors", withTestUser)
+	sdg.GET("", gin.WrapF(shellHandler.GetShellDescriptors))
+	sdg.POST("", gin.WrapF(shellHandler.PostShellDescriptor))
+	sdg.GET("/:aasIdentifier", api.WithPathValues(shellHandler.GetShellDescriptor))
+	sdg.PUT("/:aasIdentifier", api.WithPathValues(shellHandler.PutShellDescriptor))
+	sdg.DELETE("/:aasIdentifier", api.WithPathValues(shellHandler.DeleteShellDescriptor))
+	sdg.GET("/:aasIdentifier/submodel-descriptors", api.WithPathValues(shellHandler.GetSubmodelDescriptors))
+	sdg.POST("/:aasIdentifier/submodel-descriptors", api.WithPathValues(shellHandler.PostSubmodelDescriptor))
+	sdg.GET("/:aasIdentifier/submodel-descriptors/:submodelIdentifier", api.WithPathValues(shellHandler.GetSubmodelDescriptor))
+	sdg.PUT("/:aasIdentifier/submodel-descriptors/:submodelIdentifier", api.WithPathValues(shellHandler.PutSubmodelDescriptor))
+	sdg.DELETE("/:aasIdentifier/submodel-descriptors/:submodelIdentifier", api.WithPathValues(shellHandler.DeleteSubmodelDescriptor))
+	smdg := r.Group("/api/v1/submodel-descriptors", withTestUser)
+	smdg.GET("", gin.WrapF(submodelHandler.GetSubmodelDescriptors))
+	smdg.POST("", gin.WrapF(submodelHandler.PostSubmodelDescriptor))
+	smdg.GET("/:submodelIdentifier", api.WithPathValues(submodelHandler.GetSubmodelDescriptor))
+	smdg.PUT("/:submodelIdentifier", api.WithPathValues(submodelHandler.PutSubmodelDescriptor))
+	smdg.DELETE("/:submodelIdentifier", api.WithPathValues(submodelHandler.DeleteSubmodelDescriptor))
+	return r
+}
+
+// submodelDescriptorJSON is a submodel descriptor served by an edge server.
+func submodelDescriptorJSON(id, href string) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"endpoints": [{"interface": "SUBMODEL-3.0", "protocolInformation": {"href": %q, "endpointProtocol": "HTTP"}}]
+	}`, id, href)
+}
+
+// shellDescriptorJSON is a shell descriptor of an instance of assetType with
+// one submodel descriptor.
+func shellDescriptorJSON(id, assetType string) string {
+	return fmt.Sprintf(`{
+		"id": %q,
+		"idShort": "Pump",
+		"assetKind": "Instance",
+		"assetType": %q,
+		"globalAssetId": "urn:example:asset:pump",
+		"endpoints": [{"interface": "AAS-3.0", "protocolInformation": {"href": "https://edge.example.com/shells/cHVtcA"}}],
+		"submodelDescriptors": [%s]
+	}`, id, assetType, submodelDescriptorJSON(id+":nameplate", "https://edge.example.com/submodels/bmFtZXBsYXRl"))
+}
+
+func TestValidateShellDescriptor(t *testing.T) {
+	var descriptor aas.AssetAdministrationShellDescriptor
+	assert.NoError(t, json.Unmarshal([]byte(shellDescriptorJSON("urn:aas:pump", "urn:type:pump")), &descriptor))
+	assert.Empty(t, aas.ValidateShellDescriptor(&descriptor))
+
+	descriptor.SubmodelDescriptors = append(descriptor.SubmodelDescriptors, descriptor.SubmodelDescriptors[0])
+	descriptor.SubmodelDescriptors[1].Endpoints = nil
+	descriptor.Endpoints[0].ProtocolInformation.SecurityAttributes = []aas.SecurityAttribute{{Type: "BASIC", Key: "k", Value: "v"}}
+	var paths []string
+	for _, violation := range aas.ValidateShellDescriptor(&descriptor) {
+		paths = append(paths, violation.Path)
+	}
+	assert.ElementsMatch(t, []string{
+		"$.endpoints[0].protocolInformation.securityAttributes[0].type",
+		"$.submodelDescriptors[1].endpoints",
+		"$.submodelDescriptors[1].id",
+	}, paths)
+}
+
+func TestShellDescriptorAPI(t *testing.T) {
+	router := newRegistryRouter(NewMockShellDescriptorRepository(), NewMockSubmodelDescriptorRepository())
+	pump := "/api/v1/shell-descriptors/" + encodeID("urn:aas:pump")
+
+	rr := serve(router, http.MethodPost, "/api/v1/shell-descriptors", shellDescriptorJSON("urn:aas:pump", "urn:type:pump"), testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, pump, rr.Header().Get("Location"))
+	assert.Equal(t, http.StatusCreated, serve(router, http.MethodPost, "/api/v1/shell-descriptors", shellDescriptorJSON("urn:aas:valve", "urn:type:valve"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, "/api/v1/shell-descriptors", shellDescriptorJSON("urn:aas:pump", "x"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, "/api/v1/shell-descriptors", shellDescriptorJSON("urn:aas:x", "x")).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, "/api/v1/shell-descriptors", `{"id":"urn:aas:x","assetKind":"Prototype"}`, testUserHeader, "alice").Code)
+
+	rr = serve(router, http.MethodGet, pump, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, shellDescriptorJSON("urn:aas:pump", "urn:type:pump"), rr.Body.String())
+
+	// Filters
+	list := func(query string) []string {
+		var page api.GetShellDescriptorsResult
+		rr := serve(router, http.MethodGet, "/api/v1/shell-descriptors"+query, "")
+		assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+		assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+		var ids []string
+		for _, descriptor := range page.Result {
+			ids = append(ids, descriptor.Id)
+		}
+		return ids
+	}
+	assert.Equal(t, []string{"urn:aas:pump", "urn:aas:valve"}, list("?assetKind=Instance"))
+	assert.Equal(t, []string{"urn:aas:valve"}, list("?assetType="+encodeID("urn:type:valve")))
+	assert.Empty(t, list("?assetKind=Type"))
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/shell-descriptors?assetKind=Prototype", "").Code)
+
+	// Submodel descriptors
+	submodels := pump + "/submodel-descriptors"
+	operational := submodels + "/" + encodeID("urn:aas:pump:operational")
+	rr = serve(router, http.MethodPost, submodels, submodelDescriptorJSON("urn:aas:pump:operational", "https://edge.example.com/submodels/b3A"), testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, operational, rr.Header().Get("Location"))
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, submodels, submodelDescriptorJSON("urn:aas:pump:operational", "https://edge.example.com"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, submodels, `{"id":"urn:aas:pump:x","endpoints":[]}`, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPost, submodels, submodelDescriptorJSON("urn:aas:pump:x", "https://edge.example.com"), testUserHeader, "bob").Code)
+
+	var page api.GetSubmodelDescriptorsResult
+	rr = serve(router, http.MethodGet, submodels+"?limit=1", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Len(t, page.Result, 1)
+	assert.Equal(t, "1", page.PagingMetadata.Cursor)
+
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, operational, submodelDescriptorJSON("urn:aas:pump:operational", "https://edge2.example.com/submodels/b3A"), testUserHeader, "alice").Code)
+	rr = serve(router, http.MethodGet, operational, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, submodelDescriptorJSON("urn:aas:pump:operational", "https://edge2.example.com/submodels/b3A"), rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, operational, submodelDescriptorJSON("urn:aas:pump:other", "https://edge.example.com"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, operational, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, operational, "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodDelete, operational, "", testUserHeader, "alice").Code)
+
+	// Replacing and deleting
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, pump, shellDescriptorJSON("urn:aas:pump", "urn:type:valve"), testUserHeader, "alice").Code)
+	assert.Equal(t, []string{"urn:aas:pump", "urn:aas:valve"}, list("?assetType="+encodeID("urn:type:valve")))
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, pump, shellDescriptorJSON("urn:aas:valve", "x"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPut, pump, shellDescriptorJSON("urn:aas:pump", "x"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, pump, "", testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, pump, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, pump, "").Code)
+}
+
+func TestSubmodelDescriptorAPI(t *testing.T) {
+	router := newRegistryRouter(NewMockShellDescriptorRepository(), NewMockSubmodelDescriptorRepository())
+	nameplate := "/api/v1/submodel-descriptors/" + encodeID("urn:sm:nameplate")
+
+	rr := serve(router, http.MethodPost, "/api/v1/submodel-descriptors", submodelDescriptorJSON("urn:sm:nameplate", "https://edge.example.com/submodels/bmFtZXBsYXRl"), testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, nameplate, rr.Header().Get("Location"))
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, "/api/v1/submodel-descriptors", submodelDescriptorJSON("urn:sm:nameplate", "https://x"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, "/api/v1/submodel-descriptors", `{"id":"urn:sm:x"}`, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, "/api/v1/submodel-descriptors", submodelDescriptorJSON("urn:sm:x", "https://x")).Code)
+
+	var page api.GetSubmodelDescriptorsResult
+	rr = serve(router, http.MethodGet, "/api/v1/submodel-descriptors", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Len(t, page.Result, 1)
+
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, nameplate, submodelDescriptorJSON("urn:sm:nameplate", "https://edge2.example.com"), testUserHeader, "alice").Code)
+	rr = serve(router, http.MethodGet, nameplate, "")
+	assert.JSONEq(t, submodelDescriptorJSON("urn:sm:nameplate", "https://edge2.example.com"), rr.Body.String())
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, nameplate, "", testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, nameplate, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, nameplate, "").Code)
+}
@@ -0,0 +1,235 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/aas-hub-org/aashub/internal/auth"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockShellRepository keeps shells in memory, ordered by id.
+type MockShellRepository struct {
+	shells map[string]aas.AssetAdministrationShell
+	owners map[string]string
+}
+
+func NewMockShellRepository() *MockShellRepository {
+	return &MockShellRepository{shells: map[string]aas.AssetAdministrationShell{}, owners: map[string]string{}}
+}
+
+func (m *MockShellRepository) ListShells(ctx context.Context, idShort string, cursor string, limit int) ([]aas.AssetAdministrationShell, string, error) {
+	var ids []string
+	for id, shell := range m.shells {
+		if id > cursor && (idShort == "" || shell.IdShort != nil && *shell.IdShort == idShort) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	shells := []aas.AssetAdministrationShell{}
+	for i, id := range ids {
+		if i == limit {
+			return shells, ids[i-1], nil
+		}
+		shells = append(shells, m.shells[id])
+	}
+	return shells, "", nil
+}
+
+func (m *MockShellRepository) GetShell(ctx context.Context, id string) (*aas.AssetAdministrationShell, error) {
+	shell, ok := m.shells[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+	}
+	return &shell, nil
+}
+
+func (m *MockShellRepository) CreateShell(ctx context.Context, ownerID string, shell *aas.AssetAdministrationShell) error {
+	if _, ok := m.shells[shell.Id]; ok {
+		return repositories.ErrIdentifiableExists
+	}
+	m.shells[shell.Id] = *shell
+	m.owners[shell.Id] = ownerID
+	return nil
+}
+
+func (m *MockShellRepository) UpdateShell(ctx context.Context, ownerID string, id string, update func(*aas.AssetAdministrationShell) error) error {
+	shell, err := m.GetShell(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	if err := update(shell); err != nil {
+		return err
+	}
+	m.shells[id] = *shell
+	return nil
+}
+
+func (m *MockShellRepository) DeleteShell(ctx context.Context, ownerID string, id string) error {
+	if _, err := m.GetShell(ctx, id); err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	delete(m.shells, id)
+	return nil
+}
+
+// testUserHeader names the user of a request in handler tests, replacing the
+// JWT checked by auth.RequireUser.
+const testUserHeader = "X-Test-User"
+
+func withTestUser(c *gin.Context) {
+	if user := c.GetHeader(testUserHeader); user != "" {
+		c.Request = c.Request.WithContext(auth.WithUserID(c.Request.Context(), user))
+	}
+	c.Next()
+}
+
+func newShellRouter(repo *MockShellRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := &api.ShellHandler{Repo: repo}
+	r := gin.New()
+	sg := r.Group("/api/v1/shells", withTestUser)
+	sg.GET("", gin.WrapF(handler.GetShells))
+	sg.POST("", gin.WrapF(handler.PostShell))
+	sg.GET("/:aasIdentifier", api.WithPathValues(handler.GetShell))
+	sg.PUT("/:aasIdentifier", api.WithPathValues(handler.PutShell))
+	sg.DELETE("/:aasIdentifier", api.WithPathValues(handler.DeleteShell))
+	sg.GET("/:aasIdentifier/asset-information", api.WithPathValues(handler.GetAssetInformation))
+	sg.PUT("/:aasIdentifier/asset-information", api.WithPathValues(handler.PutAssetInformation))
+	sg.GET("/:aasIdentifier/submodel-refs", api.WithPathValues(handler.GetSubmodelRefs))
+	sg.POST("/:aasIdentifier/submodel-refs", api.WithPathValues(handler.PostSubmodelRef))
+	sg.DELETE("/:aasIdentifier/submodel-refs/:submodelIdentifier", api.WithPathValues(handler.DeleteSubmodelRef))
+	return r
+}
+
+// serve sends a request to router. headers are given as name, value pairs.
+func serve(router http.Handler, method, target, body string, headers ...string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for i := 0; i+1 < len(headers); i += 2 {
+		req.Header.Set(headers[i], headers[i+1])
+	}
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	return rr
+}
+
+func encodeID(id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(id))
+}
+
+func shellJSON(id, idShort string) string {
+	return fmt.Sprintf(`{"modelType":"AssetAdministrationShell","id":%q,"idShort":%q,"assetInformation":{"assetKind":"Instance","globalAssetId":"urn:asset:%s"}}`, id, idShort, idShort)
+}
+
+func TestShellAPI(t *testing.T) {
+	router := newShellRouter(NewMockShellRepository())
+	pump := "/api/v1/shells/" + encodeID("urn:aas:pump")
+
+	// Creating shells
+	// A request whose response cannot be written stores nothing
+	assert.Equal(t, http.StatusNotAcceptable, serve(router, http.MethodPost, "/api/v1/shells", shellJSON("urn:aas:pump", "Pump"), testUserHeader, "alice", "Accept", "text/html").Code)
+	rr := serve(router, http.MethodPost, "/api/v1/shells", shellJSON("urn:aas:pump", "Pump"), testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, pump, rr.Header().Get("Location"))
+	assert.Equal(t, http.StatusCreated, serve(router, http.MethodPost, "/api/v1/shells", shellJSON("urn:aas:valve", "Valve"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, "/api/v1/shells", shellJSON("urn:aas:pump", "Pump"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, "/api/v1/shells", shellJSON("urn:aas:fan", "Fan")).Code)
+
+	rr = serve(router, http.MethodPost, "/api/v1/shells", shellJSON("urn:aas:fan", "1st"), testUserHeader, "alice")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	var result api.Result
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	assert.Len(t, result.Messages, 1)
+	assert.Equal(t, "Error", result.Messages[0].MessageType)
+	assert.Contains(t, result.Messages[0].Text, "$.idShort")
+
+	// Reading shells
+	rr = serve(router, http.MethodGet, pump, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, shellJSON("urn:aas:pump", "Pump"), rr.Body.String())
+	rr = serve(router, http.MethodGet, pump, "", "Accept", "application/xml")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `<assetAdministrationShell xmlns="https://admin-shell.io/aas/3/0">`)
+	assert.Equal(t, http.StatusOK, serve(router, http.MethodGet, pump+"==", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, "/api/v1/shells/"+encodeID("urn:aas:unknown"), "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/shells/not*base64", "").Code)
+
+	var page api.GetShellsResult
+	rr = serve(router, http.MethodGet, "/api/v1/shells?limit=1", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Equal(t, "urn:aas:pump", page.Result[0].Id)
+	assert.NotEmpty(t, page.PagingMetadata.Cursor)
+	rr = serve(router, http.MethodGet, "/api/v1/shells?limit=1&cursor="+page.PagingMetadata.Cursor, "")
+	page = api.GetShellsResult{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Equal(t, "urn:aas:valve", page.Result[0].Id)
+	assert.Empty(t, page.PagingMetadata.Cursor)
+	rr = serve(router, http.MethodGet, "/api/v1/shells?idShort=Valve", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Len(t, page.Result, 1)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/shells?limit=0", "").Code)
+
+	// Replacing shells
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, pump, shellJSON("urn:aas:pump", "Pump2"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, pump, shellJSON("urn:aas:valve", "Valve"), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPut, pump, shellJSON("urn:aas:pump", "Pump"), testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPut, pump, shellJSON("urn:aas:pump", "Pump")).Code)
+
+	// Asset information
+	info := `{"assetKind":"Type","globalAssetId":"urn:asset:pump-type"}`
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, pump+"/asset-information", info, testUserHeader, "alice").Code)
+	rr = serve(router, http.MethodGet, pump+"/asset-information", "")
+	assert.JSONEq(t, info, rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, pump+"/asset-information", `{"assetKind":"Type"}`, testUserHeader, "alice").Code)
+
+	// Submodel references
+	for _, id := range []string{"urn:sm:nameplate", "urn:sm:documentation"} {
+		ref := fmt.Sprintf(`{"type":"ModelReference","keys":[{"type":"Submodel","value":%q}]}`, id)
+		rr = serve(router, http.MethodPost, pump+"/submodel-refs", ref, testUserHeader, "alice")
+		assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		assert.JSONEq(t, ref, rr.Body.String())
+	}
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, pump+"/submodel-refs", `{"type":"ModelReference","keys":[{"type":"Submodel","value":"urn:sm:nameplate"}]}`, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotAcceptable, serve(router, http.MethodPost, pump+"/submodel-refs", `{"type":"ModelReference","keys":[{"type":"Submodel","value":"urn:sm:other"}]}`, testUserHeader, "alice", "Accept", "text/html").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, pump+"/submodel-refs", `{"type":"ModelReference","keys":[]}`, testUserHeader, "alice").Code)
+
+	var refs api.GetReferencesResult
+	rr = serve(router, http.MethodGet, pump+"/submodel-refs?limit=1", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &refs))
+	assert.Equal(t, "urn:sm:nameplate", refs.Result[0].Keys[0].Value)
+	assert.Equal(t, "1", refs.PagingMetadata.Cursor)
+	rr = serve(router, http.MethodGet, pump+"/submodel-refs?cursor=1", "")
+	refs = api.GetReferencesResult{}
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &refs))
+	assert.Equal(t, "urn:sm:documentation", refs.Result[0].Keys[0].Value)
+	assert.Empty(t, refs.PagingMetadata.Cursor)
+
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, pump+"/submodel-refs/"+encodeID("urn:sm:nameplate"), "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodDelete, pump+"/submodel-refs/"+encodeID("urn:sm:nameplate"), "", testUserHeader, "alice").Code)
+
+	// Deleting shells
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, pump, "", testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, pump, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, pump, "").Code)
+}
@@ -0,0 +1,298 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// nameplateJSON is a submodel with elements of most types, nested in a
+// collection and a list.
+const nameplateJSON = `{
+	"modelType": "Submodel",
+	"id": "urn:sm:nameplate",
+	"idShort": "Nameplate",
+	"semanticId": {"type": "ExternalReference", "keys": [{"type": "GlobalReference", "value": "https://admin-shell.io/idta/nameplate/2/0/Nameplate"}]},
+	"submodelElements": [
+		{"modelType": "Property", "idShort": "ManufacturerName", "valueType": "xs:string", "value": "ACME"},
+		{"modelType": "Property", "idShort": "YearOfConstruction", "valueType": "xs:int", "value": "2024"},
+		{"modelType": "Property", "idShort": "Certified", "valueType": "xs:boolean", "value": "true"},
+		{"modelType": "MultiLanguageProperty", "idShort": "ProductDesignation", "value": [{"language": "en", "text": "Pump"}]},
+		{"modelType": "Range", "idShort": "Pressure", "valueType": "xs:double", "min": "0.5", "max": "10"},
+		{"modelType": "SubmodelElementCollection", "idShort": "Address", "value": [
+			{"modelType": "Property", "idShort": "City", "valueType": "xs:string", "value": "Berlin"},
+			{"modelType": "Blob", "idShort": "Logo", "contentType": "image/png", "value": "iVBORw=="}
+		]},
+		{"modelType": "SubmodelElementList", "idShort": "Markings", "typeValueListElement": "SubmodelElementCollection", "value": [
+			{"modelType": "SubmodelElementCollection", "value": [
+				{"modelType": "File", "idShort": "Image", "contentType": "image/png", "value": "/aasx/ce.png"}
+			]}
+		]}
+	]
+}`
+
+// MockSubmodelRepository keeps submodels in memory as JSON, so that handlers
+// cannot modify the stored submodels except through UpdateSubmodel.
+type MockSubmodelRepository struct {
+	submodels map[string][]byte
+	owners    map[string]string
+}
+
+func NewMockSubmodelRepository() *MockSubmodelRepository {
+	return &MockSubmodelRepository{submodels: map[string][]byte{}, owners: map[string]string{}}
+}
+
+func (m *MockSubmodelRepository) ListSubmodels(ctx context.Context, idShort string, semanticId string, cursor string, limit int) ([]aas.Submodel, string, error) {
+	var ids []string
+	for id := range m.submodels {
+		submodel, _ := m.GetSubmodel(ctx, id)
+		if id > cursor && (idShort == "" || submodel.IdShort != nil && *submodel.IdShort == idShort) &&
+			(semanticId == "" || submodel.SemanticId != nil && submodel.SemanticId.Keys[0].Value == semanticId) {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	submodels := []aas.Submodel{}
+	for i, id := range ids {
+		if i == limit {
+			return submodels, ids[i-1], nil
+		}
+		submodel, _ := m.GetSubmodel(ctx, id)
+		submodels = append(submodels, *submodel)
+	}
+	return submodels, "", nil
+}
+
+func (m *MockSubmodelRepository) GetSubmodel(ctx context.Context, id string) (*aas.Submodel, error) {
+	data, ok := m.submodels[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", repositories.ErrIdentifiableNotFound, id)
+	}
+	var submodel aas.Submodel
+	if err := json.Unmarshal(data, &submodel); err != nil {
+		return nil, err
+	}
+	return &submodel, nil
+}
+
+func (m *MockSubmodelRepository) CreateSubmodel(ctx context.Context, ownerID string, submodel *aas.Submodel) error {
+	if _, ok := m.submodels[submodel.Id]; ok {
+		return repositories.ErrIdentifiableExists
+	}
+	m.owners[submodel.Id] = ownerID
+	return m.store(submodel)
+}
+
+func (m *MockSubmodelRepository) UpdateSubmodel(ctx context.Context, ownerID string, id string, update func(*aas.Submodel) error) error {
+	submodel, err := m.GetSubmodel(ctx, id)
+	if err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	if err := update(submodel); err != nil {
+		return err
+	}
+	return m.store(submodel)
+}
+
+func (m *MockSubmodelRepository) DeleteSubmodel(ctx context.Context, ownerID string, id string) error {
+	if _, err := m.GetSubmodel(ctx, id); err != nil {
+		return err
+	}
+	if m.owners[id] != ownerID {
+		return repositories.ErrNotOwner
+	}
+	delete(m.submodels, id)
+	return nil
+}
+
+func (m *MockSubmodelRepository) store(submodel *aas.Submodel) error {
+	data, err := json.Marshal(submodel)
+	if err != nil {
+		return err
+	}
+	m.submodels[submodel.Id] = data
+	return nil
+}
+
+func newSubmodelRouter(handler *api.SubmodelHandler) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	smg := r.Group("/api/v1/submodels", withTestUser)
+	smg.GET("", gin.WrapF(handler.GetSubmodels))
+	smg.POST("", gin.WrapF(handler.PostSubmodel))
+	smg.GET("/:submodelIdentifier", api.WithPathValues(handler.GetSubmodel))
+	smg.PUT("/:submodelIdentifier", api.WithPathValues(handler.PutSubmodel))
+	smg.DELETE("/:submodelIdentifier", api.WithPathValues(handler.DeleteSubmodel))
+	smg.GET("/:submodelIdentifier/$metadata", api.WithPathValues(handler.GetSubmodelMetadata))
+	smg.GET("/:submodelIdentifier/$value", api.WithPathValues(handler.GetSubmodelValue))
+	smg.GET("/:submodelIdentifier/$reference", api.WithPathValues(handler.GetSubmodelReference))
+	smg.GET("/:submodelIdentifier/$path", api.WithPathValues(handler.GetSubmodelPaths))
+	smg.GET("/:submodelIdentifier/submodel-elements", api.WithPathValues(handler.GetSubmodelElements))
+	smg.POST("/:submodelIdentifier/submodel-elements", api.WithPathValues(handler.PostSubmodelElement))
+	smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath", api.WithPathValues(handler.GetSubmodelElement))
+	smg.PUT("/:submodelIdentifier/submodel-elements/:idShortPath", api.WithPathValues(handler.PutSubmodelElement))
+	smg.POST("/:submodelIdentifier/submodel-elements/:idShortPath", api.WithPathValues(handler.PostSubmodelElementAtPath))
+	smg.DELETE("/:submodelIdentifier/submodel-elements/:idShortPath", api.WithPathValues(handler.DeleteSubmodelElement))
+	smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$metadata", api.WithPathValues(handler.GetSubmodelElementMetadata))
+	smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$value", api.WithPathValues(handler.GetSubmodelElementValue))
+	smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$reference", api.WithPathValues(handler.GetSubmodelElementReference))
+	smg.GET("/:submodelIdentifier/submodel-elements/:idShortPath/$path", api.WithPathValues(handler.GetSubmodelElementPaths))
+	return r
+}
+
+func TestSubmodelAPI(t *testing.T) {
+	router := newSubmodelRouter(&api.SubmodelHandler{Repo: NewMockSubmodelRepository()})
+	nameplate := "/api/v1/submodels/" + encodeID("urn:sm:nameplate")
+	elements := nameplate + "/submodel-elements"
+
+	// Creating submodels
+	// A request whose response cannot be written stores nothing
+	assert.Equal(t, http.StatusNotAcceptable, serve(router, http.MethodPost, "/api/v1/submodels", nameplateJSON, testUserHeader, "alice", "Accept", "text/html").Code)
+	rr := serve(router, http.MethodPost, "/api/v1/submodels", nameplateJSON, testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.Equal(t, nameplate, rr.Header().Get("Location"))
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, "/api/v1/submodels", nameplateJSON, testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusUnauthorized, serve(router, http.MethodPost, "/api/v1/submodels", nameplateJSON).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, "/api/v1/submodels", `{"modelType":"Submodel","id":""}`, testUserHeader, "alice").Code)
+
+	// Listing submodels
+	var list api.GetSubmodelsResult
+	rr = serve(router, http.MethodGet, "/api/v1/submodels?semanticId="+encodeID("https://admin-shell.io/idta/nameplate/2/0/Nameplate"), "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	assert.Len(t, list.Result, 1)
+	list = api.GetSubmodelsResult{}
+	rr = serve(router, http.MethodGet, "/api/v1/submodels?semanticId="+encodeID("urn:other"), "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &list))
+	assert.Empty(t, list.Result)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/submodels?semanticId=*", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, "/api/v1/submodels?level=shallow", "").Code)
+
+	// Level and extent
+	var submodel aas.Submodel
+	rr = serve(router, http.MethodGet, nameplate, "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &submodel))
+	address := submodel.SubmodelElements[5].(*aas.SubmodelElementCollection)
+	assert.Len(t, address.Value, 2)
+	assert.Nil(t, address.Value[1].(*aas.Blob).Value)
+
+	submodel = aas.Submodel{}
+	rr = serve(router, http.MethodGet, nameplate+"?extent=withBlobValue", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &submodel))
+	assert.NotEmpty(t, submodel.SubmodelElements[5].(*aas.SubmodelElementCollection).Value[1].(*aas.Blob).Value)
+
+	submodel = aas.Submodel{}
+	rr = serve(router, http.MethodGet, nameplate+"?level=core", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &submodel))
+	assert.Len(t, submodel.SubmodelElements, 7)
+	assert.Empty(t, submodel.SubmodelElements[5].(*aas.SubmodelElementCollection).Value)
+
+	rr = serve(router, http.MethodGet, nameplate, "", "Accept", "application/xml")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `<submodel xmlns="https://admin-shell.io/aas/3/0">`)
+
+	// Modifiers of the submodel
+	rr = serve(router, http.MethodGet, nameplate+"/$metadata", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Contains(t, rr.Body.String(), `"idShort":"Nameplate"`)
+	assert.NotContains(t, rr.Body.String(), "submodelElements")
+
+	rr = serve(router, http.MethodGet, nameplate+"/$value", "")
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{
+		"ManufacturerName": "ACME",
+		"YearOfConstruction": 2024,
+		"Certified": true,
+		"ProductDesignation": [{"en": "Pump"}],
+		"Pressure": {"min": 0.5, "max": 10},
+		"Address": {"City": "Berlin", "Logo": {"contentType": "image/png"}},
+		"Markings": [{"Image": {"contentType": "image/png", "value": "/aasx/ce.png"}}]
+	}`, rr.Body.String())
+
+	rr = serve(router, http.MethodGet, nameplate+"/$reference", "")
+	assert.JSONEq(t, `{"type":"ModelReference","keys":[{"type":"Submodel","value":"urn:sm:nameplate"}]}`, rr.Body.String())
+
+	var paths []string
+	rr = serve(router, http.MethodGet, nameplate+"/$path?level=core", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &paths))
+	assert.Len(t, paths, 7)
+
+	// Elements
+	var page api.GetSubmodelElementsResult
+	rr = serve(router, http.MethodGet, elements+"?limit=2", "")
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &page))
+	assert.Len(t, page.Result, 2)
+	assert.Equal(t, "2", page.PagingMetadata.Cursor)
+
+	image := elements + "/Markings%5B0%5D.Image"
+	rr = serve(router, http.MethodGet, image, "")
+	assert.Equal(t, http.StatusOK, rr.Code, rr.Body.String())
+	assert.JSONEq(t, `{"modelType":"File","idShort":"Image","contentType":"image/png","value":"/aasx/ce.png"}`, rr.Body.String())
+	rr = serve(router, http.MethodGet, image, "", "Accept", "application/xml")
+	assert.Contains(t, rr.Body.String(), `<file xmlns="https://admin-shell.io/aas/3/0">`)
+	rr = serve(router, http.MethodGet, image+"/$reference", "")
+	assert.JSONEq(t, `{"type":"ModelReference","keys":[
+		{"type":"Submodel","value":"urn:sm:nameplate"},
+		{"type":"SubmodelElementList","value":"Markings"},
+		{"type":"SubmodelElementCollection","value":"0"},
+		{"type":"File","value":"Image"}]}`, rr.Body.String())
+
+	rr = serve(router, http.MethodGet, elements+"/YearOfConstruction/$value", "")
+	assert.JSONEq(t, "2024", rr.Body.String())
+	rr = serve(router, http.MethodGet, elements+"/Address/$metadata", "")
+	assert.JSONEq(t, `{"modelType":"SubmodelElementCollection","idShort":"Address"}`, rr.Body.String())
+	rr = serve(router, http.MethodGet, elements+"/Markings/$path", "")
+	assert.JSONEq(t, `["Markings","Markings[0]","Markings[0].Image"]`, rr.Body.String())
+
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, elements+"/Address.Street", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, elements+"/Markings%5B1%5D", "").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodGet, elements+"/Address..City", "").Code)
+
+	// Modifying elements
+	street := `{"modelType":"Property","idShort":"Street","valueType":"xs:string","value":"Main Street"}`
+	assert.Equal(t, http.StatusNotAcceptable, serve(router, http.MethodPost, elements+"/Address", street, testUserHeader, "alice", "Accept", "text/html").Code)
+	rr = serve(router, http.MethodPost, elements+"/Address", street, testUserHeader, "alice")
+	assert.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+	assert.JSONEq(t, street, rr.Body.String())
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, elements+"/Address", street, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPost, elements+"/ManufacturerName", street, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPost, elements+"/Address", street, testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNotAcceptable, serve(router, http.MethodPost, elements, street, testUserHeader, "alice", "Accept", "text/html").Code)
+	assert.Equal(t, http.StatusCreated, serve(router, http.MethodPost, elements, street, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusConflict, serve(router, http.MethodPost, elements, street, testUserHeader, "alice").Code)
+
+	manufacturer := `{"modelType":"Property","idShort":"ManufacturerName","valueType":"xs:string","value":"ACME Corp"}`
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, elements+"/ManufacturerName", manufacturer, testUserHeader, "alice").Code)
+	rr = serve(router, http.MethodGet, elements+"/ManufacturerName/$value", "")
+	assert.JSONEq(t, `"ACME Corp"`, rr.Body.String())
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, elements+"/Certified", manufacturer, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, elements+"/ManufacturerName", `{"modelType":"Unknown"}`, testUserHeader, "alice").Code)
+
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, elements+"/Address.City", "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, elements+"/Address.City", "").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodDelete, elements+"/Address.City", "", testUserHeader, "alice").Code)
+
+	// Replacing and deleting submodels
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodPut, nameplate, nameplateJSON, testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodPut, nameplate, nameplateJSON, testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusBadRequest, serve(router, http.MethodPut, nameplate, strings.Replace(nameplateJSON, "urn:sm:nameplate", "urn:sm:other", 1), testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusForbidden, serve(router, http.MethodDelete, nameplate, "", testUserHeader, "bob").Code)
+	assert.Equal(t, http.StatusNoContent, serve(router, http.MethodDelete, nameplate, "", testUserHeader, "alice").Code)
+	assert.Equal(t, http.StatusNotFound, serve(router, http.MethodGet, nameplate, "").Code)
+}
@@ -0,0 +1,161 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/aas-hub-org/aashub/internal/config"
+	"github.com/aas-hub-org/aashub/internal/database"
+	"github.com/aas-hub-org/aashub/internal/mail"
+	"github.com/aas-hub-org/aashub/internal/tracing"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// setupTracing records all spans in memory for the duration of the test.
+func setupTracing(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+	_, err := tracing.Setup(context.Background(), config.Default().Tracing)
+	assert.NoError(t, err)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := tracing.NewProvider(config.Default().Tracing, sdktrace.WithSyncer(exporter))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		provider.Shutdown(context.Background())
+	})
+	return exporter
+}
+
+func spanByName(spans tracetest.SpanStubs, name string) *tracetest.SpanStub {
+	for i := range spans {
+		if spans[i].Name == name {
+			return &spans[i]
+		}
+	}
+	return nil
+}
+
+func TestTracing_RequestSpans(t *testing.T) {
+	exporter := setupTracing(t)
+
+	cfg := config.Default().Database
+	cfg.Driver = "sqlite"
+	cfg.DSN = filepath.Join(t.TempDir(), "aashub.db")
+	db, err := database.NewDB(cfg)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	mailer := &tracing.Mailer{Mailer: &mail.MemoryMailer{From: "noreply@example.com"}}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(tracing.Middleware("aashub"))
+	router.GET("/items/:id", func(c *gin.Context) {
+		ctx := c.Request.Context()
+		var one int
+		assert.NoError(t, db.QueryRowContext(ctx, "SELECT 1 WHERE 1 = ?", 1).Scan(&one))
+		assert.NoError(t, mailer.Send(ctx, &mail.Message{To: "test@example.com", Subject: "Hello", Text: "Hello"}))
+		c.Status(http.StatusOK)
+	})
+	router.GET("/livez", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// The caller's trace is continued
+	req := httptest.NewRequest(http.MethodGet, "/items/1", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 3)
+
+	server := spanByName(spans, "/items/:id")
+	if assert.NotNil(t, server) {
+		assert.Equal(t, trace.SpanKindServer, server.SpanKind)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", server.SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", server.Parent.SpanID().String())
+	}
+
+	query := spanByName(spans, "SELECT")
+	if assert.NotNil(t, query) && server != nil {
+		assert.Equal(t, server.SpanContext.SpanID(), query.Parent.SpanID())
+		assert.Equal(t, trace.SpanKindClient, query.SpanKind)
+		assert.Contains(t, query.Attributes, attribute.String("db.system", "sqlite"))
+		assert.Contains(t, query.Attributes, attribute.String("db.query.text", "SELECT 1 WHERE 1 = ?"))
+	}
+
+	send := spanByName(spans, "mail.send")
+	if assert.NotNil(t, send) && server != nil {
+		assert.Equal(t, server.SpanContext.SpanID(), send.Parent.SpanID())
+	}
+}
+
+func TestTracing_MailerError(t *testing.T) {
+	exporter := setupTracing(t)
+
+	mailer := &tracing.Mailer{Mailer: failingMailer{}}
+	err := mailer.Send(context.Background(), &mail.Message{To: "test@example.com", Subject: "Hello", Text: "Hello"})
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+	}
+}
+
+func TestTracing_TransactionSpans(t *testing.T) {
+	exporter := setupTracing(t)
+
+	cfg := config.Default().Database
+	cfg.Driver = "sqlite"
+	cfg.DSN = filepath.Join(t.TempDir(), "aashub.db")
+	db, err := database.NewDB(cfg)
+	assert.NoError(t, err)
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err = db.ExecContext(ctx, "CREATE TABLE items (id INTEGER PRIMARY KEY, name TEXT)")
+	assert.NoError(t, err)
+	exporter.Reset()
+
+	tx, err := db.BeginTx(ctx, nil)
+	assert.NoError(t, err)
+	defer tx.Rollback()
+	_, err = tx.ExecContext(ctx, "INSERT INTO items (id, name) VALUES (?, ?)", 1, "first")
+	assert.NoError(t, err)
+
+	// The row's span only ends once the row has been read
+	row := tx.QueryRowContext(ctx, "SELECT name FROM items WHERE id = ?", 1)
+	assert.Nil(t, spanByName(exporter.GetSpans(), "SELECT"))
+	var name string
+	assert.NoError(t, row.Scan(&name))
+	assert.NoError(t, tx.Commit())
+
+	spans := exporter.GetSpans()
+	insert := spanByName(spans, "INSERT")
+	if assert.NotNil(t, insert) {
+		assert.Contains(t, insert.Attributes, attribute.String("db.query.text", "INSERT INTO items (id, name) VALUES (?, ?)"))
+	}
+	assert.NotNil(t, spanByName(spans, "SELECT"))
+
+	// Rows that do not exist are not an error of the query
+	err = db.QueryRowContext(ctx, "SELECT name FROM items WHERE id = ?", 2).Scan(&name)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	spans = exporter.GetSpans()
+	assert.Equal(t, codes.Unset, spans[len(spans)-1].Status.Code)
+}
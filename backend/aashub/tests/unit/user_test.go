@@ -5,6 +5,7 @@ package unit_test
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -16,28 +17,42 @@ import (
 	b64 "encoding/base64"
 
 	api "github.com/aas-hub-org/aashub/api/handler"
+	repositories "github.com/aas-hub-org/aashub/internal/database/repositories"
+	models "github.com/aas-hub-org/aashub/internal/models"
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 )
 
-func (m *MockRepository) RegisterUser(username, email, password string) error {
+func (m *MockRepository) RegisterUser(ctx context.Context, username, email, password, locale string) error {
 	return nil
 }
 
-func (repo *MockRepository) LoginUser(username string, password string) (string, error) {
+func (repo *MockRepository) LoginUser(ctx context.Context, username string, password string) (string, error) {
 	return "", nil
 }
 
+func (m *MockRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	return nil, repositories.ErrUserNotFound
+}
+
+func (m *MockRepository) GetByUsername(ctx context.Context, username string) (*models.User, error) {
+	return nil, repositories.ErrUserNotFound
+}
+
+func (m *MockRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	return nil, repositories.ErrUserNotFound
+}
+
 var (
 	// VerifyFunc is a package-level variable that can be overridden in tests.
 	VerifyFunc func(email, code string) (string, error)
 )
 
-func (m *MockRepository) CreateVerification(email string) (string, error) {
+func (m *MockRepository) CreateVerification(ctx context.Context, email string) (string, error) {
 	return "", nil
 }
 
-func (m *MockRepository) Verify(email, code string) (string, error) {
+func (m *MockRepository) Verify(ctx context.Context, email, code string) (string, error) {
 	if VerifyFunc != nil {
 		return VerifyFunc(email, code)
 	}
@@ -100,6 +115,34 @@ func TestRegisterUser_InvalidRequest(t *testing.T) {
 	assert.Equal(t, http.StatusBadRequest, rr.Code, "Expected status code 400")
 }
 
+func TestRegisterUser_InvalidEmail(t *testing.T) {
+	mockRepo := &MockRepository{}
+	handler := api.UserHandler{Repo: mockRepo}
+
+	user := api.APIUser{
+		Username: "testUser",
+		Email:    "test@example.com\r\nBcc: victim@example.com",
+		Password: "password123",
+	}
+	userJSON, err := json.Marshal(user)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, err := http.NewRequest("POST", "/users/register", bytes.NewBuffer(userJSON))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	rr := httptest.NewRecorder()
+	router := mux.NewRouter()
+	router.HandleFunc("/users/register", handler.RegisterUser)
+	router.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code, "Expected status code 400")
+}
+
 func TestVerifyUser_Success(t *testing.T) {
 	// Set up the mock behavior
 	originalVerifyFunc := VerifyFunc
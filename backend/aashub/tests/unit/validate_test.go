@@ -0,0 +1,297 @@
+//go:build unit
+// +build unit
+
+package unit_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	api "github.com/aas-hub-org/aashub/api/handler"
+	"github.com/aas-hub-org/aashub/internal/aas"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate_SampleFiles(t *testing.T) {
+	files, err := filepath.Glob("testdata/aas/*.json")
+	assert.NoError(t, err)
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+
+		var env aas.Environment
+		assert.NoError(t, json.Unmarshal(data, &env))
+		assert.Empty(t, aas.Validate(&env), file)
+	}
+}
+
+func TestValidate_Violations(t *testing.T) {
+	tests := []struct {
+		name       string
+		submodel   string
+		path       string
+		constraint string
+	}{
+		{
+			name:       "idShort format",
+			submodel:   `{"id":"urn:sm","idShort":"1st"}`,
+			path:       "$.submodels[0].idShort",
+			constraint: "AASd-002",
+		},
+		{
+			name:       "idShort ending with a hyphen",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Capability","idShort":"a-"}]}`,
+			path:       "$.submodels[0].submodelElements[0].idShort",
+			constraint: "AASd-002",
+		},
+		{
+			name:       "duplicate idShort",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Capability","idShort":"a"},{"modelType":"SubmodelElementCollection","idShort":"b","value":[{"modelType":"Capability","idShort":"a"},{"modelType":"Capability","idShort":"a"}]}]}`,
+			path:       "$.submodels[0].submodelElements[1].value[1].idShort",
+			constraint: "AASd-022",
+		},
+		{
+			name:       "missing idShort",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Capability"}]}`,
+			path:       "$.submodels[0].submodelElements[0].idShort",
+			constraint: "AASd-117",
+		},
+		{
+			name:       "idShort in a list",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"SubmodelElementList","idShort":"l","typeValueListElement":"Capability","value":[{"modelType":"Capability","idShort":"a"}]}]}`,
+			path:       "$.submodels[0].submodelElements[0].value[0].idShort",
+			constraint: "AASd-120",
+		},
+		{
+			name:       "list element type",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"SubmodelElementList","idShort":"l","typeValueListElement":"Blob","value":[{"modelType":"Capability"}]}]}`,
+			path:       "$.submodels[0].submodelElements[0].value[0]",
+			constraint: "AASd-108",
+		},
+		{
+			name:       "list value type",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"SubmodelElementList","idShort":"l","typeValueListElement":"Property","valueTypeListElement":"xs:int","value":[{"modelType":"Property","valueType":"xs:string"}]}]}`,
+			path:       "$.submodels[0].submodelElements[0].value[0].valueType",
+			constraint: "AASd-109",
+		},
+		{
+			name:       "list semanticId",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"SubmodelElementList","idShort":"l","typeValueListElement":"Capability","value":[{"modelType":"Capability","semanticId":{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"a"}]}},{"modelType":"Capability","semanticId":{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"b"}]}}]}]}`,
+			path:       "$.submodels[0].submodelElements[0].value[1].semanticId",
+			constraint: "AASd-114",
+		},
+		{
+			name:       "property value",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Property","idShort":"p","valueType":"xs:unsignedByte","value":"256"}]}`,
+			path:       "$.submodels[0].submodelElements[0].value",
+			constraint: "schema",
+		},
+		{
+			name:       "range maximum",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Range","idShort":"r","valueType":"xs:date","min":"2024-02-29","max":"2023-02-29"}]}`,
+			path:       "$.submodels[0].submodelElements[0].max",
+			constraint: "schema",
+		},
+		{
+			name:       "qualifier value",
+			submodel:   `{"id":"urn:sm","qualifiers":[{"type":"q","valueType":"xs:boolean","value":"yes"}]}`,
+			path:       "$.submodels[0].qualifiers[0].value",
+			constraint: "AASd-020",
+		},
+		{
+			name:       "duplicate qualifier type",
+			submodel:   `{"id":"urn:sm","qualifiers":[{"type":"q","valueType":"xs:string"},{"type":"q","valueType":"xs:string"}]}`,
+			path:       "$.submodels[0].qualifiers[1].type",
+			constraint: "AASd-021",
+		},
+		{
+			name:       "supplemental semanticId without semanticId",
+			submodel:   `{"id":"urn:sm","supplementalSemanticIds":[{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"a"}]}]}`,
+			path:       "$.submodels[0].supplementalSemanticIds",
+			constraint: "AASd-118",
+		},
+		{
+			name:       "external reference to a submodel",
+			submodel:   `{"id":"urn:sm","semanticId":{"type":"ExternalReference","keys":[{"type":"Submodel","value":"urn:other"}]}}`,
+			path:       "$.submodels[0].semanticId.keys[0].type",
+			constraint: "AASd-122",
+		},
+		{
+			name:       "model reference to a global reference",
+			submodel:   `{"id":"urn:sm","semanticId":{"type":"ModelReference","keys":[{"type":"GlobalReference","value":"urn:other"}]}}`,
+			path:       "$.submodels[0].semanticId.keys[0].type",
+			constraint: "AASd-123",
+		},
+		{
+			name:       "model reference starting with a fragment",
+			submodel:   `{"id":"urn:sm","semanticId":{"type":"ModelReference","keys":[{"type":"Property","value":"p"}]}}`,
+			path:       "$.submodels[0].semanticId.keys[0].type",
+			constraint: "AASd-121",
+		},
+		{
+			name:       "model reference with an identifiable fragment",
+			submodel:   `{"id":"urn:sm","semanticId":{"type":"ModelReference","keys":[{"type":"Submodel","value":"urn:other"},{"type":"Submodel","value":"urn:other"}]}}`,
+			path:       "$.submodels[0].semanticId.keys[1].type",
+			constraint: "AASd-125",
+		},
+		{
+			name:       "fragment reference after a property",
+			submodel:   `{"id":"urn:sm","semanticId":{"type":"ModelReference","keys":[{"type":"Submodel","value":"urn:other"},{"type":"Property","value":"p"},{"type":"FragmentReference","value":"f"}]}}`,
+			path:       "$.submodels[0].semanticId.keys[2].type",
+			constraint: "AASd-127",
+		},
+		{
+			name:       "list index",
+			submodel:   `{"id":"urn:sm","semanticId":{"type":"ModelReference","keys":[{"type":"Submodel","value":"urn:other"},{"type":"SubmodelElementList","value":"l"},{"type":"Property","value":"first"}]}}`,
+			path:       "$.submodels[0].semanticId.keys[2].value",
+			constraint: "AASd-128",
+		},
+		{
+			name:       "self-managed entity without asset",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Entity","idShort":"e","entityType":"SelfManagedEntity"}]}`,
+			path:       "$.submodels[0].submodelElements[0]",
+			constraint: "AASd-014",
+		},
+		{
+			name:       "relationship without first",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"RelationshipElement","idShort":"r","second":{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"b"}]}}]}`,
+			path:       "$.submodels[0].submodelElements[0].first",
+			constraint: "schema",
+		},
+		{
+			name:       "annotated relationship without second",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"AnnotatedRelationshipElement","idShort":"r","first":{"type":"ExternalReference","keys":[{"type":"GlobalReference","value":"a"}]}}]}`,
+			path:       "$.submodels[0].submodelElements[0].second",
+			constraint: "schema",
+		},
+		{
+			name:       "duplicate operation variable",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"Operation","idShort":"o","inputVariables":[{"value":{"modelType":"Capability","idShort":"v"}}],"outputVariables":[{"value":{"modelType":"Capability","idShort":"v"}}]}]}`,
+			path:       "$.submodels[0].submodelElements[0].outputVariables[0].value.idShort",
+			constraint: "AASd-134",
+		},
+		{
+			name:       "template qualifier in an instance",
+			submodel:   `{"id":"urn:sm","kind":"Instance","submodelElements":[{"modelType":"Capability","idShort":"c","qualifiers":[{"kind":"TemplateQualifier","type":"q","valueType":"xs:string"}]}]}`,
+			path:       "$.submodels[0].submodelElements[0].qualifiers[0].kind",
+			constraint: "AASd-129",
+		},
+		{
+			name:       "data element category",
+			submodel:   `{"id":"urn:sm","submodelElements":[{"modelType":"File","idShort":"f","category":"EVENT","contentType":"text/plain"}]}`,
+			path:       "$.submodels[0].submodelElements[0].category",
+			constraint: "AASd-090",
+		},
+		{
+			name:       "empty id",
+			submodel:   `{"id":""}`,
+			path:       "$.submodels[0].id",
+			constraint: "AASd-100",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var env aas.Environment
+			assert.NoError(t, json.Unmarshal([]byte(`{"submodels":[`+tc.submodel+`]}`), &env))
+
+			violations := aas.Validate(&env)
+			assert.True(t, hasViolation(violations, tc.path, tc.constraint), "%v", violations)
+		})
+	}
+}
+
+func TestValidate_AssetInformation(t *testing.T) {
+	env := aas.Environment{AssetAdministrationShells: []aas.AssetAdministrationShell{{
+		Identifiable:     aas.Identifiable{Id: "urn:aas"},
+		AssetInformation: aas.AssetInformation{AssetKind: aas.AssetKindInstance},
+	}}}
+
+	violations := aas.Validate(&env)
+	assert.Len(t, violations, 1)
+	assert.Equal(t, "$.assetAdministrationShells[0].assetInformation", violations[0].Path)
+	assert.Equal(t, "AASd-131", violations[0].Constraint)
+}
+
+func TestIsValidValue(t *testing.T) {
+	tests := []struct {
+		valueType aas.DataTypeDefXsd
+		value     string
+		valid     bool
+	}{
+		{aas.DataTypeDefXsdBoolean, "true", true},
+		{aas.DataTypeDefXsdBoolean, "True", false},
+		{aas.DataTypeDefXsdInt, "-2147483648", true},
+		{aas.DataTypeDefXsdInt, "2147483648", false},
+		{aas.DataTypeDefXsdUnsignedLong, "18446744073709551615", true},
+		{aas.DataTypeDefXsdNonNegativeInteger, "-1", false},
+		{aas.DataTypeDefXsdInteger, "+123456789012345678901234567890", true},
+		{aas.DataTypeDefXsdInteger, "1.0", false},
+		{aas.DataTypeDefXsdDecimal, "-.5", true},
+		{aas.DataTypeDefXsdDecimal, "1e3", false},
+		{aas.DataTypeDefXsdDouble, "1.5E-3", true},
+		{aas.DataTypeDefXsdDouble, "-INF", true},
+		{aas.DataTypeDefXsdDouble, "1e400", false},
+		{aas.DataTypeDefXsdFloat, "1e39", false},
+		{aas.DataTypeDefXsdDate, "2024-02-29Z", true},
+		{aas.DataTypeDefXsdDate, "2023-02-29", false},
+		{aas.DataTypeDefXsdDateTime, "2024-01-31T24:00:00+01:00", true},
+		{aas.DataTypeDefXsdDateTime, "2024-01-31 12:00:00", false},
+		{aas.DataTypeDefXsdTime, "12:30:00.5", true},
+		{aas.DataTypeDefXsdDuration, "P1Y2M3DT4H5M6.7S", true},
+		{aas.DataTypeDefXsdDuration, "P1YT", false},
+		{aas.DataTypeDefXsdGMonthDay, "--02-30", false},
+		{aas.DataTypeDefXsdHexBinary, "0aFF", true},
+		{aas.DataTypeDefXsdHexBinary, "abc", false},
+		{aas.DataTypeDefXsdBase64Binary, "aGVs bG8=", true},
+		{aas.DataTypeDefXsdBase64Binary, "aGVsbG8", false},
+		{aas.DataTypeDefXsdString, "", true},
+		{"xs:foo", "x", false},
+	}
+
+	for _, tc := range tests {
+		assert.Equal(t, tc.valid, aas.IsValidValue(tc.valueType, tc.value), "%s %q", tc.valueType, tc.value)
+	}
+}
+
+func TestValidationHandler(t *testing.T) {
+	handler := &api.ValidationHandler{}
+
+	body := `{"submodels":[{"modelType":"Submodel","id":"urn:sm","idShort":"1st"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/validate", bytes.NewBufferString(body))
+	rr := httptest.NewRecorder()
+	handler.Validate(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var result api.ValidationResult
+	assert.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+	assert.False(t, result.Valid)
+	assert.Len(t, result.Violations, 1)
+	assert.Equal(t, "$.submodels[0].idShort", result.Violations[0].Path)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/validate", bytes.NewBufferString(`{"submodels":[{"id":"urn:sm","submodelElements":[{"modelType":"Pump"}]}]}`))
+	rr = httptest.NewRecorder()
+	handler.Validate(rr, req)
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/api/v1/validate", bytes.NewBufferString(`{}`))
+	rr = httptest.NewRecorder()
+	handler.Validate(rr, req)
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.JSONEq(t, `{"valid":true,"violations":[]}`, rr.Body.String())
+}
+
+func hasViolation(violations []aas.Violation, path, constraint string) bool {
+	for _, violation := range violations {
+		if violation.Path == path && violation.Constraint == constraint {
+			return true
+		}
+	}
+	return false
+}
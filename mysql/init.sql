@@ -1,3 +1,7 @@
+-- The schema is owned by the migrations in backend/aashub/internal/database/migrations.
+-- The tables are created here only so the seed data below can be inserted before
+-- the hub starts. They match the schema of migration 1, which adopts them
+-- unchanged; the later migrations add the remaining columns.
 CREATE TABLE IF NOT EXISTS Users (
     id CHAR(36) PRIMARY KEY,
     username VARCHAR(255) NOT NULL UNIQUE,